@@ -0,0 +1,366 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package action provides a mapping from game actions like "jump" and "fire" to input bindings.
+//
+// A game declares actions and binds them to keys, mouse buttons, and standard gamepad buttons and axes.
+// The bindings can be changed at runtime and serialized to JSON, so players can remap the controls.
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// AxisThreshold is the threshold of a gamepad axis value to treat the axis as pressed.
+const AxisThreshold = 0.5
+
+type bindingType int
+
+const (
+	bindingTypeKey bindingType = iota
+	bindingTypeMouseButton
+	bindingTypeGamepadButton
+	bindingTypeGamepadAxis
+)
+
+// Binding represents an input that triggers an action.
+//
+// Binding implements encoding.TextMarshaler and encoding.TextUnmarshaler.
+type Binding struct {
+	typ      bindingType
+	value    int
+	negative bool
+}
+
+// KeyBinding returns a binding for the given key.
+func KeyBinding(key ebiten.Key) Binding {
+	return Binding{typ: bindingTypeKey, value: int(key)}
+}
+
+// MouseButtonBinding returns a binding for the given mouse button.
+func MouseButtonBinding(button ebiten.MouseButton) Binding {
+	return Binding{typ: bindingTypeMouseButton, value: int(button)}
+}
+
+// GamepadButtonBinding returns a binding for the given standard gamepad button.
+func GamepadButtonBinding(button ebiten.StandardGamepadButton) Binding {
+	return Binding{typ: bindingTypeGamepadButton, value: int(button)}
+}
+
+// GamepadAxisBinding returns a binding for the given standard gamepad axis.
+//
+// If negative is true, the binding is triggered when the axis value is negative, e.g., left on a horizontal axis.
+// Otherwise, the binding is triggered when the axis value is positive.
+func GamepadAxisBinding(axis ebiten.StandardGamepadAxis, negative bool) Binding {
+	return Binding{typ: bindingTypeGamepadAxis, value: int(axis), negative: negative}
+}
+
+// String returns a string representing the binding.
+func (b Binding) String() string {
+	switch b.typ {
+	case bindingTypeKey:
+		return "key:" + ebiten.Key(b.value).String()
+	case bindingTypeMouseButton:
+		return "mouse:" + strconv.Itoa(b.value)
+	case bindingTypeGamepadButton:
+		return "gamepadbutton:" + strconv.Itoa(b.value)
+	case bindingTypeGamepadAxis:
+		if b.negative {
+			return "gamepadaxis:" + strconv.Itoa(b.value) + "-"
+		}
+		return "gamepadaxis:" + strconv.Itoa(b.value) + "+"
+	default:
+		panic(fmt.Sprintf("action: invalid binding type: %d", b.typ))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b Binding) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *Binding) UnmarshalText(text []byte) error {
+	typ, value, ok := strings.Cut(string(text), ":")
+	if !ok {
+		return fmt.Errorf("action: invalid binding: %s", string(text))
+	}
+
+	switch typ {
+	case "key":
+		var k ebiten.Key
+		if err := k.UnmarshalText([]byte(value)); err != nil {
+			return err
+		}
+		*b = KeyBinding(k)
+		return nil
+	case "mouse":
+		v, err := strconv.Atoi(value)
+		if err != nil || v < 0 || v > int(ebiten.MouseButtonMax) {
+			return fmt.Errorf("action: invalid mouse button: %s", value)
+		}
+		*b = MouseButtonBinding(ebiten.MouseButton(v))
+		return nil
+	case "gamepadbutton":
+		v, err := strconv.Atoi(value)
+		if err != nil || v < 0 || v > int(ebiten.StandardGamepadButtonMax) {
+			return fmt.Errorf("action: invalid gamepad button: %s", value)
+		}
+		*b = GamepadButtonBinding(ebiten.StandardGamepadButton(v))
+		return nil
+	case "gamepadaxis":
+		var negative bool
+		switch {
+		case strings.HasSuffix(value, "+"):
+		case strings.HasSuffix(value, "-"):
+			negative = true
+		default:
+			return fmt.Errorf("action: invalid gamepad axis: %s", value)
+		}
+		v, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil || v < 0 || v > int(ebiten.StandardGamepadAxisMax) {
+			return fmt.Errorf("action: invalid gamepad axis: %s", value)
+		}
+		*b = GamepadAxisBinding(ebiten.StandardGamepadAxis(v), negative)
+		return nil
+	default:
+		return fmt.Errorf("action: invalid binding type: %s", typ)
+	}
+}
+
+// inputValue returns the binding's value in [0, 1] for the given gamepads.
+func (b Binding) inputValue(gamepadIDs []ebiten.GamepadID) float64 {
+	switch b.typ {
+	case bindingTypeKey:
+		if ebiten.IsKeyPressed(ebiten.Key(b.value)) {
+			return 1
+		}
+	case bindingTypeMouseButton:
+		if ebiten.IsMouseButtonPressed(ebiten.MouseButton(b.value)) {
+			return 1
+		}
+	case bindingTypeGamepadButton:
+		var v float64
+		for _, id := range gamepadIDs {
+			if bv := ebiten.StandardGamepadButtonValue(id, ebiten.StandardGamepadButton(b.value)); bv > v {
+				v = bv
+			}
+		}
+		return v
+	case bindingTypeGamepadAxis:
+		var v float64
+		for _, id := range gamepadIDs {
+			av := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxis(b.value))
+			if b.negative {
+				av = -av
+			}
+			if av > v {
+				v = av
+			}
+		}
+		return v
+	}
+	return 0
+}
+
+type actionState struct {
+	bindings []Binding
+	value    float64
+	pressed  bool
+	prev     bool
+}
+
+// Map maps actions to bindings.
+//
+// The zero value for Map is an empty map.
+type Map struct {
+	actions map[string]*actionState
+
+	gamepadID    ebiten.GamepadID
+	gamepadIDSet bool
+	gamepadIDs   []ebiten.GamepadID
+}
+
+// Bind adds bindings to the action.
+func (m *Map) Bind(action string, bindings ...Binding) {
+	s := m.state(action)
+	s.bindings = append(s.bindings, bindings...)
+}
+
+// SetBindings replaces the bindings of the action.
+func (m *Map) SetBindings(action string, bindings []Binding) {
+	s := m.state(action)
+	s.bindings = append(s.bindings[:0], bindings...)
+}
+
+// Bindings returns the bindings of the action.
+func (m *Map) Bindings(action string) []Binding {
+	s, ok := m.actions[action]
+	if !ok {
+		return nil
+	}
+	return append([]Binding(nil), s.bindings...)
+}
+
+// Actions returns the names of the actions in the sorted order.
+func (m *Map) Actions() []string {
+	names := make([]string, 0, len(m.actions))
+	for name := range m.actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (m *Map) state(action string) *actionState {
+	if m.actions == nil {
+		m.actions = map[string]*actionState{}
+	}
+	s, ok := m.actions[action]
+	if !ok {
+		s = &actionState{}
+		m.actions[action] = s
+	}
+	return s
+}
+
+// SetGamepadID makes the map read only the given gamepad.
+// By default, all the gamepads with the standard layout are read.
+func (m *Map) SetGamepadID(id ebiten.GamepadID) {
+	m.gamepadID = id
+	m.gamepadIDSet = true
+}
+
+// ResetGamepadID makes the map read all the gamepads with the standard layout.
+func (m *Map) ResetGamepadID() {
+	m.gamepadIDSet = false
+}
+
+// Update updates the states of the actions.
+//
+// Update must be called every tick in the game's Update before querying the actions.
+func (m *Map) Update() {
+	m.gamepadIDs = m.gamepadIDs[:0]
+	if m.gamepadIDSet {
+		m.gamepadIDs = append(m.gamepadIDs, m.gamepadID)
+	} else {
+		for _, id := range ebiten.AppendGamepadIDs(nil) {
+			if ebiten.IsStandardGamepadLayoutAvailable(id) {
+				m.gamepadIDs = append(m.gamepadIDs, id)
+			}
+		}
+	}
+
+	for _, s := range m.actions {
+		s.prev = s.pressed
+		s.value = 0
+		for _, b := range s.bindings {
+			if v := b.inputValue(m.gamepadIDs); v > s.value {
+				s.value = v
+			}
+		}
+		s.pressed = s.value >= AxisThreshold
+	}
+}
+
+// IsPressed reports whether the action is pressed.
+func (m *Map) IsPressed(action string) bool {
+	s, ok := m.actions[action]
+	if !ok {
+		return false
+	}
+	return s.pressed
+}
+
+// IsJustPressed reports whether the action started being pressed in the current tick.
+func (m *Map) IsJustPressed(action string) bool {
+	s, ok := m.actions[action]
+	if !ok {
+		return false
+	}
+	return s.pressed && !s.prev
+}
+
+// IsJustReleased reports whether the action was released in the current tick.
+func (m *Map) IsJustReleased(action string) bool {
+	s, ok := m.actions[action]
+	if !ok {
+		return false
+	}
+	return !s.pressed && s.prev
+}
+
+// Value returns the action's analog value in [0, 1].
+// Keys and mouse buttons have 0 or 1, and gamepad buttons and axes can have a value between them.
+func (m *Map) Value(action string) float64 {
+	s, ok := m.actions[action]
+	if !ok {
+		return 0
+	}
+	return s.value
+}
+
+// MarshalJSON implements json.Marshaler.
+// The result is an object whose keys are action names and values are arrays of bindings.
+func (m *Map) MarshalJSON() ([]byte, error) {
+	obj := map[string][]Binding{}
+	for name, s := range m.actions {
+		obj[name] = s.bindings
+	}
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The bindings of the actions in the data are replaced, and the other actions are kept.
+func (m *Map) UnmarshalJSON(data []byte) error {
+	var obj map[string][]Binding
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	for name, bindings := range obj {
+		m.SetBindings(name, bindings)
+	}
+	return nil
+}
+
+// JustPressedBinding returns a binding that was just pressed in the current tick.
+// JustPressedBinding is useful to let a player choose a new binding for an action.
+//
+// If there is no such binding, JustPressedBinding returns false as the second value.
+func JustPressedBinding() (Binding, bool) {
+	if keys := inpututil.AppendJustPressedKeys(nil); len(keys) > 0 {
+		return KeyBinding(keys[0]), true
+	}
+	for b := ebiten.MouseButton(0); b <= ebiten.MouseButtonMax; b++ {
+		if inpututil.IsMouseButtonJustPressed(b) {
+			return MouseButtonBinding(b), true
+		}
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		if buttons := inpututil.AppendJustPressedStandardGamepadButtons(id, nil); len(buttons) > 0 {
+			return GamepadButtonBinding(buttons[0]), true
+		}
+	}
+	return Binding{}, false
+}
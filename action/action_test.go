@@ -0,0 +1,85 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package action_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/action"
+)
+
+func TestBindingText(t *testing.T) {
+	cases := []struct {
+		Binding action.Binding
+		Text    string
+	}{
+		{action.KeyBinding(ebiten.KeySpace), "key:Space"},
+		{action.MouseButtonBinding(ebiten.MouseButtonRight), "mouse:2"},
+		{action.GamepadButtonBinding(ebiten.StandardGamepadButtonRightBottom), "gamepadbutton:0"},
+		{action.GamepadAxisBinding(ebiten.StandardGamepadAxisLeftStickHorizontal, true), "gamepadaxis:0-"},
+		{action.GamepadAxisBinding(ebiten.StandardGamepadAxisLeftStickVertical, false), "gamepadaxis:1+"},
+	}
+	for _, c := range cases {
+		text, err := c.Binding.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(text), c.Text; got != want {
+			t.Errorf("MarshalText: got: %s, want: %s", got, want)
+		}
+		var b action.Binding
+		if err := b.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if b != c.Binding {
+			t.Errorf("UnmarshalText(%q): got: %v, want: %v", c.Text, b, c.Binding)
+		}
+	}
+
+	for _, text := range []string{"", "key", "key:NoSuchKey", "mouse:100", "gamepadaxis:0", "joystick:0"} {
+		var b action.Binding
+		if err := b.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("UnmarshalText(%q) must return an error", text)
+		}
+	}
+}
+
+func TestMapJSON(t *testing.T) {
+	var m action.Map
+	m.Bind("jump", action.KeyBinding(ebiten.KeySpace), action.GamepadButtonBinding(ebiten.StandardGamepadButtonRightBottom))
+	m.Bind("fire", action.MouseButtonBinding(ebiten.MouseButtonLeft))
+
+	data, err := json.Marshal(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m2 action.Map
+	m2.Bind("jump", action.KeyBinding(ebiten.KeyW))
+	if err := json.Unmarshal(data, &m2); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := m2.Actions(), []string{"fire", "jump"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("m2.Actions(): got: %v, want: %v", got, want)
+	}
+	for _, name := range m.Actions() {
+		if got, want := m2.Bindings(name), m.Bindings(name); !reflect.DeepEqual(got, want) {
+			t.Errorf("m2.Bindings(%q): got: %v, want: %v", name, got, want)
+		}
+	}
+}
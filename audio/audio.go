@@ -72,6 +72,10 @@ type Context struct {
 
 	players map[*playerImpl]struct{}
 
+	volume  float64
+	buses   map[string]*Bus
+	volumeM sync.Mutex
+
 	m         sync.Mutex
 	semaphore chan struct{}
 }
@@ -89,6 +93,34 @@ var (
 //
 // NewContext panics when an audio context is already created.
 func NewContext(sampleRate int) *Context {
+	return NewContextWithOptions(sampleRate, nil)
+}
+
+// ContextOptions represents options for NewContextWithOptions.
+type ContextOptions struct {
+	// BufferSize is the buffer size of the underlying audio device.
+	// A smaller buffer size reduces the latency, but might cause noises on some environments.
+	//
+	// The default (zero) value is an environment-dependent value chosen by Ebitengine.
+	//
+	// On browsers, the buffer size is rounded to the nearest power of two frame count between 256 and 16384,
+	// which is the restriction of the Web Audio API.
+	//
+	// Context.OutputLatency returns the buffer size after the rounding.
+	// If BufferSize is zero, Context.OutputLatency returns the default buffer size of the platform.
+	BufferSize time.Duration
+}
+
+// NewContextWithOptions creates a new audio context with the given sample rate and options.
+//
+// If options is nil, the default options are used.
+//
+// NewContextWithOptions panics when an audio context is already created.
+func NewContextWithOptions(sampleRate int, options *ContextOptions) *Context {
+	if options == nil {
+		options = &ContextOptions{}
+	}
+
 	theContextLock.Lock()
 	defer theContextLock.Unlock()
 
@@ -98,8 +130,10 @@ func NewContext(sampleRate int) *Context {
 
 	c := &Context{
 		sampleRate:    sampleRate,
-		playerFactory: newPlayerFactory(sampleRate),
+		playerFactory: newPlayerFactory(sampleRate, adjustDeviceBufferSize(sampleRate, options.BufferSize)),
 		players:       map[*playerImpl]struct{}{},
+		volume:        1,
+		buses:         map[string]*Bus{},
 		inited:        make(chan struct{}),
 		semaphore:     make(chan struct{}, 1),
 	}
@@ -198,21 +232,31 @@ func (c *Context) removePlayer(p *playerImpl) {
 }
 
 func (c *Context) gcPlayers() error {
-	c.m.Lock()
-	defer c.m.Unlock()
+	var callbacks []func()
 
+	c.m.Lock()
 	// Now reader players cannot call removePlayers from themselves in the current implementation.
 	// Underlying playering can be the pause state after fishing its playing,
 	// but there is no way to notify this to players so far.
 	// Instead, let's check the states proactively every frame.
 	for p := range c.players {
 		if err := p.Err(); err != nil {
+			c.m.Unlock()
 			return err
 		}
 		if !p.IsPlaying() {
 			delete(c.players, p)
+			if f := p.endCallback(); f != nil {
+				callbacks = append(callbacks, f)
+			}
 		}
 	}
+	c.m.Unlock()
+
+	// Call the callbacks without locking the mutex as the callbacks might call the player's functions.
+	for _, f := range callbacks {
+		f()
+	}
 
 	return nil
 }
@@ -253,6 +297,22 @@ func (c *Context) SampleRate() int {
 	return c.sampleRate
 }
 
+// OutputLatency returns an estimation of the latency of the audio device.
+//
+// OutputLatency returns the buffer size specified at ContextOptions.BufferSize, after the rounding on browsers.
+// If the buffer size is not specified, OutputLatency returns the default buffer size of the platform.
+// On Android and Nintendo Switch, the default buffer size is chosen by the system and unknown, and then OutputLatency returns 0.
+func (c *Context) OutputLatency() time.Duration {
+	if c.playerFactory.bufferSize != 0 {
+		return c.playerFactory.bufferSize
+	}
+	return defaultDeviceBufferSize(c.sampleRate)
+}
+
+func framesToDuration(frames int, sampleRate int) time.Duration {
+	return time.Duration(frames) * time.Second / time.Duration(sampleRate)
+}
+
 func (c *Context) acquireSemaphore() {
 	c.semaphore <- struct{}{}
 }
@@ -391,6 +451,15 @@ func (p *Player) Current() time.Duration {
 	return p.p.Current()
 }
 
+// UnplayedBufferSize returns the duration of the data that has been read from the source but not played yet.
+//
+// UnplayedBufferSize doesn't include the latency of the audio device. See Context.OutputLatency for the estimation.
+// Current already subtracts UnplayedBufferSize from the read position, so Current minus the output latency is an
+// estimation of the position that is actually audible.
+func (p *Player) UnplayedBufferSize() time.Duration {
+	return p.p.UnplayedBufferSize()
+}
+
 // Volume returns the current volume of this player [0-1].
 func (p *Player) Volume() float64 {
 	return p.p.Volume()
@@ -398,10 +467,37 @@ func (p *Player) Volume() float64 {
 
 // SetVolume sets the volume of this player.
 // volume must be in between 0 and 1. SetVolume panics otherwise.
+//
+// The actual volume is the product of the player's volume, the bus's volume, and the context's master volume.
 func (p *Player) SetVolume(volume float64) {
 	p.p.SetVolume(volume)
 }
 
+// Bus returns the bus the player is attached to.
+// Bus returns nil if the player is not attached to any bus.
+func (p *Player) Bus() *Bus {
+	return p.p.Bus()
+}
+
+// SetBus attaches the player to the bus.
+// If nil is given, the player is detached from the current bus.
+//
+// SetBus panics if the bus belongs to a different context.
+func (p *Player) SetBus(bus *Bus) {
+	p.p.SetBus(bus)
+}
+
+// SetOnEnd sets a function that is called when the player stops playing by reaching the end of the stream.
+//
+// f is called on the same goroutine as the game's Update, before Update is called.
+// f is not called when the player is paused by Pause or Close.
+// If nil is given, the function set previously is removed.
+//
+// As the state is checked every tick, f might be called up to one tick later than the actual end.
+func (p *Player) SetOnEnd(f func()) {
+	p.p.SetOnEnd(f)
+}
+
 // SetBufferSize adjusts the buffer size of the player.
 // If 0 is specified, the default buffer size is used.
 // A small buffer size is useful if you want to play a real-time PCM for example.
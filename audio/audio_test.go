@@ -116,3 +116,220 @@ func TestPauseBeforeInit(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestOnEnd(t *testing.T) {
+	setup()
+	defer teardown()
+
+	p, err := context.NewPlayer(bytes.NewReader(make([]byte, 4)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var count int
+	p.SetOnEnd(func() {
+		count++
+	})
+	p.Play()
+
+	for i := 0; i < 10; i++ {
+		if err := audio.UpdateForTesting(); err != nil {
+			t.Fatal(err)
+		}
+		if count > 0 {
+			break
+		}
+		// TODO: This is a dirty hack. Would it be possible to use virtual time?
+		time.Sleep(200 * time.Millisecond)
+	}
+	if got, want := count, 1; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+
+	// The callback must not be called twice.
+	if err := audio.UpdateForTesting(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := count, 1; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+}
+
+func TestOnEndWithPause(t *testing.T) {
+	setup()
+	defer teardown()
+
+	p, err := context.NewPlayer(bytes.NewReader(make([]byte, 4)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var called bool
+	p.SetOnEnd(func() {
+		called = true
+	})
+	p.Play()
+	p.Pause()
+
+	if err := audio.UpdateForTesting(); err != nil {
+		t.Fatal(err)
+	}
+	if called {
+		t.Errorf("the callback must not be called by Pause")
+	}
+}
+
+func TestBusVolume(t *testing.T) {
+	setup()
+	defer teardown()
+
+	music := context.Bus("music")
+	if context.Bus("music") != music {
+		t.Errorf("Bus must return the same bus for the same name")
+	}
+
+	p := context.NewPlayerFromBytes(make([]byte, 4))
+	p.SetBus(music)
+	p.SetVolume(0.5)
+	if got, want := p.UnderlyingVolumeForTesting(), 0.5; got != want {
+		t.Errorf("volume: got: %f, want: %f", got, want)
+	}
+
+	music.SetVolume(0.5)
+	context.SetVolume(0.5)
+	p.Play()
+	if got, want := p.UnderlyingVolumeForTesting(), 0.125; got != want {
+		t.Errorf("volume: got: %f, want: %f", got, want)
+	}
+	if got, want := p.Volume(), 0.5; got != want {
+		t.Errorf("p.Volume(): got: %f, want: %f", got, want)
+	}
+
+	music.SetMuted(true)
+	if got, want := p.UnderlyingVolumeForTesting(), 0.0; got != want {
+		t.Errorf("volume: got: %f, want: %f", got, want)
+	}
+
+	p.SetBus(nil)
+	if got, want := p.UnderlyingVolumeForTesting(), 0.25; got != want {
+		t.Errorf("volume: got: %f, want: %f", got, want)
+	}
+}
+
+func TestCrossfade(t *testing.T) {
+	setup()
+	defer teardown()
+
+	from := context.NewPlayerFromBytes(make([]byte, 44100*4))
+	to := context.NewPlayerFromBytes(make([]byte, 44100*4))
+	from.SetVolume(0.8)
+	to.SetVolume(0.5)
+	from.Play()
+
+	audio.Crossfade(from, to, 50*time.Millisecond)
+
+	for i := 0; i < 100; i++ {
+		if !from.IsPlaying() && to.Volume() == 0.5 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if from.IsPlaying() {
+		t.Errorf("from must be paused after the crossfade")
+	}
+	if got, want := from.Volume(), 0.8; got != want {
+		t.Errorf("from.Volume(): got: %f, want: %f", got, want)
+	}
+	if got, want := to.Volume(), 0.5; got != want {
+		t.Errorf("to.Volume(): got: %f, want: %f", got, want)
+	}
+}
+
+func TestFuncStream(t *testing.T) {
+	var calls int
+	s := audio.NewFuncStreamForTesting(func(buf []float64) {
+		calls++
+		for i := range buf {
+			if i%2 == 0 {
+				buf[i] = 0.5
+			} else {
+				// Out of the range
+				buf[i] = -2
+			}
+		}
+	})
+
+	// A partial sample is not filled.
+	b := make([]byte, 10)
+	n, err := s.Read(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := n, 8; got != want {
+		t.Errorf("n: got: %d, want: %d", got, want)
+	}
+	if calls != 1 {
+		t.Errorf("calls: got: %d, want: 1", calls)
+	}
+	for i := 0; i < n; i += 4 {
+		l := int16(b[i]) | int16(b[i+1])<<8
+		r := int16(b[i+2]) | int16(b[i+3])<<8
+		if want := int16(16383); l != want {
+			t.Errorf("left: got: %d, want: %d", l, want)
+		}
+		if want := int16(-(1<<15 - 1)); r != want {
+			t.Errorf("right: got: %d, want: %d", r, want)
+		}
+	}
+}
+
+func TestOutputLatency(t *testing.T) {
+	setup()
+	if got, want := context.OutputLatency(), audio.DefaultDeviceBufferSizeForTesting(44100); got != want {
+		t.Errorf("OutputLatency(): got: %v, want: %v", got, want)
+	}
+	if runtime.GOOS != "android" && context.OutputLatency() <= 0 {
+		t.Errorf("OutputLatency() must be positive but %v", context.OutputLatency())
+	}
+	teardown()
+
+	c := audio.NewContextWithOptions(44100, &audio.ContextOptions{BufferSize: 100 * time.Millisecond})
+	defer teardown()
+
+	want := 100 * time.Millisecond
+	if runtime.GOOS == "js" {
+		// On browsers, the buffer size is rounded to a power of two frame count.
+		want = (4096*time.Second + 44100 - 1) / 44100
+	}
+	if got := c.OutputLatency(); got != want {
+		t.Errorf("OutputLatency(): got: %v, want: %v", got, want)
+	}
+}
+
+func TestUnplayedBufferSize(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// One second of data.
+	p := context.NewPlayerFromBytes(make([]byte, 44100*4))
+	if got, want := p.UnplayedBufferSize(), time.Duration(0); got != want {
+		t.Errorf("UnplayedBufferSize() before Play: got: %v, want: %v", got, want)
+	}
+
+	p.Play()
+	for i := 0; i < 10 && p.IsPlaying(); i++ {
+		if err := audio.UpdateForTesting(); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// All the data is read from the source, but 0.1 second of it is not played yet.
+	p.SetUnderlyingUnplayedBufferSizeForTesting(44100 * 4 / 10)
+	if got, want := p.UnplayedBufferSize(), 100*time.Millisecond; got != want {
+		t.Errorf("UnplayedBufferSize(): got: %v, want: %v", got, want)
+	}
+	if got, want := p.Current(), 900*time.Millisecond; got != want {
+		t.Errorf("Current(): got: %v, want: %v", got, want)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"fmt"
+)
+
+// Bus represents a named group of players like "music", "sfx", or "voice".
+// A bus has its own volume and mute state, which are applied to all the players attached to the bus.
+//
+// A bus is created by (*Context).Bus, and a player is attached to a bus by (*Player).SetBus.
+type Bus struct {
+	context *Context
+	name    string
+	volume  float64
+	muted   bool
+}
+
+// Bus returns the bus with the given name.
+// If the bus doesn't exist, Bus creates a new bus with volume 1.
+//
+// Bus is concurrent-safe.
+func (c *Context) Bus(name string) *Bus {
+	c.volumeM.Lock()
+	defer c.volumeM.Unlock()
+
+	if b, ok := c.buses[name]; ok {
+		return b
+	}
+	b := &Bus{
+		context: c,
+		name:    name,
+		volume:  1,
+	}
+	c.buses[name] = b
+	return b
+}
+
+// Volume returns the master volume of the context [0-1].
+//
+// Volume is concurrent-safe.
+func (c *Context) Volume() float64 {
+	c.volumeM.Lock()
+	defer c.volumeM.Unlock()
+	return c.volume
+}
+
+// SetVolume sets the master volume of the context.
+// The master volume is applied to all the players in addition to the players' and the buses' volumes.
+// volume must be in between 0 and 1. SetVolume panics otherwise.
+//
+// SetVolume is concurrent-safe.
+func (c *Context) SetVolume(volume float64) {
+	checkVolume(volume)
+
+	c.volumeM.Lock()
+	c.volume = volume
+	c.volumeM.Unlock()
+
+	c.updatePlayerVolumes()
+}
+
+// gain returns the gain applied to a player attached to the given bus.
+func (c *Context) gain(bus *Bus) float64 {
+	c.volumeM.Lock()
+	defer c.volumeM.Unlock()
+
+	g := c.volume
+	if bus != nil {
+		if bus.muted {
+			return 0
+		}
+		g *= bus.volume
+	}
+	return g
+}
+
+// updatePlayerVolumes updates the volumes of the playing players.
+// Paused players' volumes are updated when they start playing.
+func (c *Context) updatePlayerVolumes() {
+	c.m.Lock()
+	players := make([]*playerImpl, 0, len(c.players))
+	for p := range c.players {
+		players = append(players, p)
+	}
+	c.m.Unlock()
+
+	// Update the volumes without locking the mutex as gcPlayers locks the players' mutexes with the mutex locked.
+	for _, p := range players {
+		p.updateVolume()
+	}
+}
+
+// Name returns the bus name.
+func (b *Bus) Name() string {
+	return b.name
+}
+
+// Volume returns the volume of the bus [0-1].
+//
+// Volume is concurrent-safe.
+func (b *Bus) Volume() float64 {
+	b.context.volumeM.Lock()
+	defer b.context.volumeM.Unlock()
+	return b.volume
+}
+
+// SetVolume sets the volume of the bus.
+// volume must be in between 0 and 1. SetVolume panics otherwise.
+//
+// SetVolume is concurrent-safe.
+func (b *Bus) SetVolume(volume float64) {
+	checkVolume(volume)
+
+	b.context.volumeM.Lock()
+	b.volume = volume
+	b.context.volumeM.Unlock()
+
+	b.context.updatePlayerVolumes()
+}
+
+// IsMuted reports whether the bus is muted.
+//
+// IsMuted is concurrent-safe.
+func (b *Bus) IsMuted() bool {
+	b.context.volumeM.Lock()
+	defer b.context.volumeM.Unlock()
+	return b.muted
+}
+
+// SetMuted sets whether the bus is muted.
+// The players attached to a muted bus keep playing without sound.
+//
+// SetMuted is concurrent-safe.
+func (b *Bus) SetMuted(muted bool) {
+	b.context.volumeM.Lock()
+	b.muted = muted
+	b.context.volumeM.Unlock()
+
+	b.context.updatePlayerVolumes()
+}
+
+func checkVolume(volume float64) {
+	if volume < 0 || volume > 1 {
+		panic(fmt.Sprintf("audio: volume must be in between 0 and 1 but was %f", volume))
+	}
+}
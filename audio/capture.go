@@ -0,0 +1,169 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// Capture is a stream of PCM recorded from the default audio input device like a microphone.
+//
+// The format is the same as a Player's source: signed 16bits little endian, 2 channel stereo
+// at the sample rate of the audio context.
+type Capture struct {
+	c io.ReadCloser
+}
+
+// NewCapture starts recording from the default audio input device.
+//
+// Read on the returned Capture blocks until recorded data is available.
+// If the recorded data is not read for a while, the oldest data is discarded.
+//
+// NewCapture uses these APIs:
+//
+//   - Browsers: getUserMedia
+//   - Windows: WASAPI
+//   - macOS and iOS: Audio Queue Services
+//   - Linux and other Unix-like systems: ALSA
+//   - Android: AAudio (Android 8.0 or later)
+//
+// On browsers, the browser might ask the user for the permission, and NewCapture blocks until the user answers.
+// Then, NewCapture must not be called on the main thread or from JavaScript callbacks.
+// On macOS and iOS, the application must have NSMicrophoneUsageDescription in its Info.plist.
+// On iOS, the audio session's category is changed to allow recording.
+// On Android, the application must have the RECORD_AUDIO permission.
+//
+// NewCapture returns an error when capturing is not available, e.g., when there is no input device,
+// the permission is not granted, or the environment is Nintendo Switch or the headless mode.
+func (c *Context) NewCapture() (*Capture, error) {
+	impl, err := newCapture(c.sampleRate)
+	if err != nil {
+		return nil, err
+	}
+	return &Capture{c: impl}, nil
+}
+
+// Read reads the recorded PCM.
+//
+// Read returns io.EOF after Close is called and all the recorded data is consumed.
+// If recording stops due to an error like disconnecting the device, Read returns the error instead of io.EOF.
+func (c *Capture) Read(buf []byte) (int, error) {
+	return c.c.Read(buf)
+}
+
+// Close stops recording and releases the input device.
+func (c *Capture) Close() error {
+	return c.c.Close()
+}
+
+// captureBuffer is a buffer of recorded PCM shared by the platform-specific implementations.
+//
+// captureBuffer keeps at most one second of unread data and discards the oldest data when it overflows.
+type captureBuffer struct {
+	buf    []byte
+	maxBuf int
+	closed bool
+	err    error
+
+	// closedByUser is true when close is called.
+	// closed can be true without closedByUser when recording stops due to an error.
+	closedByUser bool
+
+	cond *sync.Cond
+}
+
+func newCaptureBuffer(sampleRate int) *captureBuffer {
+	return &captureBuffer{
+		maxBuf: sampleRate * bytesPerSample,
+		cond:   sync.NewCond(&sync.Mutex{}),
+	}
+}
+
+// write appends the recorded PCM in the format of signed 16bits little endian, 2 channel stereo.
+func (c *captureBuffer) write(pcm []byte) {
+	c.cond.L.Lock()
+	defer c.cond.L.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	c.buf = append(c.buf, pcm...)
+
+	// Discard the oldest data when the buffer is not consumed.
+	if l := len(c.buf); l > c.maxBuf {
+		d := l - c.maxBuf
+		d += (bytesPerSample - d%bytesPerSample) % bytesPerSample
+		c.buf = c.buf[:copy(c.buf, c.buf[d:])]
+	}
+
+	c.cond.Signal()
+}
+
+func (c *captureBuffer) Read(buf []byte) (int, error) {
+	c.cond.L.Lock()
+	defer c.cond.L.Unlock()
+
+	for len(c.buf) == 0 && !c.closed {
+		c.cond.Wait()
+	}
+	if len(c.buf) == 0 {
+		if c.err != nil {
+			return 0, c.err
+		}
+		return 0, io.EOF
+	}
+
+	n := copy(buf, c.buf)
+	c.buf = c.buf[:copy(c.buf, c.buf[n:])]
+	return n, nil
+}
+
+// close marks the buffer as closed. close returns an error if close is already called.
+func (c *captureBuffer) close() error {
+	c.cond.L.Lock()
+	defer c.cond.L.Unlock()
+
+	if c.closedByUser {
+		return errors.New("audio: the capture is already closed")
+	}
+	c.closedByUser = true
+	c.closed = true
+	c.cond.Broadcast()
+	return nil
+}
+
+// closeWithError closes the buffer due to an error in recording.
+// Read returns err after all the recorded data is consumed.
+func (c *captureBuffer) closeWithError(err error) {
+	c.cond.L.Lock()
+	defer c.cond.L.Unlock()
+
+	if c.closed {
+		return
+	}
+	c.closed = true
+	c.err = err
+	c.cond.Broadcast()
+}
+
+// isClosed reports whether the buffer is closed.
+func (c *captureBuffer) isClosed() bool {
+	c.cond.L.Lock()
+	defer c.cond.L.Unlock()
+	return c.closed
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ebitengineheadless
+
+package audio
+
+// AAudio is loaded dynamically since it is available only on Android 8.0 (API level 26) or later.
+
+// #cgo LDFLAGS: -ldl
+//
+// #include <dlfcn.h>
+// #include <stdint.h>
+// #include <stdlib.h>
+//
+// typedef struct AAudioStreamBuilder AAudioStreamBuilder;
+// typedef struct AAudioStream AAudioStream;
+//
+// typedef int32_t (*createStreamBuilderFunc)(AAudioStreamBuilder** builder);
+// typedef void (*builderSetInt32Func)(AAudioStreamBuilder* builder, int32_t value);
+// typedef int32_t (*builderOpenStreamFunc)(AAudioStreamBuilder* builder, AAudioStream** stream);
+// typedef int32_t (*builderDeleteFunc)(AAudioStreamBuilder* builder);
+// typedef int32_t (*streamFunc)(AAudioStream* stream);
+// typedef int32_t (*streamReadFunc)(AAudioStream* stream, void* buffer, int32_t numFrames, int64_t timeoutNanoseconds);
+//
+// static void* aaudio;
+// static createStreamBuilderFunc pAAudio_createStreamBuilder;
+// static builderSetInt32Func pAAudioStreamBuilder_setDirection;
+// static builderSetInt32Func pAAudioStreamBuilder_setFormat;
+// static builderSetInt32Func pAAudioStreamBuilder_setChannelCount;
+// static builderSetInt32Func pAAudioStreamBuilder_setSampleRate;
+// static builderOpenStreamFunc pAAudioStreamBuilder_openStream;
+// static builderDeleteFunc pAAudioStreamBuilder_delete;
+// static streamFunc pAAudioStream_requestStart;
+// static streamFunc pAAudioStream_requestStop;
+// static streamFunc pAAudioStream_close;
+// static streamFunc pAAudioStream_getSampleRate;
+// static streamReadFunc pAAudioStream_read;
+//
+// static int loadAAudio() {
+//   if (aaudio) {
+//     return 1;
+//   }
+//   void* lib = dlopen("libaaudio.so", RTLD_NOW);
+//   if (!lib) {
+//     return 0;
+//   }
+//   pAAudio_createStreamBuilder = (createStreamBuilderFunc)dlsym(lib, "AAudio_createStreamBuilder");
+//   pAAudioStreamBuilder_setDirection = (builderSetInt32Func)dlsym(lib, "AAudioStreamBuilder_setDirection");
+//   pAAudioStreamBuilder_setFormat = (builderSetInt32Func)dlsym(lib, "AAudioStreamBuilder_setFormat");
+//   pAAudioStreamBuilder_setChannelCount = (builderSetInt32Func)dlsym(lib, "AAudioStreamBuilder_setChannelCount");
+//   pAAudioStreamBuilder_setSampleRate = (builderSetInt32Func)dlsym(lib, "AAudioStreamBuilder_setSampleRate");
+//   pAAudioStreamBuilder_openStream = (builderOpenStreamFunc)dlsym(lib, "AAudioStreamBuilder_openStream");
+//   pAAudioStreamBuilder_delete = (builderDeleteFunc)dlsym(lib, "AAudioStreamBuilder_delete");
+//   pAAudioStream_requestStart = (streamFunc)dlsym(lib, "AAudioStream_requestStart");
+//   pAAudioStream_requestStop = (streamFunc)dlsym(lib, "AAudioStream_requestStop");
+//   pAAudioStream_close = (streamFunc)dlsym(lib, "AAudioStream_close");
+//   pAAudioStream_getSampleRate = (streamFunc)dlsym(lib, "AAudioStream_getSampleRate");
+//   pAAudioStream_read = (streamReadFunc)dlsym(lib, "AAudioStream_read");
+//   aaudio = lib;
+//   return 1;
+// }
+//
+// // openInputStream opens a 16bit stereo input stream.
+// // openInputStream returns an AAudio result code, which is negative on failure.
+// static int32_t openInputStream(int32_t sampleRate, AAudioStream** stream) {
+//   AAudioStreamBuilder* builder;
+//   int32_t result = pAAudio_createStreamBuilder(&builder);
+//   if (result < 0) {
+//     return result;
+//   }
+//   pAAudioStreamBuilder_setDirection(builder, 1);    // AAUDIO_DIRECTION_INPUT
+//   pAAudioStreamBuilder_setFormat(builder, 1);       // AAUDIO_FORMAT_PCM_I16
+//   pAAudioStreamBuilder_setChannelCount(builder, 2);
+//   pAAudioStreamBuilder_setSampleRate(builder, sampleRate);
+//   result = pAAudioStreamBuilder_openStream(builder, stream);
+//   pAAudioStreamBuilder_delete(builder);
+//   return result;
+// }
+//
+// static int32_t streamGetSampleRate(AAudioStream* stream) {
+//   return pAAudioStream_getSampleRate(stream);
+// }
+//
+// static int32_t streamRequestStart(AAudioStream* stream) {
+//   return pAAudioStream_requestStart(stream);
+// }
+//
+// static int32_t streamRequestStop(AAudioStream* stream) {
+//   return pAAudioStream_requestStop(stream);
+// }
+//
+// static int32_t streamClose(AAudioStream* stream) {
+//   return pAAudioStream_close(stream);
+// }
+//
+// static int32_t streamRead(AAudioStream* stream, void* buffer, int32_t numFrames, int64_t timeoutNanoseconds) {
+//   return pAAudioStream_read(stream, buffer, numFrames, timeoutNanoseconds);
+// }
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"unsafe"
+)
+
+const (
+	// captureAAudioReadFrames is the maximum number of frames read at once.
+	captureAAudioReadFrames = 1024
+
+	// captureAAudioReadTimeout is the timeout to wait for the recorded data.
+	// The loop checks whether the capture is closed at this interval at least.
+	captureAAudioReadTimeout = 100 * time.Millisecond
+)
+
+type captureImpl struct {
+	stream *C.AAudioStream
+	buf    *captureBuffer
+	done   chan struct{}
+}
+
+func newCapture(sampleRate int) (io.ReadCloser, error) {
+	if C.loadAAudio() == 0 {
+		return nil, errors.New("audio: capturing requires Android 8.0 (API level 26) or later")
+	}
+
+	c := &captureImpl{
+		buf:  newCaptureBuffer(sampleRate),
+		done: make(chan struct{}),
+	}
+	if result := C.openInputStream(C.int32_t(sampleRate), &c.stream); result < 0 {
+		return nil, fmt.Errorf("audio: AAudioStreamBuilder_openStream failed: %d", result)
+	}
+	// The sample rate might be different from the requested one, and then no conversion is done.
+	if rate := int(C.streamGetSampleRate(c.stream)); rate != sampleRate {
+		C.streamClose(c.stream)
+		return nil, fmt.Errorf("audio: the sample rate %d is not supported by the input device (%d)", sampleRate, rate)
+	}
+	if result := C.streamRequestStart(c.stream); result < 0 {
+		C.streamClose(c.stream)
+		return nil, fmt.Errorf("audio: AAudioStream_requestStart failed: %d", result)
+	}
+
+	go c.loop()
+	return c, nil
+}
+
+func (c *captureImpl) loop() {
+	defer close(c.done)
+
+	// The buffer is allocated by C so that C code can write it without restrictions.
+	p := C.malloc(captureAAudioReadFrames * bytesPerSample)
+	defer C.free(p)
+	tmp := unsafe.Slice((*byte)(p), captureAAudioReadFrames*bytesPerSample)
+
+	for !c.buf.isClosed() {
+		n := C.streamRead(c.stream, p, captureAAudioReadFrames, C.int64_t(captureAAudioReadTimeout))
+		if n < 0 {
+			c.buf.closeWithError(fmt.Errorf("audio: AAudioStream_read failed: %d", n))
+			return
+		}
+		if n > 0 {
+			c.buf.write(tmp[:int(n)*bytesPerSample])
+		}
+	}
+}
+
+func (c *captureImpl) Read(buf []byte) (int, error) {
+	return c.buf.Read(buf)
+}
+
+func (c *captureImpl) Close() error {
+	if err := c.buf.close(); err != nil {
+		return err
+	}
+	// Wait for the loop to stop reading the stream.
+	<-c.done
+	C.streamRequestStop(c.stream)
+	C.streamClose(c.stream)
+	return nil
+}
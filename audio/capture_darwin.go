@@ -0,0 +1,189 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ebitengineheadless
+
+package audio
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+)
+
+const (
+	kAudioFormatLinearPCM               = 0x6C70636D // 'lpcm'
+	kAudioFormatFlagIsSignedInteger     = 1 << 2
+	kAudioFormatFlagIsPacked            = 1 << 3
+	captureAudioQueueBufferCount        = 3
+	captureAudioQueueBufferSizeInFrames = 2048
+)
+
+type audioStreamBasicDescription struct {
+	mSampleRate       float64
+	mFormatID         uint32
+	mFormatFlags      uint32
+	mBytesPerPacket   uint32
+	mFramesPerPacket  uint32
+	mBytesPerFrame    uint32
+	mChannelsPerFrame uint32
+	mBitsPerChannel   uint32
+	mReserved         uint32
+}
+
+type audioQueueRef uintptr
+
+type audioQueueBuffer struct {
+	mAudioDataBytesCapacity    uint32
+	mAudioData                 unsafe.Pointer // void*
+	mAudioDataByteSize         uint32
+	mUserData                  uintptr // void*
+	mPacketDescriptionCapacity uint32
+	mPacketDescriptions        uintptr // AudioStreamPacketDescription*
+	mPacketDescriptionCount    uint32
+}
+
+var (
+	_AudioQueueNewInput       func(inFormat *audioStreamBasicDescription, inCallbackProc uintptr, inUserData uintptr, inCallbackRunLoop uintptr, inCallbackRunLoopMode uintptr, inFlags uint32, outAQ *audioQueueRef) int32
+	_AudioQueueAllocateBuffer func(inAQ audioQueueRef, inBufferByteSize uint32, outBuffer **audioQueueBuffer) int32
+	_AudioQueueEnqueueBuffer  func(inAQ audioQueueRef, inBuffer *audioQueueBuffer, inNumPacketDescs uint32, inPacketDescs uintptr) int32
+	_AudioQueueStart          func(inAQ audioQueueRef, inStartTime uintptr) int32
+	_AudioQueueStop           func(inAQ audioQueueRef, inImmediate bool) int32
+	_AudioQueueDispose        func(inAQ audioQueueRef, inImmediate bool) int32
+)
+
+var (
+	audioToolboxOnce  sync.Once
+	captureCallback   uintptr
+	capturesM         sync.Mutex
+	captures                  = map[uintptr]*captureImpl{}
+	nextCaptureUserID uintptr = 1
+)
+
+func initAudioToolbox() {
+	toolbox := purego.Dlopen("/System/Library/Frameworks/AudioToolbox.framework/AudioToolbox", purego.RTLD_LAZY|purego.RTLD_GLOBAL)
+	purego.RegisterLibFunc(&_AudioQueueNewInput, toolbox, "AudioQueueNewInput")
+	purego.RegisterLibFunc(&_AudioQueueAllocateBuffer, toolbox, "AudioQueueAllocateBuffer")
+	purego.RegisterLibFunc(&_AudioQueueEnqueueBuffer, toolbox, "AudioQueueEnqueueBuffer")
+	purego.RegisterLibFunc(&_AudioQueueStart, toolbox, "AudioQueueStart")
+	purego.RegisterLibFunc(&_AudioQueueStop, toolbox, "AudioQueueStop")
+	purego.RegisterLibFunc(&_AudioQueueDispose, toolbox, "AudioQueueDispose")
+
+	// The number of callbacks purego can create is limited. Create only one callback and dispatch it by the user data.
+	captureCallback = purego.NewCallback(onCaptureInput)
+}
+
+type captureImpl struct {
+	queue  audioQueueRef
+	userID uintptr
+	buf    *captureBuffer
+}
+
+func newCapture(sampleRate int) (io.ReadCloser, error) {
+	audioToolboxOnce.Do(initAudioToolbox)
+
+	if err := prepareAudioSessionForCapture(); err != nil {
+		return nil, err
+	}
+
+	c := &captureImpl{
+		buf: newCaptureBuffer(sampleRate),
+	}
+
+	capturesM.Lock()
+	c.userID = nextCaptureUserID
+	nextCaptureUserID++
+	captures[c.userID] = c
+	capturesM.Unlock()
+
+	desc := audioStreamBasicDescription{
+		mSampleRate:       float64(sampleRate),
+		mFormatID:         kAudioFormatLinearPCM,
+		mFormatFlags:      kAudioFormatFlagIsSignedInteger | kAudioFormatFlagIsPacked,
+		mBytesPerPacket:   bytesPerSample,
+		mFramesPerPacket:  1,
+		mBytesPerFrame:    bytesPerSample,
+		mChannelsPerFrame: channelCount,
+		mBitsPerChannel:   bitDepthInBytes * 8,
+	}
+	if osstatus := _AudioQueueNewInput(&desc, captureCallback, c.userID, 0, 0, 0, &c.queue); osstatus != 0 {
+		c.unregister()
+		return nil, fmt.Errorf("audio: AudioQueueNewInput failed: %d", osstatus)
+	}
+
+	for i := 0; i < captureAudioQueueBufferCount; i++ {
+		var buf *audioQueueBuffer
+		if osstatus := _AudioQueueAllocateBuffer(c.queue, captureAudioQueueBufferSizeInFrames*bytesPerSample, &buf); osstatus != 0 {
+			c.dispose()
+			return nil, fmt.Errorf("audio: AudioQueueAllocateBuffer failed: %d", osstatus)
+		}
+		if osstatus := _AudioQueueEnqueueBuffer(c.queue, buf, 0, 0); osstatus != 0 {
+			c.dispose()
+			return nil, fmt.Errorf("audio: AudioQueueEnqueueBuffer failed: %d", osstatus)
+		}
+	}
+
+	if osstatus := _AudioQueueStart(c.queue, 0); osstatus != 0 {
+		c.dispose()
+		return nil, fmt.Errorf("audio: AudioQueueStart failed: %d", osstatus)
+	}
+
+	return c, nil
+}
+
+func onCaptureInput(inUserData uintptr, inAQ audioQueueRef, inBuffer *audioQueueBuffer, inStartTime uintptr, inNumberPacketDescriptions uint32, inPacketDescs uintptr) {
+	capturesM.Lock()
+	c := captures[inUserData]
+	capturesM.Unlock()
+	if c == nil {
+		return
+	}
+	if c.buf.isClosed() {
+		return
+	}
+
+	if inBuffer.mAudioDataByteSize > 0 {
+		c.buf.write(unsafe.Slice((*byte)(inBuffer.mAudioData), inBuffer.mAudioDataByteSize))
+	}
+	// Reuse the buffer for the next input.
+	_AudioQueueEnqueueBuffer(inAQ, inBuffer, 0, 0)
+}
+
+func (c *captureImpl) unregister() {
+	capturesM.Lock()
+	defer capturesM.Unlock()
+	delete(captures, c.userID)
+}
+
+func (c *captureImpl) dispose() {
+	// AudioQueueDispose also disposes the buffers.
+	_AudioQueueStop(c.queue, true)
+	_AudioQueueDispose(c.queue, true)
+	c.unregister()
+}
+
+func (c *captureImpl) Read(buf []byte) (int, error) {
+	return c.buf.Read(buf)
+}
+
+func (c *captureImpl) Close() error {
+	if err := c.buf.close(); err != nil {
+		return err
+	}
+	c.dispose()
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ebitengineheadless
+
+package audio_test
+
+import (
+	"testing"
+)
+
+func TestNewCaptureHeadless(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := context.NewCapture()
+	if err == nil {
+		t.Errorf("NewCapture must return an error in the headless mode")
+	}
+	if c != nil {
+		t.Errorf("NewCapture must return nil in the headless mode but %v", c)
+	}
+}
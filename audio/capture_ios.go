@@ -0,0 +1,53 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ebitengineheadless
+
+package audio
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+	"github.com/ebitengine/purego/objc"
+)
+
+const (
+	avAudioSessionCategoryOptionMixWithOthers    = 0x1
+	avAudioSessionCategoryOptionAllowBluetooth   = 0x4
+	avAudioSessionCategoryOptionDefaultToSpeaker = 0x8
+)
+
+// prepareAudioSessionForCapture changes the audio session's category to PlayAndRecord to enable recording.
+//
+// DefaultToSpeaker is specified so that the playing sounds are not routed to the receiver.
+func prepareAudioSessionForCapture() error {
+	avfoundation := purego.Dlopen("/System/Library/Frameworks/AVFoundation.framework/AVFoundation", purego.RTLD_LAZY|purego.RTLD_GLOBAL)
+	if avfoundation == 0 {
+		return errors.New("audio: AVFoundation is not available")
+	}
+	sym := purego.Dlsym(avfoundation, "AVAudioSessionCategoryPlayAndRecord")
+	if sym == 0 {
+		return errors.New("audio: AVAudioSessionCategoryPlayAndRecord is not available")
+	}
+	category := *(*objc.ID)(unsafe.Pointer(sym))
+
+	session := objc.ID(objc.GetClass("AVAudioSession")).Send(objc.RegisterName("sharedInstance"))
+	options := avAudioSessionCategoryOptionMixWithOthers | avAudioSessionCategoryOptionAllowBluetooth | avAudioSessionCategoryOptionDefaultToSpeaker
+	if !objc.Send[bool](session, objc.RegisterName("setCategory:withOptions:error:"), category, uintptr(options), uintptr(0)) {
+		return errors.New("audio: setting the audio session's category failed")
+	}
+	return nil
+}
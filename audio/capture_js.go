@@ -0,0 +1,175 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ebitengineheadless
+
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"syscall/js"
+)
+
+// captureBufferSize is the number of frames passed to the ScriptProcessorNode's callback at once.
+const captureBufferSize = 4096
+
+type captureImpl struct {
+	stream    js.Value
+	context   js.Value
+	source    js.Value
+	processor js.Value
+	onProcess js.Func
+
+	buf *captureBuffer
+	tmp [channelCount][]byte
+	pcm []byte
+}
+
+func newCapture(sampleRate int) (io.ReadCloser, error) {
+	mediaDevices := js.Global().Get("navigator").Get("mediaDevices")
+	if !mediaDevices.Truthy() {
+		return nil, errors.New("audio: navigator.mediaDevices is not available")
+	}
+
+	class := js.Global().Get("AudioContext")
+	if !class.Truthy() {
+		class = js.Global().Get("webkitAudioContext")
+	}
+	if !class.Truthy() {
+		return nil, errors.New("audio: AudioContext is not available")
+	}
+
+	stream, err := getUserMedia(mediaDevices)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &captureImpl{
+		stream: stream,
+		buf:    newCaptureBuffer(sampleRate),
+	}
+
+	c.context = class.New(map[string]any{
+		"sampleRate": sampleRate,
+	})
+	c.source = c.context.Call("createMediaStreamSource", stream)
+	c.processor = c.context.Call("createScriptProcessor", captureBufferSize, channelCount, channelCount)
+	c.onProcess = js.FuncOf(func(this js.Value, args []js.Value) any {
+		c.appendBuffer(args[0].Get("inputBuffer"))
+		return nil
+	})
+	c.processor.Set("onaudioprocess", c.onProcess)
+	c.source.Call("connect", c.processor)
+	// A ScriptProcessorNode doesn't process anything unless it is connected to the destination.
+	// As the output buffer is never filled, nothing is actually played.
+	c.processor.Call("connect", c.context.Get("destination"))
+
+	return c, nil
+}
+
+func getUserMedia(mediaDevices js.Value) (js.Value, error) {
+	type result struct {
+		stream js.Value
+		err    error
+	}
+	ch := make(chan result, 1)
+
+	var then, catch js.Func
+	then = js.FuncOf(func(this js.Value, args []js.Value) any {
+		then.Release()
+		catch.Release()
+		ch <- result{stream: args[0]}
+		return nil
+	})
+	catch = js.FuncOf(func(this js.Value, args []js.Value) any {
+		then.Release()
+		catch.Release()
+		ch <- result{err: fmt.Errorf("audio: getUserMedia failed: %s", args[0].Call("toString").String())}
+		return nil
+	})
+	mediaDevices.Call("getUserMedia", map[string]any{
+		"audio": true,
+	}).Call("then", then).Call("catch", catch)
+
+	r := <-ch
+	return r.stream, r.err
+}
+
+func (c *captureImpl) appendBuffer(buffer js.Value) {
+	if c.buf.isClosed() {
+		return
+	}
+
+	n := buffer.Get("length").Int()
+	chs := buffer.Get("numberOfChannels").Int()
+	if n == 0 || chs == 0 {
+		return
+	}
+
+	for i := range c.tmp {
+		// A monaural input is copied to both channels.
+		ch := i
+		if ch >= chs {
+			ch = chs - 1
+		}
+		data := buffer.Call("getChannelData", ch)
+		bs := js.Global().Get("Uint8Array").New(data.Get("buffer"), data.Get("byteOffset"), data.Get("byteLength"))
+		if len(c.tmp[i]) < n*4 {
+			c.tmp[i] = make([]byte, n*4)
+		}
+		js.CopyBytesToGo(c.tmp[i][:n*4], bs)
+	}
+
+	c.pcm = c.pcm[:0]
+	for j := 0; j < n; j++ {
+		for i := range c.tmp {
+			f := math.Float32frombits(binary.LittleEndian.Uint32(c.tmp[i][4*j:]))
+			if f > 1 {
+				f = 1
+			}
+			if f < -1 {
+				f = -1
+			}
+			v := int16(f * (1<<15 - 1))
+			c.pcm = append(c.pcm, byte(v), byte(v>>8))
+		}
+	}
+	c.buf.write(c.pcm)
+}
+
+func (c *captureImpl) Read(buf []byte) (int, error) {
+	return c.buf.Read(buf)
+}
+
+func (c *captureImpl) Close() error {
+	if err := c.buf.close(); err != nil {
+		return err
+	}
+
+	c.source.Call("disconnect")
+	c.processor.Call("disconnect")
+	c.processor.Set("onaudioprocess", js.Null())
+	c.onProcess.Release()
+
+	tracks := c.stream.Call("getTracks")
+	for i := 0; i < tracks.Length(); i++ {
+		tracks.Index(i).Call("stop")
+	}
+	c.context.Call("close")
+	return nil
+}
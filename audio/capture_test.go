@@ -0,0 +1,164 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+func TestCaptureBuffer(t *testing.T) {
+	b := audio.NewCaptureBufferForTesting(4)
+	b.Write([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+	buf := make([]byte, 3)
+	n, err := b.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf[:n], []byte{1, 2, 3}; !bytes.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Close(); err == nil {
+		t.Errorf("Close must return an error when the capture is already closed")
+	}
+
+	// The data recorded before closing is still readable.
+	got, err := io.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{4, 5, 6, 7, 8}; !bytes.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// Writing after closing is ignored.
+	b.Write([]byte{1, 2, 3, 4})
+	if _, err := b.Read(buf); err != io.EOF {
+		t.Errorf("got: %v, want: %v", err, io.EOF)
+	}
+}
+
+func TestCaptureBufferOverflow(t *testing.T) {
+	// The buffer can hold one second, which is 2 samples (8 bytes).
+	b := audio.NewCaptureBufferForTesting(2)
+	b.Write([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	b.Write([]byte{11, 12})
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The oldest data is discarded by samples.
+	got, err := io.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{5, 6, 7, 8, 9, 10, 11, 12}; !bytes.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestCaptureBufferError(t *testing.T) {
+	b := audio.NewCaptureBufferForTesting(4)
+	b.Write([]byte{1, 2, 3, 4})
+
+	errDisconnected := errors.New("disconnected")
+	b.CloseWithError(errDisconnected)
+
+	buf := make([]byte, 8)
+	n, err := b.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 4 {
+		t.Errorf("got: %d, want: %d", n, 4)
+	}
+	if _, err := b.Read(buf); err != errDisconnected {
+		t.Errorf("got: %v, want: %v", err, errDisconnected)
+	}
+
+	// Close succeeds even after the recording stops due to an error, so that the resources are released.
+	if err := b.Close(); err != nil {
+		t.Errorf("got: %v, want: nil", err)
+	}
+}
+
+func TestCapture(t *testing.T) {
+	b := audio.NewCaptureBufferForTesting(4)
+	c := b.NewCaptureForTesting()
+
+	// Read blocks until recorded data is available.
+	ch := make(chan []byte)
+	go func() {
+		buf := make([]byte, 8)
+		n, err := c.Read(buf)
+		if err != nil {
+			t.Error(err)
+		}
+		ch <- buf[:n]
+	}()
+	select {
+	case <-ch:
+		t.Fatalf("Read must block until recorded data is available")
+	case <-time.After(10 * time.Millisecond):
+	}
+	b.Write([]byte{1, 2, 3, 4})
+	if got, want := <-ch, []byte{1, 2, 3, 4}; !bytes.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// Close unblocks Read.
+	go func() {
+		_, err := c.Read(make([]byte, 8))
+		if err != io.EOF {
+			t.Errorf("got: %v, want: %v", err, io.EOF)
+		}
+		close(ch)
+	}()
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	<-ch
+
+	if _, err := c.Read(make([]byte, 8)); err != io.EOF {
+		t.Errorf("got: %v, want: %v", err, io.EOF)
+	}
+	if err := c.Close(); err == nil {
+		t.Errorf("Close must return an error when the capture is already closed")
+	}
+}
+
+func TestCaptureError(t *testing.T) {
+	b := audio.NewCaptureBufferForTesting(4)
+	c := b.NewCaptureForTesting()
+
+	errDisconnected := errors.New("disconnected")
+	b.CloseWithError(errDisconnected)
+	if _, err := c.Read(make([]byte, 8)); err != errDisconnected {
+		t.Errorf("got: %v, want: %v", err, errDisconnected)
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("got: %v, want: nil", err)
+	}
+}
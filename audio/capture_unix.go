@@ -0,0 +1,132 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !android && !darwin && !js && !windows && !nintendosdk && !ebitengineheadless
+
+package audio
+
+// #cgo pkg-config: alsa
+//
+// #include <alsa/asoundlib.h>
+import "C"
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+)
+
+const (
+	// captureALSALatency is the requested latency of the capture device in microseconds.
+	captureALSALatency = 100 * 1000
+
+	// captureALSAPeriodInFrames is the maximum number of frames read at once.
+	captureALSAPeriodInFrames = 1024
+
+	// captureALSAWaitTimeout is the timeout to wait for the recorded data in milliseconds.
+	// The loop checks whether the capture is closed at this interval at least.
+	captureALSAWaitTimeout = 100
+)
+
+type captureImpl struct {
+	handle *C.snd_pcm_t
+	buf    *captureBuffer
+	done   chan struct{}
+}
+
+func alsaCaptureError(name string, err C.int) error {
+	return fmt.Errorf("audio: ALSA error at %s: %s", name, C.GoString(C.snd_strerror(err)))
+}
+
+func newCapture(sampleRate int) (io.ReadCloser, error) {
+	c := &captureImpl{
+		buf:  newCaptureBuffer(sampleRate),
+		done: make(chan struct{}),
+	}
+
+	name := C.CString("default")
+	defer C.free(unsafe.Pointer(name))
+	if err := C.snd_pcm_open(&c.handle, name, C.SND_PCM_STREAM_CAPTURE, 0); err < 0 {
+		return nil, alsaCaptureError("snd_pcm_open", err)
+	}
+	// Allow the resampling by ALSA (soft_resample = 1), as the device might not support the sample rate.
+	if err := C.snd_pcm_set_params(c.handle, C.SND_PCM_FORMAT_S16_LE, C.SND_PCM_ACCESS_RW_INTERLEAVED, channelCount, C.uint(sampleRate), 1, captureALSALatency); err < 0 {
+		C.snd_pcm_close(c.handle)
+		return nil, alsaCaptureError("snd_pcm_set_params", err)
+	}
+	if err := C.snd_pcm_start(c.handle); err < 0 {
+		C.snd_pcm_close(c.handle)
+		return nil, alsaCaptureError("snd_pcm_start", err)
+	}
+
+	go c.loop()
+	return c, nil
+}
+
+func (c *captureImpl) loop() {
+	defer close(c.done)
+
+	pcm := make([]byte, captureALSAPeriodInFrames*bytesPerSample)
+	for !c.buf.isClosed() {
+		if err := C.snd_pcm_wait(c.handle, captureALSAWaitTimeout); err < 0 {
+			if err := C.snd_pcm_recover(c.handle, err, 1); err < 0 {
+				c.buf.closeWithError(alsaCaptureError("snd_pcm_wait", err))
+				return
+			}
+			continue
+		}
+
+		avail := C.snd_pcm_avail_update(c.handle)
+		if avail < 0 {
+			if err := C.snd_pcm_recover(c.handle, C.int(avail), 1); err < 0 {
+				c.buf.closeWithError(alsaCaptureError("snd_pcm_avail_update", err))
+				return
+			}
+			continue
+		}
+		if avail == 0 {
+			continue
+		}
+		if avail > captureALSAPeriodInFrames {
+			avail = captureALSAPeriodInFrames
+		}
+
+		// As avail frames are already available, snd_pcm_readi doesn't block.
+		n := C.snd_pcm_readi(c.handle, unsafe.Pointer(&pcm[0]), C.snd_pcm_uframes_t(avail))
+		if n < 0 {
+			// Recover from an overrun or a suspension.
+			if err := C.snd_pcm_recover(c.handle, C.int(n), 1); err < 0 {
+				c.buf.closeWithError(alsaCaptureError("snd_pcm_readi", err))
+				return
+			}
+			continue
+		}
+		c.buf.write(pcm[:int(n)*bytesPerSample])
+	}
+}
+
+func (c *captureImpl) Read(buf []byte) (int, error) {
+	return c.buf.Read(buf)
+}
+
+func (c *captureImpl) Close() error {
+	if err := c.buf.close(); err != nil {
+		return err
+	}
+	// Wait for the loop to finish not to use the handle concurrently.
+	<-c.done
+	C.snd_pcm_drop(c.handle)
+	C.snd_pcm_close(c.handle)
+	return nil
+}
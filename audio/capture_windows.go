@@ -0,0 +1,413 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nintendosdk && !ebitengineheadless
+
+package audio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procCoCreateInstance = windows.NewLazySystemDLL("ole32").NewProc("CoCreateInstance")
+)
+
+var (
+	uuidIAudioClient        = windows.GUID{Data1: 0x1cb9ad4c, Data2: 0xdbfa, Data3: 0x4c32, Data4: [...]byte{0xb1, 0x78, 0xc2, 0xf5, 0x68, 0xa7, 0x03, 0xb2}}
+	uuidIAudioCaptureClient = windows.GUID{Data1: 0xc8adbd64, Data2: 0xe71e, Data3: 0x48a0, Data4: [...]byte{0xa4, 0xde, 0x18, 0x5c, 0x39, 0x5c, 0xd3, 0x17}}
+	uuidIMMDeviceEnumerator = windows.GUID{Data1: 0xa95664d2, Data2: 0x9614, Data3: 0x4f35, Data4: [...]byte{0xa7, 0x46, 0xde, 0x8d, 0xb6, 0x36, 0x17, 0xe6}}
+	uuidMMDeviceEnumerator  = windows.GUID{Data1: 0xbcde0395, Data2: 0xe52f, Data3: 0x467c, Data4: [...]byte{0x8e, 0x3d, 0xc4, 0x57, 0x92, 0x91, 0x69, 0x2e}}
+)
+
+const (
+	_AUDCLNT_BUFFERFLAGS_SILENT              = 0x2
+	_AUDCLNT_SHAREMODE_SHARED                = 0
+	_AUDCLNT_STREAMFLAGS_AUTOCONVERTPCM      = 0x80000000
+	_AUDCLNT_STREAMFLAGS_EVENTCALLBACK       = 0x00040000
+	_AUDCLNT_STREAMFLAGS_SRC_DEFAULT_QUALITY = 0x08000000
+	_CLSCTX_ALL                              = 0x1 | 0x2 | 0x4 | 0x10
+	_WAVE_FORMAT_PCM                         = 1
+	eCapture                                 = 1
+	eConsole                                 = 0
+
+	// captureWASAPIBufferDuration is the buffer duration in 100[ns].
+	captureWASAPIBufferDuration = 100 * 10000
+
+	// captureWASAPIWaitTimeout is the timeout to wait for the recorded data in milliseconds.
+	// The loop checks whether the capture is closed at this interval at least.
+	captureWASAPIWaitTimeout = 100
+)
+
+type _WAVEFORMATEX struct {
+	wFormatTag      uint16
+	nChannels       uint16
+	nSamplesPerSec  uint32
+	nAvgBytesPerSec uint32
+	nBlockAlign     uint16
+	wBitsPerSample  uint16
+	cbSize          uint16
+}
+
+func hresultError(name string, r uintptr) error {
+	return fmt.Errorf("audio: %s failed: HRESULT(0x%08x)", name, uint32(r))
+}
+
+type _IMMDeviceEnumerator struct {
+	vtbl *_IMMDeviceEnumerator_Vtbl
+}
+
+type _IMMDeviceEnumerator_Vtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+
+	EnumAudioEndpoints                     uintptr
+	GetDefaultAudioEndpoint                uintptr
+	GetDevice                              uintptr
+	RegisterEndpointNotificationCallback   uintptr
+	UnregisterEndpointNotificationCallback uintptr
+}
+
+func (i *_IMMDeviceEnumerator) GetDefaultAudioEndpoint(dataFlow, role uint32) (*_IMMDevice, error) {
+	var device *_IMMDevice
+	r, _, _ := syscall.Syscall6(i.vtbl.GetDefaultAudioEndpoint, 4, uintptr(unsafe.Pointer(i)), uintptr(dataFlow), uintptr(role), uintptr(unsafe.Pointer(&device)), 0, 0)
+	if uint32(r) != uint32(windows.S_OK) {
+		return nil, hresultError("IMMDeviceEnumerator::GetDefaultAudioEndpoint", r)
+	}
+	return device, nil
+}
+
+func (i *_IMMDeviceEnumerator) Release() {
+	_, _, _ = syscall.Syscall(i.vtbl.Release, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+}
+
+type _IMMDevice struct {
+	vtbl *_IMMDevice_Vtbl
+}
+
+type _IMMDevice_Vtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+
+	Activate          uintptr
+	OpenPropertyStore uintptr
+	GetId             uintptr
+	GetState          uintptr
+}
+
+func (i *_IMMDevice) Activate(iid *windows.GUID, clsCtx uint32) (unsafe.Pointer, error) {
+	var v unsafe.Pointer
+	r, _, _ := syscall.Syscall6(i.vtbl.Activate, 5, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(iid)), uintptr(clsCtx), 0, uintptr(unsafe.Pointer(&v)), 0)
+	runtime.KeepAlive(iid)
+	if uint32(r) != uint32(windows.S_OK) {
+		return nil, hresultError("IMMDevice::Activate", r)
+	}
+	return v, nil
+}
+
+func (i *_IMMDevice) Release() {
+	_, _, _ = syscall.Syscall(i.vtbl.Release, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+}
+
+type _IAudioClient struct {
+	vtbl *_IAudioClient_Vtbl
+}
+
+type _IAudioClient_Vtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+
+	Initialize        uintptr
+	GetBufferSize     uintptr
+	GetStreamLatency  uintptr
+	GetCurrentPadding uintptr
+	IsFormatSupported uintptr
+	GetMixFormat      uintptr
+	GetDevicePeriod   uintptr
+	Start             uintptr
+	Stop              uintptr
+	Reset             uintptr
+	SetEventHandle    uintptr
+	GetService        uintptr
+}
+
+func (i *_IAudioClient) Initialize(shareMode uint32, streamFlags uint32, bufferDuration int64, periodicity int64, format *_WAVEFORMATEX) error {
+	var r uintptr
+	if unsafe.Sizeof(uintptr(0)) == 8 {
+		r, _, _ = syscall.Syscall9(i.vtbl.Initialize, 7, uintptr(unsafe.Pointer(i)),
+			uintptr(shareMode), uintptr(streamFlags), uintptr(bufferDuration),
+			uintptr(periodicity), uintptr(unsafe.Pointer(format)), 0,
+			0, 0)
+	} else {
+		// A 64bit integer is passed as two 32bit integers on 32bit machines.
+		r, _, _ = syscall.Syscall9(i.vtbl.Initialize, 9, uintptr(unsafe.Pointer(i)),
+			uintptr(shareMode), uintptr(streamFlags), uintptr(bufferDuration),
+			uintptr(bufferDuration>>32), uintptr(periodicity), uintptr(periodicity>>32),
+			uintptr(unsafe.Pointer(format)), 0)
+	}
+	runtime.KeepAlive(format)
+	if uint32(r) != uint32(windows.S_OK) {
+		return hresultError("IAudioClient::Initialize", r)
+	}
+	return nil
+}
+
+func (i *_IAudioClient) GetService(riid *windows.GUID) (unsafe.Pointer, error) {
+	var v unsafe.Pointer
+	r, _, _ := syscall.Syscall(i.vtbl.GetService, 3, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(riid)), uintptr(unsafe.Pointer(&v)))
+	runtime.KeepAlive(riid)
+	if uint32(r) != uint32(windows.S_OK) {
+		return nil, hresultError("IAudioClient::GetService", r)
+	}
+	return v, nil
+}
+
+func (i *_IAudioClient) SetEventHandle(event windows.Handle) error {
+	r, _, _ := syscall.Syscall(i.vtbl.SetEventHandle, 2, uintptr(unsafe.Pointer(i)), uintptr(event), 0)
+	if uint32(r) != uint32(windows.S_OK) {
+		return hresultError("IAudioClient::SetEventHandle", r)
+	}
+	return nil
+}
+
+func (i *_IAudioClient) Start() error {
+	r, _, _ := syscall.Syscall(i.vtbl.Start, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+	if uint32(r) != uint32(windows.S_OK) {
+		return hresultError("IAudioClient::Start", r)
+	}
+	return nil
+}
+
+func (i *_IAudioClient) Stop() {
+	_, _, _ = syscall.Syscall(i.vtbl.Stop, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+}
+
+func (i *_IAudioClient) Release() {
+	_, _, _ = syscall.Syscall(i.vtbl.Release, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+}
+
+type _IAudioCaptureClient struct {
+	vtbl *_IAudioCaptureClient_Vtbl
+}
+
+type _IAudioCaptureClient_Vtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+
+	GetBuffer         uintptr
+	ReleaseBuffer     uintptr
+	GetNextPacketSize uintptr
+}
+
+func (i *_IAudioCaptureClient) GetBuffer() (*byte, uint32, uint32, error) {
+	var data *byte
+	var frames uint32
+	var flags uint32
+	r, _, _ := syscall.Syscall6(i.vtbl.GetBuffer, 6, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(&data)), uintptr(unsafe.Pointer(&frames)), uintptr(unsafe.Pointer(&flags)), 0, 0)
+	if uint32(r) != uint32(windows.S_OK) {
+		return nil, 0, 0, hresultError("IAudioCaptureClient::GetBuffer", r)
+	}
+	return data, frames, flags, nil
+}
+
+func (i *_IAudioCaptureClient) ReleaseBuffer(frames uint32) error {
+	r, _, _ := syscall.Syscall(i.vtbl.ReleaseBuffer, 2, uintptr(unsafe.Pointer(i)), uintptr(frames), 0)
+	if uint32(r) != uint32(windows.S_OK) {
+		return hresultError("IAudioCaptureClient::ReleaseBuffer", r)
+	}
+	return nil
+}
+
+func (i *_IAudioCaptureClient) GetNextPacketSize() (uint32, error) {
+	var frames uint32
+	r, _, _ := syscall.Syscall(i.vtbl.GetNextPacketSize, 2, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(&frames)), 0)
+	if uint32(r) != uint32(windows.S_OK) {
+		return 0, hresultError("IAudioCaptureClient::GetNextPacketSize", r)
+	}
+	return frames, nil
+}
+
+func (i *_IAudioCaptureClient) Release() {
+	_, _, _ = syscall.Syscall(i.vtbl.Release, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+}
+
+type captureImpl struct {
+	buf  *captureBuffer
+	done chan struct{}
+}
+
+func newCapture(sampleRate int) (io.ReadCloser, error) {
+	c := &captureImpl{
+		buf:  newCaptureBuffer(sampleRate),
+		done: make(chan struct{}),
+	}
+
+	// COM objects are used only on one thread initialized for COM.
+	errCh := make(chan error)
+	go func() {
+		defer close(c.done)
+
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		// S_FALSE is returned when CoInitializeEx is nested. This is a successful case.
+		if err := windows.CoInitializeEx(0, windows.COINIT_MULTITHREADED); err != nil && !errors.Is(err, syscall.Errno(windows.S_FALSE)) {
+			errCh <- err
+			return
+		}
+		// CoUninitialize should be called even when CoInitializeEx returns S_FALSE.
+		defer windows.CoUninitialize()
+
+		c.run(sampleRate, errCh)
+	}()
+
+	if err := <-errCh; err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// run starts recording and reads the recorded data until the capture is closed.
+// run sends nil to errCh when recording starts successfully, or an error otherwise.
+func (c *captureImpl) run(sampleRate int, errCh chan<- error) {
+	var enumerator unsafe.Pointer
+	if r, _, _ := procCoCreateInstance.Call(uintptr(unsafe.Pointer(&uuidMMDeviceEnumerator)), 0, _CLSCTX_ALL, uintptr(unsafe.Pointer(&uuidIMMDeviceEnumerator)), uintptr(unsafe.Pointer(&enumerator))); uint32(r) != uint32(windows.S_OK) {
+		errCh <- hresultError("CoCreateInstance", r)
+		return
+	}
+	e := (*_IMMDeviceEnumerator)(enumerator)
+	defer e.Release()
+
+	device, err := e.GetDefaultAudioEndpoint(eCapture, eConsole)
+	if err != nil {
+		errCh <- err
+		return
+	}
+	defer device.Release()
+
+	client, err := device.Activate(&uuidIAudioClient, _CLSCTX_ALL)
+	if err != nil {
+		errCh <- err
+		return
+	}
+	audioClient := (*_IAudioClient)(client)
+	defer audioClient.Release()
+
+	// Let the audio engine convert the device's format to the requested format.
+	format := &_WAVEFORMATEX{
+		wFormatTag:      _WAVE_FORMAT_PCM,
+		nChannels:       channelCount,
+		nSamplesPerSec:  uint32(sampleRate),
+		nAvgBytesPerSec: uint32(sampleRate * bytesPerSample),
+		nBlockAlign:     bytesPerSample,
+		wBitsPerSample:  bitDepthInBytes * 8,
+	}
+	if err := audioClient.Initialize(_AUDCLNT_SHAREMODE_SHARED,
+		_AUDCLNT_STREAMFLAGS_EVENTCALLBACK|_AUDCLNT_STREAMFLAGS_AUTOCONVERTPCM|_AUDCLNT_STREAMFLAGS_SRC_DEFAULT_QUALITY,
+		captureWASAPIBufferDuration, 0, format); err != nil {
+		errCh <- err
+		return
+	}
+
+	event, err := windows.CreateEventEx(nil, nil, 0, windows.EVENT_ALL_ACCESS)
+	if err != nil {
+		errCh <- err
+		return
+	}
+	defer func() {
+		_ = windows.CloseHandle(event)
+	}()
+	if err := audioClient.SetEventHandle(event); err != nil {
+		errCh <- err
+		return
+	}
+
+	cc, err := audioClient.GetService(&uuidIAudioCaptureClient)
+	if err != nil {
+		errCh <- err
+		return
+	}
+	captureClient := (*_IAudioCaptureClient)(cc)
+	defer captureClient.Release()
+
+	if err := audioClient.Start(); err != nil {
+		errCh <- err
+		return
+	}
+	defer audioClient.Stop()
+
+	close(errCh)
+
+	var silence []byte
+	for !c.buf.isClosed() {
+		if _, err := windows.WaitForSingleObject(event, captureWASAPIWaitTimeout); err != nil {
+			c.buf.closeWithError(err)
+			return
+		}
+
+		for {
+			frames, err := captureClient.GetNextPacketSize()
+			if err != nil {
+				c.buf.closeWithError(err)
+				return
+			}
+			if frames == 0 {
+				break
+			}
+
+			data, frames, flags, err := captureClient.GetBuffer()
+			if err != nil {
+				c.buf.closeWithError(err)
+				return
+			}
+			n := int(frames) * bytesPerSample
+			if flags&_AUDCLNT_BUFFERFLAGS_SILENT != 0 {
+				if len(silence) < n {
+					silence = make([]byte, n)
+				}
+				c.buf.write(silence[:n])
+			} else if n > 0 {
+				c.buf.write(unsafe.Slice(data, n))
+			}
+			if err := captureClient.ReleaseBuffer(frames); err != nil {
+				c.buf.closeWithError(err)
+				return
+			}
+		}
+	}
+}
+
+func (c *captureImpl) Read(buf []byte) (int, error) {
+	return c.buf.Read(buf)
+}
+
+func (c *captureImpl) Close() error {
+	if err := c.buf.close(); err != nil {
+		return err
+	}
+	// Wait for the recording thread to release the COM objects.
+	<-c.done
+	return nil
+}
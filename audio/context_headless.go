@@ -0,0 +1,164 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ebitengineheadless
+
+package audio
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+func newContext(sampleRate int, bufferSize time.Duration) (context, chan struct{}, error) {
+	ready := make(chan struct{})
+	close(ready)
+	return &nullContext{
+		bytesPerSecond: sampleRate * channelCount * bitDepthInBytes,
+	}, ready, nil
+}
+
+// nullContext is a context without any audio device.
+// Players consume their sources at the same speed as real audio devices do, but nothing is output.
+type nullContext struct {
+	bytesPerSecond int
+}
+
+func (c *nullContext) NewPlayer(r io.Reader) player {
+	p := &nullPlayer{
+		src:            r,
+		bytesPerSecond: c.bytesPerSecond,
+		volume:         1,
+	}
+	p.cond = sync.NewCond(&p.m)
+	go p.loop()
+	return p
+}
+
+func (c *nullContext) Suspend() error {
+	return nil
+}
+
+func (c *nullContext) Resume() error {
+	return nil
+}
+
+func (c *nullContext) Err() error {
+	return nil
+}
+
+type nullPlayer struct {
+	src            io.Reader
+	bytesPerSecond int
+	playing        bool
+	closed         bool
+	volume         float64
+	err            error
+
+	cond *sync.Cond
+	m    sync.Mutex
+}
+
+func (p *nullPlayer) loop() {
+	const interval = 10 * time.Millisecond
+	buf := make([]byte, p.bytesPerSecond*int(interval)/int(time.Second)/(channelCount*bitDepthInBytes)*(channelCount*bitDepthInBytes))
+
+	for {
+		p.m.Lock()
+		for !p.playing && !p.closed {
+			p.cond.Wait()
+		}
+		if p.closed {
+			p.m.Unlock()
+			return
+		}
+		if _, err := io.ReadFull(p.src, buf); err != nil {
+			if err != io.EOF && err != io.ErrUnexpectedEOF {
+				p.err = err
+			}
+			p.playing = false
+		}
+		p.m.Unlock()
+
+		time.Sleep(interval)
+	}
+}
+
+func (p *nullPlayer) Pause() {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.playing = false
+}
+
+func (p *nullPlayer) Play() {
+	p.m.Lock()
+	defer p.m.Unlock()
+	if p.closed || p.err != nil {
+		return
+	}
+	p.playing = true
+	p.cond.Signal()
+}
+
+func (p *nullPlayer) IsPlaying() bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.playing
+}
+
+func (p *nullPlayer) Volume() float64 {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.volume
+}
+
+func (p *nullPlayer) SetVolume(volume float64) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.volume = volume
+}
+
+func (p *nullPlayer) UnplayedBufferSize() int {
+	return 0
+}
+
+func (p *nullPlayer) Err() error {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.err
+}
+
+func (p *nullPlayer) SetBufferSize(bufferSize int) {
+}
+
+func (p *nullPlayer) Seek(offset int64, whence int) (int64, error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	s, ok := p.src.(io.Seeker)
+	if !ok {
+		return 0, errors.New("audio: the source must implement io.Seeker")
+	}
+	return s.Seek(offset, whence)
+}
+
+func (p *nullPlayer) Close() error {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.playing = false
+	p.closed = true
+	p.cond.Signal()
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"math"
+	"time"
+)
+
+const crossfadeInterval = 10 * time.Millisecond
+
+// Crossfade fades out from and fades in to over the duration d.
+//
+// to starts playing if it is not playing, and its volume goes up from 0 to its volume at the time of calling
+// Crossfade. from's volume goes down to 0, and then from is paused and its volume is restored.
+// The volumes are changed on a separate goroutine in wall-clock time with equal-power curves,
+// so the game doesn't have to change the volumes every frame.
+//
+// Either from or to can be nil to fade in or out only one player.
+//
+// A fade is canceled when another Crossfade with the same player starts or SetVolume of the player is called.
+//
+// Crossfade returns immediately.
+func Crossfade(from, to *Player, d time.Duration) {
+	var fromID, toID uint64
+	var fromVolume, toVolume float64
+	if from != nil {
+		fromID, fromVolume = from.p.startFade()
+	}
+	if to != nil {
+		toID, toVolume = to.p.startFade()
+		to.p.setFadeVolume(toID, 0)
+		to.Play()
+	}
+
+	go func() {
+		start := time.Now()
+		t := time.NewTicker(crossfadeInterval)
+		defer t.Stop()
+
+		for {
+			rate := 1.0
+			if d > 0 {
+				rate = math.Min(float64(time.Since(start))/float64(d), 1)
+			}
+
+			fromActive := from != nil && from.p.setFadeVolume(fromID, fromVolume*math.Cos(rate*math.Pi/2))
+			toActive := to != nil && to.p.setFadeVolume(toID, toVolume*math.Sin(rate*math.Pi/2))
+			if !fromActive && !toActive {
+				return
+			}
+
+			if rate >= 1 {
+				if fromActive {
+					from.p.endFade(fromID, true)
+				}
+				if toActive {
+					to.p.endFade(toID, false)
+				}
+				return
+			}
+			<-t.C
+		}
+	}()
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"time"
+)
+
+const (
+	// minScriptProcessorBufferSize and maxScriptProcessorBufferSize are the range of the frame count
+	// that createScriptProcessor accepts.
+	minScriptProcessorBufferSize = 256
+	maxScriptProcessorBufferSize = 16384
+)
+
+// adjustDeviceBufferSize adjusts the buffer size of the audio device.
+//
+// On browsers, the buffer size is used for createScriptProcessor, which accepts only a power of two frame count
+// between 256 and 16384. adjustDeviceBufferSize rounds the buffer size to the nearest valid size.
+func adjustDeviceBufferSize(sampleRate int, bufferSize time.Duration) time.Duration {
+	if bufferSize == 0 {
+		return 0
+	}
+
+	frames := int64(bufferSize) * int64(sampleRate) / int64(time.Second)
+	valid := int64(minScriptProcessorBufferSize)
+	for valid < maxScriptProcessorBufferSize && valid*2-frames < frames-valid {
+		valid *= 2
+	}
+
+	// Round up so that the frame count calculated from the duration is exactly valid.
+	return time.Duration((valid*int64(time.Second) + int64(sampleRate) - 1) / int64(sampleRate))
+}
+
+// defaultDeviceBufferSize returns the buffer size of the audio device when ContextOptions.BufferSize is not specified.
+//
+// Oto uses 2048 frames for createScriptProcessor.
+func defaultDeviceBufferSize(sampleRate int) time.Duration {
+	return framesToDuration(2048, sampleRate)
+}
@@ -0,0 +1,28 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build nintendosdk
+
+package audio
+
+import (
+	"time"
+)
+
+// defaultDeviceBufferSize returns the buffer size of the audio device when ContextOptions.BufferSize is not specified.
+//
+// The buffer size depends on the platform and is not known. Returns 0 as an unknown value.
+func defaultDeviceBufferSize(sampleRate int) time.Duration {
+	return 0
+}
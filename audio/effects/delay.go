@@ -0,0 +1,125 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package effects
+
+import (
+	"io"
+	"time"
+)
+
+// Delay is an echo effect that repeats the source after a fixed time.
+type Delay struct {
+	s *stream
+	d *delay
+}
+
+// NewDelay creates a delay effect.
+//
+// src's format must be linear PCM (signed 16bits little endian, 2 channel stereo) with the given sample rate.
+//
+// duration is the time between the source and its echo.
+// feedback is the ratio of an echo fed back to the next echo, and must be in [0, 1).
+// mix is the volume of echos, and must be in [0, 1].
+//
+// The returned Delay is seekable when src is io.Seeker.
+func NewDelay(src io.Reader, sampleRate int, duration time.Duration, feedback, mix float64) *Delay {
+	n := int(int64(duration) * int64(sampleRate) / int64(time.Second))
+	if n <= 0 {
+		panic("effects: duration is too short")
+	}
+	d := &delay{
+		buf: make([][channelCount]float64, n),
+	}
+	d.setFeedback(feedback)
+	d.setMix(mix)
+	return &Delay{
+		s: &stream{
+			src:  src,
+			proc: d,
+		},
+		d: d,
+	}
+}
+
+// Read is implementation of io.Reader's Read.
+func (d *Delay) Read(buf []byte) (int, error) {
+	return d.s.Read(buf)
+}
+
+// Seek is implementation of io.Seeker's Seek.
+//
+// Seek returns an error when the source is not io.Seeker.
+func (d *Delay) Seek(offset int64, whence int) (int64, error) {
+	return d.s.Seek(offset, whence)
+}
+
+// SetFeedback sets the feedback ratio.
+//
+// SetFeedback panics if feedback is not in [0, 1).
+func (d *Delay) SetFeedback(feedback float64) {
+	d.s.m.Lock()
+	defer d.s.m.Unlock()
+	d.d.setFeedback(feedback)
+}
+
+// SetMix sets the volume of echos.
+//
+// SetMix panics if mix is not in [0, 1].
+func (d *Delay) SetMix(mix float64) {
+	d.s.m.Lock()
+	defer d.s.m.Unlock()
+	d.d.setMix(mix)
+}
+
+type delay struct {
+	buf      [][channelCount]float64
+	pos      int
+	feedback float64
+	mix      float64
+}
+
+func (d *delay) setFeedback(feedback float64) {
+	if feedback < 0 || feedback >= 1 {
+		panic("effects: feedback must be in [0, 1)")
+	}
+	d.feedback = feedback
+}
+
+func (d *delay) setMix(mix float64) {
+	if mix < 0 || mix > 1 {
+		panic("effects: mix must be in [0, 1]")
+	}
+	d.mix = mix
+}
+
+func (d *delay) process(l, r float64) (float64, float64) {
+	e := d.buf[d.pos]
+	d.buf[d.pos] = [channelCount]float64{
+		l + e[0]*d.feedback,
+		r + e[1]*d.feedback,
+	}
+	d.pos++
+	if d.pos == len(d.buf) {
+		d.pos = 0
+	}
+	return l + e[0]*d.mix, r + e[1]*d.mix
+}
+
+func (d *delay) reset() {
+	for i := range d.buf {
+		d.buf[i] = [channelCount]float64{}
+	}
+	d.pos = 0
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package effects provides audio effects applied to PCM streams.
+//
+// An effect wraps a stream in the format audio.Player accepts (signed 16bits little endian, 2 channel stereo)
+// and is also such a stream. Then, an effect is inserted between a source and a player like this:
+//
+//	s, _ := vorbis.DecodeWithSampleRate(sampleRate, src)
+//	p, _ := audioContext.NewPlayer(effects.NewLowPass(s, sampleRate, 800))
+//
+// Effects can be chained by wrapping an effect with another effect.
+//
+// The parameters of effects can be changed while the player is playing.
+package effects
+
+import (
+	"errors"
+	"io"
+	"math"
+	"sync"
+)
+
+const (
+	channelCount    = 2
+	bitDepthInBytes = 2
+	bytesPerSample  = bitDepthInBytes * channelCount
+)
+
+// processor processes one stereo sample.
+// Values are in [-1, 1], but the results can exceed the range.
+type processor interface {
+	process(l, r float64) (float64, float64)
+	reset()
+}
+
+// stream is the common implementation of the effects.
+type stream struct {
+	src  io.Reader
+	proc processor
+
+	// in is the bytes read from src but not processed yet as they don't form a whole sample.
+	in []byte
+
+	// out is the processed bytes that are not returned yet.
+	out []byte
+
+	buf []byte
+
+	// m is a mutex for this stream.
+	// All the exported functions are protected by this mutex as Read can be called from a different goroutine
+	// than the goroutine where the parameters are changed.
+	m sync.Mutex
+}
+
+func (s *stream) Read(b []byte) (int, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if len(b) == 0 {
+		return 0, nil
+	}
+
+	if len(s.out) > 0 {
+		n := copy(b, s.out)
+		s.out = s.out[:copy(s.out, s.out[n:])]
+		return n, nil
+	}
+
+	size := len(b) / bytesPerSample * bytesPerSample
+	if size < bytesPerSample {
+		size = bytesPerSample
+	}
+	if cap(s.buf) < size {
+		s.buf = make([]byte, size)
+	}
+	s.buf = s.buf[:size]
+
+	offset := copy(s.buf, s.in)
+	n, err := s.src.Read(s.buf[offset:])
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	n += offset
+
+	m := n / bytesPerSample * bytesPerSample
+	s.in = append(s.in[:0], s.buf[m:n]...)
+
+	for i := 0; i < m; i += bytesPerSample {
+		l := float64(int16(s.buf[i])|int16(s.buf[i+1])<<8) / (1 << 15)
+		r := float64(int16(s.buf[i+2])|int16(s.buf[i+3])<<8) / (1 << 15)
+		l, r = s.proc.process(l, r)
+		l16 := toInt16(l)
+		r16 := toInt16(r)
+		s.buf[i] = byte(l16)
+		s.buf[i+1] = byte(l16 >> 8)
+		s.buf[i+2] = byte(r16)
+		s.buf[i+3] = byte(r16 >> 8)
+	}
+
+	c := copy(b, s.buf[:m])
+	s.out = append(s.out[:0], s.buf[c:m]...)
+	if err == io.EOF && len(s.out) > 0 {
+		err = nil
+	}
+	return c, err
+}
+
+func (s *stream) Seek(offset int64, whence int) (int64, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	seeker, ok := s.src.(io.Seeker)
+	if !ok {
+		return 0, errors.New("effects: the source must be io.Seeker when seeking but not")
+	}
+	pos, err := seeker.Seek(offset, whence)
+	if err != nil {
+		return 0, err
+	}
+
+	s.in = s.in[:0]
+	s.out = s.out[:0]
+	s.proc.reset()
+	return pos, nil
+}
+
+func toInt16(v float64) int16 {
+	v *= 1<<15 - 1
+	if v > math.MaxInt16 {
+		return math.MaxInt16
+	}
+	if v < math.MinInt16 {
+		return math.MinInt16
+	}
+	return int16(v)
+}
@@ -0,0 +1,231 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package effects_test
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/audio/effects"
+)
+
+const sampleRate = 44100
+
+func newSineBytes(freq float64, samples int) []byte {
+	b := make([]byte, samples*4)
+	for i := 0; i < samples; i++ {
+		v := int16(0.5 * math.Sin(2*math.Pi*freq*float64(i)/sampleRate) * (1<<15 - 1))
+		b[4*i] = byte(v)
+		b[4*i+1] = byte(v >> 8)
+		b[4*i+2] = byte(v)
+		b[4*i+3] = byte(v >> 8)
+	}
+	return b
+}
+
+// peak returns the maximum absolute value of the left channel after the first skip samples.
+func peak(b []byte, skip int) float64 {
+	var p float64
+	for i := skip; i < len(b)/4; i++ {
+		v := math.Abs(float64(int16(b[4*i])|int16(b[4*i+1])<<8) / (1 << 15))
+		if p < v {
+			p = v
+		}
+	}
+	return p
+}
+
+func TestLowPass(t *testing.T) {
+	low, err := io.ReadAll(effects.NewLowPass(bytes.NewReader(newSineBytes(100, sampleRate/10)), sampleRate, 1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := peak(low, 1000); got < 0.45 {
+		t.Errorf("peak of a low frequency: got: %f, want: >= 0.45", got)
+	}
+
+	high, err := io.ReadAll(effects.NewLowPass(bytes.NewReader(newSineBytes(10000, sampleRate/10)), sampleRate, 1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := peak(high, 1000); got > 0.05 {
+		t.Errorf("peak of a high frequency: got: %f, want: <= 0.05", got)
+	}
+}
+
+func TestHighPass(t *testing.T) {
+	low, err := io.ReadAll(effects.NewHighPass(bytes.NewReader(newSineBytes(100, sampleRate/10)), sampleRate, 5000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := peak(low, 1000); got > 0.05 {
+		t.Errorf("peak of a low frequency: got: %f, want: <= 0.05", got)
+	}
+
+	high, err := io.ReadAll(effects.NewHighPass(bytes.NewReader(newSineBytes(15000, sampleRate/10)), sampleRate, 5000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := peak(high, 1000); got < 0.45 {
+		t.Errorf("peak of a high frequency: got: %f, want: >= 0.45", got)
+	}
+}
+
+func TestDelay(t *testing.T) {
+	// An impulse followed by silence.
+	src := make([]byte, 4*sampleRate/10)
+	src[0] = 0xff
+	src[1] = 0x3f
+	src[2] = 0xff
+	src[3] = 0x3f
+
+	d := effects.NewDelay(bytes.NewReader(src), sampleRate, 10*time.Millisecond, 0, 0.5)
+	out, err := io.ReadAll(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(out), len(src); got != want {
+		t.Fatalf("len(out): got: %d, want: %d", got, want)
+	}
+
+	echo := sampleRate / 100
+	for i := 1; i < len(out)/4; i++ {
+		v := int16(out[4*i]) | int16(out[4*i+1])<<8
+		if i == echo {
+			if v < 0x1f00 || v > 0x2000 {
+				t.Errorf("out[%d]: got: %d, want: around %d", i, v, 0x1fff)
+			}
+			continue
+		}
+		if v != 0 {
+			t.Errorf("out[%d]: got: %d, want: 0", i, v)
+		}
+	}
+}
+
+func TestSmallBuffers(t *testing.T) {
+	src := newSineBytes(440, sampleRate/10)
+
+	want, err := io.ReadAll(effects.NewReverb(bytes.NewReader(src), sampleRate, 0.5, 0.3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := effects.NewReverb(bytes.NewReader(src), sampleRate, 0.5, 0.3)
+	var got []byte
+	buf := make([]byte, 3)
+	for {
+		n, err := r.Read(buf)
+		got = append(got, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("reading with a small buffer must produce the same result")
+	}
+}
+
+func TestSeek(t *testing.T) {
+	src := newSineBytes(440, sampleRate/10)
+	f := effects.NewLowPass(bytes.NewReader(src), sampleRate, 1000)
+
+	first, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	second, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Errorf("the result after seeking must be the same as the first result")
+	}
+}
+
+func TestSpatial(t *testing.T) {
+	src := newSineBytes(440, sampleRate/10)
+
+	// A source at the listener's position doesn't change the stream.
+	out, err := io.ReadAll(effects.NewSpatial(bytes.NewReader(src), sampleRate, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < len(out); i += 2 {
+		got := int(int16(out[i]) | int16(out[i+1])<<8)
+		want := int(int16(src[i]) | int16(src[i+1])<<8)
+		if got-want < -1 || got-want > 1 {
+			t.Fatalf("out[%d]: got: %d, want: %d", i/2, got, want)
+		}
+	}
+
+	// A source on the left is attenuated and panned to the left.
+	s := effects.NewSpatial(bytes.NewReader(src), sampleRate, nil)
+	s.SetSourcePosition(-10, 0)
+	out, err = io.ReadAll(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The left channel is (l + r) * 0.1 = 0.1 as both channels have peaks of 0.5.
+	if got := peak(out, 0); got < 0.095 || got > 0.105 {
+		t.Errorf("peak of the left channel: got: %f, want: around 0.1", got)
+	}
+	for i := 0; i < len(out)/4; i++ {
+		if v := int16(out[4*i+2]) | int16(out[4*i+3])<<8; v != 0 {
+			t.Fatalf("right channel at %d: got: %d, want: 0", i, v)
+		}
+	}
+}
+
+func TestSpatialDoppler(t *testing.T) {
+	// An impulse followed by silence.
+	src := make([]byte, 4*sampleRate/10)
+	src[0] = 0xff
+	src[1] = 0x3f
+	src[2] = 0xff
+	src[3] = 0x3f
+
+	// The sound travels one unit per sample, so a source at 100 units is delayed by 100 samples.
+	s := effects.NewSpatial(bytes.NewReader(src), sampleRate, &effects.SpatialOptions{
+		ReferenceDistance: 1000,
+		SpeedOfSound:      sampleRate,
+	})
+	s.SetSourcePosition(0, 100)
+	out, err := io.ReadAll(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < len(out)/4; i++ {
+		v := int16(out[4*i]) | int16(out[4*i+1])<<8
+		if i == 100 {
+			if v < 0x3f00 {
+				t.Errorf("out[%d]: got: %d, want: around %d", i, v, 0x3fff)
+			}
+			continue
+		}
+		if v != 0 {
+			t.Errorf("out[%d]: got: %d, want: 0", i, v)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package effects
+
+import (
+	"io"
+	"math"
+)
+
+type filterType int
+
+const (
+	filterTypeLowPass filterType = iota
+	filterTypeHighPass
+)
+
+// Filter is a second-order (biquad) filter.
+//
+// The coefficients are based on Robert Bristow-Johnson's Audio EQ Cookbook.
+type Filter struct {
+	s *stream
+	b *biquad
+}
+
+// NewLowPass creates a low-pass filter that attenuates frequencies higher than cutoff [Hz].
+//
+// src's format must be linear PCM (signed 16bits little endian, 2 channel stereo) with the given sample rate.
+//
+// The returned Filter is seekable when src is io.Seeker.
+func NewLowPass(src io.Reader, sampleRate int, cutoff float64) *Filter {
+	return newFilter(src, filterTypeLowPass, sampleRate, cutoff)
+}
+
+// NewHighPass creates a high-pass filter that attenuates frequencies lower than cutoff [Hz].
+//
+// src's format must be linear PCM (signed 16bits little endian, 2 channel stereo) with the given sample rate.
+//
+// The returned Filter is seekable when src is io.Seeker.
+func NewHighPass(src io.Reader, sampleRate int, cutoff float64) *Filter {
+	return newFilter(src, filterTypeHighPass, sampleRate, cutoff)
+}
+
+func newFilter(src io.Reader, typ filterType, sampleRate int, cutoff float64) *Filter {
+	b := &biquad{
+		typ:        typ,
+		sampleRate: sampleRate,
+	}
+	b.setCutoff(cutoff)
+	return &Filter{
+		s: &stream{
+			src:  src,
+			proc: b,
+		},
+		b: b,
+	}
+}
+
+// Read is implementation of io.Reader's Read.
+func (f *Filter) Read(buf []byte) (int, error) {
+	return f.s.Read(buf)
+}
+
+// Seek is implementation of io.Seeker's Seek.
+//
+// Seek returns an error when the source is not io.Seeker.
+func (f *Filter) Seek(offset int64, whence int) (int64, error) {
+	return f.s.Seek(offset, whence)
+}
+
+// Cutoff returns the current cutoff frequency in Hz.
+func (f *Filter) Cutoff() float64 {
+	f.s.m.Lock()
+	defer f.s.m.Unlock()
+	return f.b.cutoff
+}
+
+// SetCutoff sets the cutoff frequency in Hz.
+//
+// SetCutoff panics if cutoff is not positive.
+func (f *Filter) SetCutoff(cutoff float64) {
+	f.s.m.Lock()
+	defer f.s.m.Unlock()
+	f.b.setCutoff(cutoff)
+}
+
+type biquad struct {
+	typ        filterType
+	sampleRate int
+	cutoff     float64
+
+	b0, b1, b2, a1, a2 float64
+
+	// x and y are the last two inputs and outputs for each channel.
+	x [channelCount][2]float64
+	y [channelCount][2]float64
+}
+
+func (b *biquad) setCutoff(cutoff float64) {
+	if cutoff <= 0 {
+		panic("effects: cutoff must be positive")
+	}
+	b.cutoff = cutoff
+
+	// The cutoff frequency must be lower than the Nyquist frequency.
+	if nyquist := float64(b.sampleRate) / 2; cutoff >= nyquist {
+		cutoff = nyquist * 0.99
+	}
+
+	const q = 1 / math.Sqrt2
+	w0 := 2 * math.Pi * cutoff / float64(b.sampleRate)
+	cos := math.Cos(w0)
+	alpha := math.Sin(w0) / (2 * q)
+
+	var b0, b1, b2 float64
+	switch b.typ {
+	case filterTypeLowPass:
+		b0 = (1 - cos) / 2
+		b1 = 1 - cos
+		b2 = (1 - cos) / 2
+	case filterTypeHighPass:
+		b0 = (1 + cos) / 2
+		b1 = -(1 + cos)
+		b2 = (1 + cos) / 2
+	}
+	a0 := 1 + alpha
+	a1 := -2 * cos
+	a2 := 1 - alpha
+
+	b.b0 = b0 / a0
+	b.b1 = b1 / a0
+	b.b2 = b2 / a0
+	b.a1 = a1 / a0
+	b.a2 = a2 / a0
+}
+
+func (b *biquad) process(l, r float64) (float64, float64) {
+	return b.processChannel(0, l), b.processChannel(1, r)
+}
+
+func (b *biquad) processChannel(ch int, x float64) float64 {
+	x1, x2 := b.x[ch][0], b.x[ch][1]
+	y1, y2 := b.y[ch][0], b.y[ch][1]
+	y := b.b0*x + b.b1*x1 + b.b2*x2 - b.a1*y1 - b.a2*y2
+	b.x[ch] = [2]float64{x, x1}
+	b.y[ch] = [2]float64{y, y1}
+	return y
+}
+
+func (b *biquad) reset() {
+	b.x = [channelCount][2]float64{}
+	b.y = [channelCount][2]float64{}
+}
@@ -0,0 +1,223 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package effects
+
+import (
+	"io"
+)
+
+// The delay lengths in samples at 44100 [Hz], taken from Freeverb.
+var (
+	combLengths    = []int{1116, 1188, 1277, 1356, 1422, 1491, 1557, 1617}
+	allpassLengths = []int{556, 441, 341, 225}
+)
+
+// stereoSpread is the difference of the delay lengths between the left and the right channels at 44100 [Hz].
+const stereoSpread = 23
+
+// Reverb is a reverberation effect based on Schroeder's reverberator (Freeverb).
+type Reverb struct {
+	s *stream
+	r *reverb
+}
+
+// NewReverb creates a reverb effect.
+//
+// src's format must be linear PCM (signed 16bits little endian, 2 channel stereo) with the given sample rate.
+//
+// roomSize is the size of the virtual room, and must be in [0, 1].
+// A bigger value makes a longer reverberation.
+// mix is the volume of the reverberation, and must be in [0, 1].
+//
+// The returned Reverb is seekable when src is io.Seeker.
+func NewReverb(src io.Reader, sampleRate int, roomSize, mix float64) *Reverb {
+	r := &reverb{}
+	for ch := 0; ch < channelCount; ch++ {
+		for _, l := range combLengths {
+			r.combs[ch] = append(r.combs[ch], newComb(scaleLength(l+ch*stereoSpread, sampleRate)))
+		}
+		for _, l := range allpassLengths {
+			r.allpasses[ch] = append(r.allpasses[ch], newAllpass(scaleLength(l+ch*stereoSpread, sampleRate)))
+		}
+	}
+	r.setRoomSize(roomSize)
+	r.setMix(mix)
+	return &Reverb{
+		s: &stream{
+			src:  src,
+			proc: r,
+		},
+		r: r,
+	}
+}
+
+func scaleLength(length int, sampleRate int) int {
+	l := length * sampleRate / 44100
+	if l < 1 {
+		l = 1
+	}
+	return l
+}
+
+// Read is implementation of io.Reader's Read.
+func (r *Reverb) Read(buf []byte) (int, error) {
+	return r.s.Read(buf)
+}
+
+// Seek is implementation of io.Seeker's Seek.
+//
+// Seek returns an error when the source is not io.Seeker.
+func (r *Reverb) Seek(offset int64, whence int) (int64, error) {
+	return r.s.Seek(offset, whence)
+}
+
+// SetRoomSize sets the size of the virtual room.
+//
+// SetRoomSize panics if roomSize is not in [0, 1].
+func (r *Reverb) SetRoomSize(roomSize float64) {
+	r.s.m.Lock()
+	defer r.s.m.Unlock()
+	r.r.setRoomSize(roomSize)
+}
+
+// SetMix sets the volume of the reverberation.
+//
+// SetMix panics if mix is not in [0, 1].
+func (r *Reverb) SetMix(mix float64) {
+	r.s.m.Lock()
+	defer r.s.m.Unlock()
+	r.r.setMix(mix)
+}
+
+type reverb struct {
+	combs     [channelCount][]*comb
+	allpasses [channelCount][]*allpass
+	feedback  float64
+	mix       float64
+}
+
+func (r *reverb) setRoomSize(roomSize float64) {
+	if roomSize < 0 || roomSize > 1 {
+		panic("effects: roomSize must be in [0, 1]")
+	}
+	// These constants are from Freeverb.
+	r.feedback = roomSize*0.28 + 0.7
+}
+
+func (r *reverb) setMix(mix float64) {
+	if mix < 0 || mix > 1 {
+		panic("effects: mix must be in [0, 1]")
+	}
+	r.mix = mix
+}
+
+func (r *reverb) process(left, right float64) (float64, float64) {
+	// The input and output gains are from Freeverb.
+	const (
+		inGain  = 0.015
+		outGain = 3
+	)
+	in := (left + right) * inGain
+	wet := r.mix * outGain
+	return left*(1-r.mix) + r.processChannel(0, in)*wet, right*(1-r.mix) + r.processChannel(1, in)*wet
+}
+
+func (r *reverb) processChannel(ch int, in float64) float64 {
+	var out float64
+	for _, c := range r.combs[ch] {
+		out += c.process(in, r.feedback)
+	}
+	for _, a := range r.allpasses[ch] {
+		out = a.process(out)
+	}
+	return out
+}
+
+func (r *reverb) reset() {
+	for ch := 0; ch < channelCount; ch++ {
+		for _, c := range r.combs[ch] {
+			c.reset()
+		}
+		for _, a := range r.allpasses[ch] {
+			a.reset()
+		}
+	}
+}
+
+// comb is a lowpass-feedback comb filter.
+type comb struct {
+	buf   []float64
+	pos   int
+	store float64
+}
+
+func newComb(length int) *comb {
+	return &comb{
+		buf: make([]float64, length),
+	}
+}
+
+func (c *comb) process(in float64, feedback float64) float64 {
+	// damp is the damping of high frequencies, from Freeverb.
+	const damp = 0.2
+
+	out := c.buf[c.pos]
+	c.store = out*(1-damp) + c.store*damp
+	c.buf[c.pos] = in + c.store*feedback
+	c.pos++
+	if c.pos == len(c.buf) {
+		c.pos = 0
+	}
+	return out
+}
+
+func (c *comb) reset() {
+	for i := range c.buf {
+		c.buf[i] = 0
+	}
+	c.pos = 0
+	c.store = 0
+}
+
+// allpass is a Schroeder allpass filter.
+type allpass struct {
+	buf []float64
+	pos int
+}
+
+func newAllpass(length int) *allpass {
+	return &allpass{
+		buf: make([]float64, length),
+	}
+}
+
+func (a *allpass) process(in float64) float64 {
+	const feedback = 0.5
+
+	b := a.buf[a.pos]
+	a.buf[a.pos] = in + b*feedback
+	a.pos++
+	if a.pos == len(a.buf) {
+		a.pos = 0
+	}
+	return b - in
+}
+
+func (a *allpass) reset() {
+	for i := range a.buf {
+		a.buf[i] = 0
+	}
+	a.pos = 0
+}
@@ -0,0 +1,234 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package effects
+
+import (
+	"io"
+	"math"
+)
+
+// maxSpatialDelay is the maximum delay for the doppler effect in seconds.
+const maxSpatialDelay = 1
+
+// spatialSmoothingTime is the time constant to change the parameters smoothly in seconds.
+const spatialSmoothingTime = 0.01
+
+// SpatialOptions represents options for NewSpatial.
+type SpatialOptions struct {
+	// ReferenceDistance is the distance where the volume starts to be attenuated.
+	// A source closer than ReferenceDistance is not amplified.
+	//
+	// The default (zero) value is 1.
+	ReferenceDistance float64
+
+	// MaxDistance is the distance where the volume stops to be attenuated.
+	//
+	// The default (zero) value means that the volume is attenuated without limit.
+	MaxDistance float64
+
+	// RolloffFactor is how quickly the volume is attenuated by the distance.
+	//
+	// The default (zero) value is 1.
+	RolloffFactor float64
+
+	// SpeedOfSound is the speed of sound in the distance unit per second.
+	// If SpeedOfSound is positive, the source is delayed by the distance, and then the doppler effect happens when
+	// the distance changes. The delay is up to 1 second.
+	//
+	// The default (zero) value means that the doppler effect is disabled.
+	SpeedOfSound float64
+}
+
+// Spatial is an effect to place the source at a position relative to a listener in 2D space.
+//
+// The volume is attenuated by the distance between the source and the listener with the inverse distance model,
+// which is the same as the Web Audio API's default:
+//
+//	gain = ReferenceDistance / (ReferenceDistance + RolloffFactor * (distance - ReferenceDistance))
+//
+// The source is panned by the horizontal direction from the listener.
+type Spatial struct {
+	s *stream
+	p *spatial
+}
+
+// NewSpatial creates a spatial effect.
+//
+// src's format must be linear PCM (signed 16bits little endian, 2 channel stereo) with the given sample rate.
+//
+// The source and the listener are at the origin by default.
+//
+// The returned Spatial is seekable when src is io.Seeker.
+func NewSpatial(src io.Reader, sampleRate int, options *SpatialOptions) *Spatial {
+	if options == nil {
+		options = &SpatialOptions{}
+	}
+	p := &spatial{
+		sampleRate:        sampleRate,
+		referenceDistance: options.ReferenceDistance,
+		maxDistance:       options.MaxDistance,
+		rolloffFactor:     options.RolloffFactor,
+		speedOfSound:      options.SpeedOfSound,
+		smoothing:         1 - math.Exp(-1/(spatialSmoothingTime*float64(sampleRate))),
+	}
+	if p.referenceDistance <= 0 {
+		p.referenceDistance = 1
+	}
+	if p.rolloffFactor <= 0 {
+		p.rolloffFactor = 1
+	}
+	if p.speedOfSound > 0 {
+		p.buf = make([][channelCount]float64, maxSpatialDelay*sampleRate+2)
+	}
+	p.updateTargets()
+	return &Spatial{
+		s: &stream{
+			src:  src,
+			proc: p,
+		},
+		p: p,
+	}
+}
+
+// Read is implementation of io.Reader's Read.
+func (s *Spatial) Read(buf []byte) (int, error) {
+	return s.s.Read(buf)
+}
+
+// Seek is implementation of io.Seeker's Seek.
+//
+// Seek returns an error when the source is not io.Seeker.
+func (s *Spatial) Seek(offset int64, whence int) (int64, error) {
+	return s.s.Seek(offset, whence)
+}
+
+// SetListenerPosition sets the listener's position.
+func (s *Spatial) SetListenerPosition(x, y float64) {
+	s.s.m.Lock()
+	defer s.s.m.Unlock()
+	s.p.listenerX = x
+	s.p.listenerY = y
+	s.p.updateTargets()
+}
+
+// SetSourcePosition sets the source's position.
+func (s *Spatial) SetSourcePosition(x, y float64) {
+	s.s.m.Lock()
+	defer s.s.m.Unlock()
+	s.p.sourceX = x
+	s.p.sourceY = y
+	s.p.updateTargets()
+}
+
+type spatial struct {
+	sampleRate        int
+	referenceDistance float64
+	maxDistance       float64
+	rolloffFactor     float64
+	speedOfSound      float64
+	smoothing         float64
+
+	listenerX float64
+	listenerY float64
+	sourceX   float64
+	sourceY   float64
+
+	targetGain  float64
+	targetPan   float64
+	targetDelay float64
+
+	// gain, pan and delay are the current parameters, which approach the targets smoothly to avoid noises.
+	gain        float64
+	pan         float64
+	delay       float64
+	initialized bool
+
+	buf [][channelCount]float64
+	pos int
+}
+
+func (s *spatial) updateTargets() {
+	dx := s.sourceX - s.listenerX
+	dy := s.sourceY - s.listenerY
+	d := math.Hypot(dx, dy)
+
+	dc := math.Max(d, s.referenceDistance)
+	if s.maxDistance > 0 {
+		dc = math.Min(dc, s.maxDistance)
+	}
+	s.targetGain = s.referenceDistance / (s.referenceDistance + s.rolloffFactor*(dc-s.referenceDistance))
+	s.targetPan = math.Max(-1, math.Min(dx/math.Max(d, s.referenceDistance), 1))
+
+	if s.buf != nil {
+		s.targetDelay = math.Min(d/s.speedOfSound*float64(s.sampleRate), float64(len(s.buf)-2))
+	}
+}
+
+func (s *spatial) process(l, r float64) (float64, float64) {
+	if !s.initialized {
+		s.gain = s.targetGain
+		s.pan = s.targetPan
+		s.delay = s.targetDelay
+		s.initialized = true
+	} else {
+		s.gain += (s.targetGain - s.gain) * s.smoothing
+		s.pan += (s.targetPan - s.pan) * s.smoothing
+		s.delay += (s.targetDelay - s.delay) * s.smoothing
+	}
+
+	if s.buf != nil {
+		s.buf[s.pos] = [channelCount]float64{l, r}
+
+		// Read the delayed sample with linear interpolation.
+		p := float64(s.pos) - s.delay
+		if p < 0 {
+			p += float64(len(s.buf))
+		}
+		i0 := int(p)
+		i1 := i0 + 1
+		if i1 == len(s.buf) {
+			i1 = 0
+		}
+		f := p - float64(i0)
+		l = s.buf[i0][0]*(1-f) + s.buf[i1][0]*f
+		r = s.buf[i0][1]*(1-f) + s.buf[i1][1]*f
+
+		s.pos++
+		if s.pos == len(s.buf) {
+			s.pos = 0
+		}
+	}
+
+	// Pan in the same way as the Web Audio API's StereoPannerNode.
+	var outL, outR float64
+	if s.pan <= 0 {
+		x := (s.pan + 1) * math.Pi / 2
+		outL = l + r*math.Cos(x)
+		outR = r * math.Sin(x)
+	} else {
+		x := s.pan * math.Pi / 2
+		outL = l * math.Cos(x)
+		outR = r + l*math.Sin(x)
+	}
+	return outL * s.gain, outR * s.gain
+}
+
+func (s *spatial) reset() {
+	for i := range s.buf {
+		s.buf[i] = [channelCount]float64{}
+	}
+	s.pos = 0
+	s.initialized = false
+}
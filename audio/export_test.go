@@ -17,15 +17,17 @@ package audio
 import (
 	"io"
 	"sync"
+	"time"
 )
 
 type (
 	dummyContext struct{}
 	dummyPlayer  struct {
-		r       io.Reader
-		playing bool
-		volume  float64
-		m       sync.Mutex
+		r        io.Reader
+		playing  bool
+		volume   float64
+		unplayed int
+		m        sync.Mutex
 	}
 )
 
@@ -87,7 +89,9 @@ func (p *dummyPlayer) SetVolume(volume float64) {
 }
 
 func (p *dummyPlayer) UnplayedBufferSize() int {
-	return 0
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.unplayed
 }
 
 func (p *dummyPlayer) Err() error {
@@ -147,6 +151,33 @@ func PlayersCountForTesting() int {
 	return n
 }
 
+func (p *Player) UnderlyingVolumeForTesting() float64 {
+	p.p.m.Lock()
+	defer p.p.m.Unlock()
+	if p.p.player == nil {
+		return 0
+	}
+	return p.p.player.Volume()
+}
+
+// SetUnderlyingUnplayedBufferSizeForTesting sets the size in bytes of the data that the underlying player has read but not played yet.
+func (p *Player) SetUnderlyingUnplayedBufferSizeForTesting(size int) {
+	p.p.m.Lock()
+	defer p.p.m.Unlock()
+	pl := p.p.player.(*dummyPlayer)
+	pl.m.Lock()
+	defer pl.m.Unlock()
+	pl.unplayed = size
+}
+
+func DefaultDeviceBufferSizeForTesting(sampleRate int) time.Duration {
+	return defaultDeviceBufferSize(sampleRate)
+}
+
+func NewFuncStreamForTesting(f func(buf []float64)) io.Reader {
+	return &funcStream{f: f}
+}
+
 func ResetContextForTesting() {
 	theContext = nil
 }
@@ -154,3 +185,32 @@ func ResetContextForTesting() {
 func (i *InfiniteLoop) SetNoBlendForTesting(value bool) {
 	i.noBlendForTesting = value
 }
+
+type CaptureBufferForTesting struct {
+	b *captureBuffer
+}
+
+func NewCaptureBufferForTesting(sampleRate int) *CaptureBufferForTesting {
+	return &CaptureBufferForTesting{b: newCaptureBuffer(sampleRate)}
+}
+
+func (c *CaptureBufferForTesting) Write(pcm []byte) {
+	c.b.write(pcm)
+}
+
+func (c *CaptureBufferForTesting) Read(buf []byte) (int, error) {
+	return c.b.Read(buf)
+}
+
+func (c *CaptureBufferForTesting) Close() error {
+	return c.b.close()
+}
+
+func (c *CaptureBufferForTesting) CloseWithError(err error) {
+	c.b.closeWithError(err)
+}
+
+// NewCaptureForTesting returns a Capture that uses the buffer as its back end instead of an audio input device.
+func (c *CaptureBufferForTesting) NewCaptureForTesting() *Capture {
+	return &Capture{c: c}
+}
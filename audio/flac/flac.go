@@ -0,0 +1,296 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flac provides FLAC decoder.
+package flac
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hajimehoshi/ebiten/v2/audio/internal/convert"
+)
+
+// Stream is a decoded audio stream.
+type Stream struct {
+	decoded io.ReadSeeker
+	size    int64
+}
+
+// Read is implementation of io.Reader's Read.
+func (s *Stream) Read(p []byte) (int, error) {
+	return s.decoded.Read(p)
+}
+
+// Seek is implementation of io.Seeker's Seek.
+//
+// Note that Seek can take long since decoding is a relatively heavy task.
+func (s *Stream) Seek(offset int64, whence int) (int64, error) {
+	return s.decoded.Seek(offset, whence)
+}
+
+// Length returns the size of decoded stream in bytes.
+func (s *Stream) Length() int64 {
+	return s.size
+}
+
+type frameIndex struct {
+	offset int
+	sample int64
+}
+
+// decoded is a 16bit PCM stream with the original channels, decoded from FLAC frames lazily.
+type decoded struct {
+	data []byte
+	info streamInfo
+
+	// frameOffset is the offset of the next frame to decode.
+	frameOffset int
+
+	// frameSample is the index of the first sample of the next frame to decode.
+	frameSample int64
+
+	// index records the offsets of the decoded frames for seeking.
+	index []frameIndex
+
+	samples [][]int32
+	buf     []byte
+	bufPos  int
+	pos     int64
+}
+
+func (d *decoded) bytesPerSample() int64 {
+	return int64(d.info.channelCount) * 2
+}
+
+func (d *decoded) decodeNextFrame() error {
+	if n := len(d.index); n == 0 || d.index[n-1].offset < d.frameOffset {
+		d.index = append(d.index, frameIndex{
+			offset: d.frameOffset,
+			sample: d.frameSample,
+		})
+	}
+
+	samples, n, next, err := decodeFrame(d.data, d.frameOffset, &d.info, d.samples)
+	if err != nil {
+		return err
+	}
+	d.samples = samples
+	d.frameOffset = next
+	d.frameSample += int64(n)
+
+	// Ignore the samples beyond the total samples.
+	if d.info.totalSamples > 0 && d.frameSample > d.info.totalSamples {
+		n -= int(d.frameSample - d.info.totalSamples)
+		if n < 0 {
+			n = 0
+		}
+	}
+
+	d.buf = d.buf[:0]
+	for i := 0; i < n; i++ {
+		for ch := 0; ch < d.info.channelCount; ch++ {
+			v := int16(samples[ch][i])
+			d.buf = append(d.buf, byte(v), byte(v>>8))
+		}
+	}
+	d.bufPos = 0
+	return nil
+}
+
+func (d *decoded) Read(b []byte) (int, error) {
+	var n int
+	for n < len(b) {
+		if d.bufPos == len(d.buf) {
+			if d.frameOffset >= len(d.data) || (d.info.totalSamples > 0 && d.frameSample >= d.info.totalSamples) {
+				break
+			}
+			if err := d.decodeNextFrame(); err != nil {
+				d.pos += int64(n)
+				return n, err
+			}
+			continue
+		}
+		c := copy(b[n:], d.buf[d.bufPos:])
+		d.bufPos += c
+		n += c
+	}
+	d.pos += int64(n)
+	if n == 0 && len(b) > 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+func (d *decoded) Seek(offset int64, whence int) (int64, error) {
+	next := int64(0)
+	switch whence {
+	case io.SeekStart:
+		next = offset
+	case io.SeekCurrent:
+		next = d.pos + offset
+	case io.SeekEnd:
+		next = d.Length() + offset
+	}
+	if next < 0 {
+		return 0, fmt.Errorf("flac: invalid offset: %d", next)
+	}
+	if next > d.Length() {
+		next = d.Length()
+	}
+	next = next / d.bytesPerSample() * d.bytesPerSample()
+
+	// Restart decoding from the last known frame before the target.
+	target := next / d.bytesPerSample()
+	// index[0] is always the first frame.
+	i := len(d.index) - 1
+	for i > 0 && d.index[i].sample > target {
+		i--
+	}
+	d.frameOffset = d.index[i].offset
+	d.frameSample = d.index[i].sample
+	d.buf = d.buf[:0]
+	d.bufPos = 0
+
+	for d.frameSample <= target && d.frameOffset < len(d.data) {
+		start := d.frameSample
+		if err := d.decodeNextFrame(); err != nil {
+			return 0, err
+		}
+		if target < d.frameSample {
+			d.bufPos = int((target - start) * d.bytesPerSample())
+			if d.bufPos > len(d.buf) {
+				d.bufPos = len(d.buf)
+			}
+			break
+		}
+		d.bufPos = len(d.buf)
+	}
+
+	d.pos = next
+	return next, nil
+}
+
+func (d *decoded) Length() int64 {
+	return d.info.totalSamples * d.bytesPerSample()
+}
+
+// decode reads the whole FLAC data from src and returns a decoded stream.
+func decode(src io.Reader) (*decoded, error) {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+	info, offset, err := parseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	if info.channelCount != 1 && info.channelCount != 2 {
+		return nil, fmt.Errorf("flac: number of channels must be 1 or 2 but was %d", info.channelCount)
+	}
+
+	d := &decoded{
+		data:        data,
+		info:        info,
+		frameOffset: offset,
+		index: []frameIndex{
+			{offset: offset},
+		},
+	}
+
+	// The total number of samples might be unknown. Count the samples by decoding all the frames.
+	if d.info.totalSamples == 0 {
+		for d.frameOffset < len(d.data) {
+			// Some files have trailing data like an ID3v1 tag after the last frame. Ignore them.
+			if !hasFrameSync(d.data[d.frameOffset:]) {
+				d.data = d.data[:d.frameOffset]
+				break
+			}
+			if err := d.decodeNextFrame(); err != nil {
+				return nil, err
+			}
+		}
+		d.info.totalSamples = d.frameSample
+		d.frameOffset = offset
+		d.frameSample = 0
+		d.buf = d.buf[:0]
+		d.bufPos = 0
+	}
+
+	return d, nil
+}
+
+// DecodeWithoutResampling decodes FLAC data to playable stream.
+//
+// The format must be 1 or 2 channels, and 24bit or less per sample.
+// The format is converted into 2 channels and 16bit.
+//
+// DecodeWithoutResampling returns error when decoding fails or IO error happens.
+//
+// DecodeWithoutResampling reads the whole src into memory, and the FLAC frames are decoded on demand.
+// The returned Stream's Seek is always available.
+//
+// A Stream doesn't close src even if src implements io.Closer.
+// Closing the source is src owner's responsibility.
+func DecodeWithoutResampling(src io.Reader) (*Stream, error) {
+	decoded, err := decode(src)
+	if err != nil {
+		return nil, err
+	}
+	var s io.ReadSeeker = decoded
+	size := decoded.Length()
+	if decoded.info.channelCount == 1 {
+		s = convert.NewStereo16(s, true, false)
+		size *= 2
+	}
+	stream := &Stream{
+		decoded: s,
+		size:    size,
+	}
+	return stream, nil
+}
+
+// DecodeWithSampleRate decodes FLAC data to playable stream.
+//
+// The format must be 1 or 2 channels, and 24bit or less per sample.
+// The format is converted into 2 channels and 16bit.
+//
+// DecodeWithSampleRate returns error when decoding fails or IO error happens.
+//
+// DecodeWithSampleRate automatically resamples the stream to fit with sampleRate if necessary.
+//
+// DecodeWithSampleRate reads the whole src into memory, and the FLAC frames are decoded on demand.
+// The returned Stream's Seek is always available.
+//
+// A Stream doesn't close src even if src implements io.Closer.
+// Closing the source is src owner's responsibility.
+func DecodeWithSampleRate(sampleRate int, src io.Reader) (*Stream, error) {
+	decoded, err := decode(src)
+	if err != nil {
+		return nil, err
+	}
+	var s io.ReadSeeker = decoded
+	size := decoded.Length()
+	if decoded.info.channelCount == 1 {
+		s = convert.NewStereo16(s, true, false)
+		size *= 2
+	}
+	if origSampleRate := decoded.info.sampleRate; origSampleRate != sampleRate {
+		r := convert.NewResampling(s, size, origSampleRate, sampleRate)
+		s = r
+		size = r.Length()
+	}
+	stream := &Stream{decoded: s, size: size}
+	return stream, nil
+}
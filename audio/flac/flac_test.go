@@ -0,0 +1,188 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flac_test
+
+import (
+	"bytes"
+	"crypto/md5"
+	_ "embed"
+	"io"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/audio/flac"
+)
+
+var (
+	// test_mono.flac is 16bit mono at 44100 Hz with 576-sample blocks.
+	//go:embed test_mono.flac
+	test_mono_flac []byte
+
+	// test_stereo.flac is 16bit stereo at 48000 Hz with 4096-sample blocks.
+	//go:embed test_stereo.flac
+	test_stereo_flac []byte
+
+	// test_stereo24.flac has the same signal as test_stereo.flac in 24bit.
+	//go:embed test_stereo24.flac
+	test_stereo24_flac []byte
+)
+
+// streamInfoMD5 returns the MD5 signature of the unencoded samples in STREAMINFO.
+// STREAMINFO is the first metadata block, and its MD5 signature is at the last 16 bytes.
+func streamInfoMD5(src []byte) []byte {
+	return src[26:42]
+}
+
+func decodeAll(t *testing.T, src []byte) []byte {
+	t.Helper()
+
+	s, err := flac.DecodeWithoutResampling(bytes.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(len(got)) != s.Length() {
+		t.Errorf("len(data): got: %d, want: %d", len(got), s.Length())
+	}
+	return got
+}
+
+func TestDecodeMono(t *testing.T) {
+	got := decodeAll(t, test_mono_flac)
+
+	// The decoded stream is always stereo. Take the left channel to compare it with the original mono samples.
+	mono := make([]byte, 0, len(got)/2)
+	for i := 0; i < len(got); i += 4 {
+		mono = append(mono, got[i], got[i+1])
+	}
+	if sum := md5.Sum(mono); !bytes.Equal(sum[:], streamInfoMD5(test_mono_flac)) {
+		t.Errorf("MD5 mismatch")
+	}
+	for i := 0; i < len(got); i += 4 {
+		if got[i] != got[i+2] || got[i+1] != got[i+3] {
+			t.Fatalf("left and right channels must be the same at %d", i/4)
+		}
+	}
+}
+
+func TestDecodeStereo(t *testing.T) {
+	got := decodeAll(t, test_stereo_flac)
+	if sum := md5.Sum(got); !bytes.Equal(sum[:], streamInfoMD5(test_stereo_flac)) {
+		t.Errorf("MD5 mismatch")
+	}
+
+	// 24bit samples are converted into 16bit by dropping the lower bits.
+	if got24 := decodeAll(t, test_stereo24_flac); !bytes.Equal(got24, got) {
+		t.Errorf("decoded 24bit data mismatch")
+	}
+}
+
+func TestDecodeWithoutTotalSamples(t *testing.T) {
+	want := decodeAll(t, test_mono_flac)
+
+	// Zero means the total number of samples is unknown.
+	src := make([]byte, len(test_mono_flac))
+	copy(src, test_mono_flac)
+	src[21] &^= 0xf
+	src[22], src[23], src[24], src[25] = 0, 0, 0, 0
+	if got := decodeAll(t, src); !bytes.Equal(got, want) {
+		t.Errorf("decoded data mismatch")
+	}
+
+	// Trailing data like an ID3v1 tag is ignored.
+	tag := make([]byte, 128)
+	copy(tag, "TAG")
+	src = append(src, tag...)
+	if got := decodeAll(t, src); !bytes.Equal(got, want) {
+		t.Errorf("decoded data with trailing data mismatch")
+	}
+}
+
+func TestSeek(t *testing.T) {
+	want := decodeAll(t, test_stereo_flac)
+
+	s, err := flac.DecodeWithoutResampling(bytes.NewReader(test_stereo_flac))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, offset := range []int64{20000, 400, 0, 16382, 16384, 1234, int64(len(want)) - 100, int64(len(want))} {
+		n, err := s.Seek(offset, io.SeekStart)
+		if err != nil {
+			t.Fatal(err)
+		}
+		offset := offset / 4 * 4
+		if n != offset {
+			t.Errorf("Seek(%d): got: %d, want: %d", offset, n, offset)
+		}
+		buf := make([]byte, 256)
+		m, err := io.ReadFull(s, buf)
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			t.Fatal(err)
+		}
+		end := offset + 256
+		if end > int64(len(want)) {
+			end = int64(len(want))
+		}
+		if !bytes.Equal(buf[:m], want[offset:end]) {
+			t.Errorf("data after Seek(%d) mismatch", offset)
+		}
+	}
+}
+
+func TestDecodeWithSampleRate(t *testing.T) {
+	want := decodeAll(t, test_stereo_flac)
+
+	s, err := flac.DecodeWithSampleRate(48000, bytes.NewReader(test_stereo_flac))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("decoded data mismatch")
+	}
+
+	s, err = flac.DecodeWithSampleRate(44100, bytes.NewReader(test_stereo_flac))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = io.ReadAll(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(len(got)) != s.Length() {
+		t.Errorf("len(data): got: %d, want: %d", len(got), s.Length())
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	if _, err := flac.DecodeWithoutResampling(bytes.NewReader([]byte("RIFF0000WAVE"))); err == nil {
+		t.Errorf("DecodeWithoutResampling must return an error for non-FLAC data")
+	}
+
+	src := test_stereo_flac[:len(test_stereo_flac)/2]
+	s, err := flac.DecodeWithoutResampling(bytes.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(s); err == nil {
+		t.Errorf("reading truncated data must return an error")
+	}
+}
@@ -0,0 +1,560 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flac
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+var errUnexpectedEnd = errors.New("flac: unexpected end of data")
+
+// bitReader reads bits from a byte slice in the MSB-first order.
+type bitReader struct {
+	data  []byte
+	pos   int
+	cache uint64
+	n     uint
+}
+
+func (b *bitReader) readBits(n uint) (uint32, error) {
+	if n == 0 {
+		return 0, nil
+	}
+	for b.n < n {
+		if b.pos >= len(b.data) {
+			return 0, errUnexpectedEnd
+		}
+		b.cache = b.cache<<8 | uint64(b.data[b.pos])
+		b.pos++
+		b.n += 8
+	}
+	b.n -= n
+	return uint32(b.cache>>b.n) & (1<<n - 1), nil
+}
+
+func (b *bitReader) readSigned(n uint) (int32, error) {
+	v, err := b.readBits(n)
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	return int32(v<<(32-n)) >> (32 - n), nil
+}
+
+// readUnary reads the number of 0 bits followed by a 1 bit.
+func (b *bitReader) readUnary() (uint32, error) {
+	var v uint32
+	for {
+		bit, err := b.readBits(1)
+		if err != nil {
+			return 0, err
+		}
+		if bit == 1 {
+			return v, nil
+		}
+		v++
+	}
+}
+
+// alignToByte discards the bits until the next byte boundary.
+func (b *bitReader) alignToByte() {
+	b.n -= b.n % 8
+}
+
+// bytePos returns the position of the next unread byte.
+// bytePos must be called only when the reader is aligned to a byte boundary.
+func (b *bitReader) bytePos() int {
+	return b.pos - int(b.n/8)
+}
+
+type streamInfo struct {
+	sampleRate    int
+	channelCount  int
+	bitsPerSample int
+	totalSamples  int64
+}
+
+// parseHeader parses the stream marker and the metadata blocks, and returns STREAMINFO and the offset of the first
+// frame.
+func parseHeader(data []byte) (streamInfo, int, error) {
+	// Skip an ID3v2 tag if exists. Some encoders put it before the stream marker.
+	pos := 0
+	if len(data) >= 10 && bytes.Equal(data[:3], []byte("ID3")) {
+		size := int(data[6]&0x7f)<<21 | int(data[7]&0x7f)<<14 | int(data[8]&0x7f)<<7 | int(data[9]&0x7f)
+		pos = 10 + size
+		if data[5]&0x10 != 0 {
+			// Footer
+			pos += 10
+		}
+	}
+
+	if len(data) < pos+4 || !bytes.Equal(data[pos:pos+4], []byte("fLaC")) {
+		return streamInfo{}, 0, fmt.Errorf("flac: invalid header: 'fLaC' not found")
+	}
+	pos += 4
+
+	var info streamInfo
+	var infoFound bool
+	for {
+		if len(data) < pos+4 {
+			return streamInfo{}, 0, fmt.Errorf("flac: invalid metadata block header")
+		}
+		last := data[pos]&0x80 != 0
+		typ := data[pos] & 0x7f
+		size := int(data[pos+1])<<16 | int(data[pos+2])<<8 | int(data[pos+3])
+		pos += 4
+		if len(data) < pos+size {
+			return streamInfo{}, 0, fmt.Errorf("flac: invalid metadata block")
+		}
+		if typ == 0 {
+			if size < 34 {
+				return streamInfo{}, 0, fmt.Errorf("flac: invalid STREAMINFO")
+			}
+			b := data[pos : pos+size]
+			info.sampleRate = int(b[10])<<12 | int(b[11])<<4 | int(b[12])>>4
+			info.channelCount = int(b[12]>>1&0x7) + 1
+			info.bitsPerSample = int(b[12]&0x1)<<4 | int(b[13]>>4) + 1
+			info.totalSamples = int64(b[13]&0xf)<<32 | int64(b[14])<<24 | int64(b[15])<<16 | int64(b[16])<<8 | int64(b[17])
+			infoFound = true
+		}
+		pos += size
+		if last {
+			break
+		}
+	}
+	if !infoFound {
+		return streamInfo{}, 0, fmt.Errorf("flac: STREAMINFO not found")
+	}
+	if info.bitsPerSample > 24 {
+		return streamInfo{}, 0, fmt.Errorf("flac: bits per sample must be 24 or less but was %d", info.bitsPerSample)
+	}
+	if info.sampleRate == 0 {
+		return streamInfo{}, 0, fmt.Errorf("flac: invalid sample rate: 0")
+	}
+	return info, pos, nil
+}
+
+const (
+	channelsIndependent = iota
+	channelsLeftSide
+	channelsSideRight
+	channelsMidSide
+)
+
+// hasFrameSync reports whether data starts with a frame sync code.
+func hasFrameSync(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0xff && data[1]&0xfc == 0xf8
+}
+
+// decodeFrame decodes a frame at data[pos:] into 16bit samples of each channel.
+// samples is reused as a buffer if it has enough capacity.
+// decodeFrame returns the samples, the number of the samples per channel, and the offset of the next frame.
+//
+// The CRCs are not verified.
+func decodeFrame(data []byte, pos int, info *streamInfo, samples [][]int32) ([][]int32, int, int, error) {
+	b := &bitReader{data: data, pos: pos}
+
+	sync, err := b.readBits(14)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if sync != 0x3ffe {
+		return nil, 0, 0, fmt.Errorf("flac: invalid frame sync code")
+	}
+	// Reserved bit and blocking strategy bit.
+	if _, err := b.readBits(2); err != nil {
+		return nil, 0, 0, err
+	}
+	blockSizeCode, err := b.readBits(4)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	sampleRateCode, err := b.readBits(4)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	channelCode, err := b.readBits(4)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	sampleSizeCode, err := b.readBits(3)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	// Reserved bit.
+	if _, err := b.readBits(1); err != nil {
+		return nil, 0, 0, err
+	}
+
+	// Skip the frame or sample number coded like UTF-8.
+	first, err := b.readBits(8)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	for i := 1; i < bits.LeadingZeros8(^uint8(first)); i++ {
+		if _, err := b.readBits(8); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	var blockSize int
+	switch {
+	case blockSizeCode == 0:
+		return nil, 0, 0, fmt.Errorf("flac: invalid block size code: %d", blockSizeCode)
+	case blockSizeCode == 1:
+		blockSize = 192
+	case blockSizeCode <= 5:
+		blockSize = 576 << (blockSizeCode - 2)
+	case blockSizeCode == 6:
+		v, err := b.readBits(8)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		blockSize = int(v) + 1
+	case blockSizeCode == 7:
+		v, err := b.readBits(16)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		blockSize = int(v) + 1
+	default:
+		blockSize = 256 << (blockSizeCode - 8)
+	}
+
+	// The sample rate in a frame header is ignored. STREAMINFO's sample rate is used.
+	switch sampleRateCode {
+	case 12:
+		if _, err := b.readBits(8); err != nil {
+			return nil, 0, 0, err
+		}
+	case 13, 14:
+		if _, err := b.readBits(16); err != nil {
+			return nil, 0, 0, err
+		}
+	case 15:
+		return nil, 0, 0, fmt.Errorf("flac: invalid sample rate code: %d", sampleRateCode)
+	}
+
+	var bitsPerSample int
+	switch sampleSizeCode {
+	case 0:
+		bitsPerSample = info.bitsPerSample
+	case 1:
+		bitsPerSample = 8
+	case 2:
+		bitsPerSample = 12
+	case 4:
+		bitsPerSample = 16
+	case 5:
+		bitsPerSample = 20
+	case 6:
+		bitsPerSample = 24
+	default:
+		return nil, 0, 0, fmt.Errorf("flac: invalid sample size code: %d", sampleSizeCode)
+	}
+
+	var channelCount int
+	assignment := channelsIndependent
+	switch {
+	case channelCode < 8:
+		channelCount = int(channelCode) + 1
+	case channelCode == 8:
+		channelCount = 2
+		assignment = channelsLeftSide
+	case channelCode == 9:
+		channelCount = 2
+		assignment = channelsSideRight
+	case channelCode == 10:
+		channelCount = 2
+		assignment = channelsMidSide
+	default:
+		return nil, 0, 0, fmt.Errorf("flac: invalid channel assignment: %d", channelCode)
+	}
+	if channelCount != info.channelCount {
+		return nil, 0, 0, fmt.Errorf("flac: number of channels mismatch: %d vs %d", channelCount, info.channelCount)
+	}
+
+	// CRC-8
+	if _, err := b.readBits(8); err != nil {
+		return nil, 0, 0, err
+	}
+
+	if len(samples) < channelCount {
+		samples = make([][]int32, channelCount)
+	}
+	for ch := 0; ch < channelCount; ch++ {
+		bps := bitsPerSample
+		// A side channel has one more bit.
+		if (assignment == channelsLeftSide && ch == 1) || (assignment == channelsSideRight && ch == 0) || (assignment == channelsMidSide && ch == 1) {
+			bps++
+		}
+		if cap(samples[ch]) < blockSize {
+			samples[ch] = make([]int32, blockSize)
+		}
+		samples[ch] = samples[ch][:blockSize]
+		if err := decodeSubframe(b, bps, samples[ch]); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	switch assignment {
+	case channelsLeftSide:
+		l, s := samples[0], samples[1]
+		for i := range s {
+			s[i] = l[i] - s[i]
+		}
+	case channelsSideRight:
+		s, r := samples[0], samples[1]
+		for i := range s {
+			s[i] += r[i]
+		}
+	case channelsMidSide:
+		m, s := samples[0], samples[1]
+		for i := range m {
+			mid := int64(m[i])<<1 | int64(s[i]&1)
+			side := int64(s[i])
+			m[i] = int32((mid + side) >> 1)
+			s[i] = int32((mid - side) >> 1)
+		}
+	}
+
+	// CRC-16
+	b.alignToByte()
+	if _, err := b.readBits(16); err != nil {
+		return nil, 0, 0, err
+	}
+
+	if bitsPerSample != 16 {
+		for ch := 0; ch < channelCount; ch++ {
+			s := samples[ch]
+			for i := range s {
+				if bitsPerSample > 16 {
+					s[i] >>= uint(bitsPerSample - 16)
+				} else {
+					s[i] <<= uint(16 - bitsPerSample)
+				}
+			}
+		}
+	}
+
+	return samples, blockSize, b.bytePos(), nil
+}
+
+func decodeSubframe(b *bitReader, bitsPerSample int, samples []int32) error {
+	header, err := b.readBits(8)
+	if err != nil {
+		return err
+	}
+	if header&0x80 != 0 {
+		return fmt.Errorf("flac: invalid subframe header")
+	}
+	typ := header >> 1 & 0x3f
+
+	var wasted int
+	if header&1 != 0 {
+		n, err := b.readUnary()
+		if err != nil {
+			return err
+		}
+		wasted = int(n) + 1
+		bitsPerSample -= wasted
+		if bitsPerSample <= 0 {
+			return fmt.Errorf("flac: invalid wasted bits: %d", wasted)
+		}
+	}
+
+	switch {
+	case typ == 0:
+		v, err := b.readSigned(uint(bitsPerSample))
+		if err != nil {
+			return err
+		}
+		for i := range samples {
+			samples[i] = v
+		}
+	case typ == 1:
+		for i := range samples {
+			v, err := b.readSigned(uint(bitsPerSample))
+			if err != nil {
+				return err
+			}
+			samples[i] = v
+		}
+	case typ >= 8 && typ <= 12:
+		order := int(typ - 8)
+		if order > len(samples) {
+			return fmt.Errorf("flac: invalid predictor order: %d", order)
+		}
+		for i := 0; i < order; i++ {
+			v, err := b.readSigned(uint(bitsPerSample))
+			if err != nil {
+				return err
+			}
+			samples[i] = v
+		}
+		if err := decodeResidual(b, order, samples); err != nil {
+			return err
+		}
+		restoreFixed(order, samples)
+	case typ >= 32:
+		order := int(typ - 31)
+		if order > len(samples) {
+			return fmt.Errorf("flac: invalid predictor order: %d", order)
+		}
+		for i := 0; i < order; i++ {
+			v, err := b.readSigned(uint(bitsPerSample))
+			if err != nil {
+				return err
+			}
+			samples[i] = v
+		}
+		p, err := b.readBits(4)
+		if err != nil {
+			return err
+		}
+		if p == 0xf {
+			return fmt.Errorf("flac: invalid LPC precision")
+		}
+		precision := uint(p) + 1
+		shift, err := b.readSigned(5)
+		if err != nil {
+			return err
+		}
+		if shift < 0 {
+			return fmt.Errorf("flac: invalid LPC shift: %d", shift)
+		}
+		var coeffs [32]int32
+		for i := 0; i < order; i++ {
+			c, err := b.readSigned(precision)
+			if err != nil {
+				return err
+			}
+			coeffs[i] = c
+		}
+		if err := decodeResidual(b, order, samples); err != nil {
+			return err
+		}
+		for i := order; i < len(samples); i++ {
+			var sum int64
+			for j := 0; j < order; j++ {
+				sum += int64(coeffs[j]) * int64(samples[i-j-1])
+			}
+			samples[i] += int32(sum >> uint(shift))
+		}
+	default:
+		return fmt.Errorf("flac: invalid subframe type: %d", typ)
+	}
+
+	if wasted > 0 {
+		for i := range samples {
+			samples[i] <<= uint(wasted)
+		}
+	}
+	return nil
+}
+
+// decodeResidual decodes the residual into samples[order:].
+func decodeResidual(b *bitReader, order int, samples []int32) error {
+	method, err := b.readBits(2)
+	if err != nil {
+		return err
+	}
+	var paramBits uint
+	switch method {
+	case 0:
+		paramBits = 4
+	case 1:
+		paramBits = 5
+	default:
+		return fmt.Errorf("flac: invalid residual coding method: %d", method)
+	}
+	escape := uint32(1)<<paramBits - 1
+
+	partitionOrder, err := b.readBits(4)
+	if err != nil {
+		return err
+	}
+	partitionCount := 1 << partitionOrder
+	if len(samples)%partitionCount != 0 {
+		return fmt.Errorf("flac: invalid partition order: %d", partitionOrder)
+	}
+	partitionSize := len(samples) / partitionCount
+	if partitionSize < order {
+		return fmt.Errorf("flac: invalid partition order: %d", partitionOrder)
+	}
+
+	i := order
+	for p := 0; p < partitionCount; p++ {
+		end := (p + 1) * partitionSize
+		param, err := b.readBits(paramBits)
+		if err != nil {
+			return err
+		}
+		if param == escape {
+			n, err := b.readBits(5)
+			if err != nil {
+				return err
+			}
+			for ; i < end; i++ {
+				v, err := b.readSigned(uint(n))
+				if err != nil {
+					return err
+				}
+				samples[i] = v
+			}
+			continue
+		}
+		for ; i < end; i++ {
+			q, err := b.readUnary()
+			if err != nil {
+				return err
+			}
+			r, err := b.readBits(uint(param))
+			if err != nil {
+				return err
+			}
+			u := q<<param | r
+			samples[i] = int32(u>>1) ^ -int32(u&1)
+		}
+	}
+	return nil
+}
+
+// restoreFixed restores the samples from the residual with the fixed predictor.
+func restoreFixed(order int, s []int32) {
+	switch order {
+	case 1:
+		for i := 1; i < len(s); i++ {
+			s[i] += s[i-1]
+		}
+	case 2:
+		for i := 2; i < len(s); i++ {
+			s[i] += 2*s[i-1] - s[i-2]
+		}
+	case 3:
+		for i := 3; i < len(s); i++ {
+			s[i] += 3*s[i-1] - 3*s[i-2] + s[i-3]
+		}
+	case 4:
+		for i := 4; i < len(s); i++ {
+			s[i] += 4*s[i-1] - 6*s[i-2] + 4*s[i-3] - s[i-4]
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"fmt"
+	"math"
+)
+
+// NewPlayerFromFunc creates a new player that plays samples generated by f.
+//
+// f is called every time the player needs more samples, and must fill buf with interleaved stereo samples
+// (left, right, left, right, ...) in [-1, 1]. Values out of the range are clipped.
+// len(buf) is always even, and the sample rate is the context's sample rate.
+//
+// f is called on a different goroutine from the game's goroutine. f must not block for a long time.
+//
+// The player never reaches the end, and cannot be rewound or seeked.
+// The latency depends on the player's buffer size. Use SetBufferSize to reduce the latency if needed.
+func (c *Context) NewPlayerFromFunc(f func(buf []float64)) *Player {
+	p, err := c.NewPlayer(&funcStream{f: f})
+	if err != nil {
+		// Errors should never happen as funcStream is not io.Seeker.
+		panic(fmt.Sprintf("audio: %v at NewPlayerFromFunc", err))
+	}
+	return p
+}
+
+// funcStream is an endless stream of samples generated by a function.
+type funcStream struct {
+	f   func(buf []float64)
+	buf []float64
+}
+
+func (s *funcStream) Read(b []byte) (int, error) {
+	n := len(b) / bytesPerSample * bytesPerSample
+	if n == 0 {
+		return 0, nil
+	}
+
+	values := n / bitDepthInBytes
+	if cap(s.buf) < values {
+		s.buf = make([]float64, values)
+	}
+	s.buf = s.buf[:values]
+	for i := range s.buf {
+		s.buf[i] = 0
+	}
+	s.f(s.buf)
+
+	for i, v := range s.buf {
+		v = math.Max(-1, math.Min(v, 1))
+		v16 := int16(v * (1<<15 - 1))
+		b[2*i] = byte(v16)
+		b[2*i+1] = byte(v16 >> 8)
+	}
+	return n, nil
+}
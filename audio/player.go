@@ -46,15 +46,17 @@ type context interface {
 type playerFactory struct {
 	context    context
 	sampleRate int
+	bufferSize time.Duration
 
 	m sync.Mutex
 }
 
 var driverForTesting context
 
-func newPlayerFactory(sampleRate int) *playerFactory {
+func newPlayerFactory(sampleRate int, bufferSize time.Duration) *playerFactory {
 	f := &playerFactory{
 		sampleRate: sampleRate,
+		bufferSize: bufferSize,
 	}
 	if driverForTesting != nil {
 		f.context = driverForTesting
@@ -70,7 +72,15 @@ type playerImpl struct {
 	stream         *timeStream
 	factory        *playerFactory
 	initBufferSize int
+	onEnd          func()
+	volume         float64
+	bus            *Bus
 	m              sync.Mutex
+
+	// fadeID identifies the current fade. A fade with an older ID is canceled.
+	fadeID     uint64
+	fading     bool
+	fadeVolume float64
 }
 
 func (f *playerFactory) newPlayer(context *Context, src io.Reader) (*playerImpl, error) {
@@ -81,6 +91,7 @@ func (f *playerFactory) newPlayer(context *Context, src io.Reader) (*playerImpl,
 		src:     src,
 		context: context,
 		factory: f,
+		volume:  1,
 	}
 	runtime.SetFinalizer(p, (*playerImpl).Close)
 	return p, nil
@@ -124,7 +135,7 @@ func (f *playerFactory) initContextIfNeeded() (<-chan struct{}, error) {
 		return nil, nil
 	}
 
-	c, ready, err := newContext(f.sampleRate)
+	c, ready, err := newContext(f.sampleRate, f.bufferSize)
 	if err != nil {
 		return nil, err
 	}
@@ -162,10 +173,27 @@ func (p *playerImpl) ensurePlayer() error {
 			p.player.SetBufferSize(p.initBufferSize)
 			p.initBufferSize = 0
 		}
+		p.applyVolume()
 	}
 	return nil
 }
 
+// applyVolume applies the player's volume multiplied by the bus's and the context's volumes to the underlying player.
+// applyVolume must be called with p.m locked.
+func (p *playerImpl) applyVolume() {
+	p.player.SetVolume(p.volume * p.context.gain(p.bus))
+}
+
+func (p *playerImpl) updateVolume() {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.player == nil {
+		return
+	}
+	p.applyVolume()
+}
+
 func (p *playerImpl) Play() {
 	p.m.Lock()
 	defer p.m.Unlock()
@@ -177,6 +205,8 @@ func (p *playerImpl) Play() {
 	if p.player.IsPlaying() {
 		return
 	}
+	// The master or the bus volume might be changed while the player is paused.
+	p.applyVolume()
 	p.player.Play()
 	p.context.addPlayer(p)
 }
@@ -209,23 +239,100 @@ func (p *playerImpl) IsPlaying() bool {
 func (p *playerImpl) Volume() float64 {
 	p.m.Lock()
 	defer p.m.Unlock()
+	return p.volume
+}
+
+func (p *playerImpl) SetVolume(volume float64) {
+	checkVolume(volume)
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	// Setting the volume explicitly cancels the current fade.
+	p.fadeID++
+	p.fading = false
 
+	p.volume = volume
 	if err := p.ensurePlayer(); err != nil {
 		p.context.setError(err)
-		return 0
+		return
 	}
-	return p.player.Volume()
+	p.applyVolume()
 }
 
-func (p *playerImpl) SetVolume(volume float64) {
+// startFade starts a new fade and cancels the current fade if exists.
+// startFade returns the fade ID and the player's volume before the fade.
+func (p *playerImpl) startFade() (uint64, float64) {
 	p.m.Lock()
 	defer p.m.Unlock()
 
-	if err := p.ensurePlayer(); err != nil {
-		p.context.setError(err)
+	if !p.fading {
+		p.fadeVolume = p.volume
+	}
+	p.fading = true
+	p.fadeID++
+	return p.fadeID, p.fadeVolume
+}
+
+// setFadeVolume sets the volume if the fade is not canceled.
+// setFadeVolume returns false if the fade is canceled.
+func (p *playerImpl) setFadeVolume(id uint64, volume float64) bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.fadeID != id {
+		return false
+	}
+	p.volume = volume
+	if p.player != nil {
+		p.applyVolume()
+	}
+	return true
+}
+
+// endFade ends the fade if the fade is not canceled.
+// If pause is true, endFade pauses the player and restores the volume before the fade.
+func (p *playerImpl) endFade(id uint64, pause bool) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.fadeID != id {
+		return
+	}
+	p.fading = false
+	if !pause {
 		return
 	}
-	p.player.SetVolume(volume)
+	p.volume = p.fadeVolume
+	if p.player == nil {
+		return
+	}
+	if p.player.IsPlaying() {
+		p.player.Pause()
+		p.context.removePlayer(p)
+	}
+	p.applyVolume()
+}
+
+func (p *playerImpl) Bus() *Bus {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.bus
+}
+
+func (p *playerImpl) SetBus(bus *Bus) {
+	if bus != nil && bus.context != p.context {
+		panic("audio: the bus belongs to a different context")
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.bus = bus
+	if p.player == nil {
+		return
+	}
+	p.applyVolume()
 }
 
 func (p *playerImpl) Close() error {
@@ -255,6 +362,17 @@ func (p *playerImpl) Current() time.Duration {
 	return time.Duration(samples) * time.Second / time.Duration(p.factory.sampleRate)
 }
 
+func (p *playerImpl) UnplayedBufferSize() time.Duration {
+	p.m.Lock()
+	defer p.m.Unlock()
+	if p.player == nil {
+		return 0
+	}
+
+	samples := int64(p.player.UnplayedBufferSize()) / bytesPerSample
+	return time.Duration(samples) * time.Second / time.Duration(p.factory.sampleRate)
+}
+
 func (p *playerImpl) Rewind() error {
 	return p.Seek(0)
 }
@@ -297,6 +415,33 @@ func (p *playerImpl) SetBufferSize(bufferSize time.Duration) {
 	p.player.SetBufferSize(bufferSizeInBytes)
 }
 
+func (p *playerImpl) SetOnEnd(f func()) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.onEnd = f
+}
+
+// endCallback returns the callback to be called if the player stopped by reaching the end of the stream.
+// Otherwise, endCallback returns nil.
+func (p *playerImpl) endCallback() func() {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.onEnd == nil {
+		return nil
+	}
+	if p.player == nil || p.stream == nil {
+		return nil
+	}
+	if p.player.IsPlaying() {
+		return nil
+	}
+	if !p.stream.reachedEOF() {
+		return nil
+	}
+	return p.onEnd
+}
+
 func (p *playerImpl) source() io.Reader {
 	return p.src
 }
@@ -305,6 +450,7 @@ type timeStream struct {
 	r          io.Reader
 	sampleRate int
 	pos        int64
+	eof        bool
 
 	// m is a mutex for this stream.
 	// All the exported functions are protected by this mutex as Read can be read from a different goroutine than Seek.
@@ -333,6 +479,9 @@ func (s *timeStream) Read(buf []byte) (int, error) {
 
 	n, err := s.r.Read(buf)
 	s.pos += int64(n)
+	if err == io.EOF {
+		s.eof = true
+	}
 	return n, err
 }
 
@@ -351,6 +500,7 @@ func (s *timeStream) Seek(offset int64, whence int) (int64, error) {
 	}
 
 	s.pos = pos
+	s.eof = false
 	return pos, nil
 }
 
@@ -367,6 +517,13 @@ func (s *timeStream) timeDurationToPos(offset time.Duration) int64 {
 	return o
 }
 
+func (s *timeStream) reachedEOF() bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.eof
+}
+
 func (s *timeStream) Current() int64 {
 	s.m.Lock()
 	defer s.m.Unlock()
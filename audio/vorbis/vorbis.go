@@ -18,6 +18,8 @@ package vorbis
 import (
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/jfreymuth/oggvorbis"
 
@@ -29,6 +31,10 @@ import (
 type Stream struct {
 	decoded io.ReadSeeker
 	size    int64
+
+	loopStart  int64
+	loopLength int64
+	hasLoop    bool
 }
 
 // Read is implementation of io.Reader's Read.
@@ -50,6 +56,89 @@ func (s *Stream) Length() int64 {
 	return s.size
 }
 
+// LoopPoints returns the loop start and the loop length in bytes of the decoded stream.
+// The loop points are specified by LOOPSTART and LOOPLENGTH (or LOOPEND) comments in samples,
+// which are used by e.g. RPG Maker.
+// If LOOPSTART is specified but neither LOOPLENGTH nor LOOPEND is, the loop continues to the end of the stream.
+//
+// If the loop points are not specified, ok is false.
+func (s *Stream) LoopPoints() (start, length int64, ok bool) {
+	return s.loopStart, s.loopLength, s.hasLoop
+}
+
+// NewInfiniteLoop creates an infinite loop stream from the stream with the loop points specified by the comments.
+// If the loop points are not specified, the whole stream is looped.
+//
+// See also (*Stream).LoopPoints.
+func NewInfiniteLoop(s *Stream) *audio.InfiniteLoop {
+	if start, length, ok := s.LoopPoints(); ok {
+		return audio.NewInfiniteLoopWithIntro(s, start, length)
+	}
+	return audio.NewInfiniteLoop(s, s.Length())
+}
+
+// parseLoopPoints parses the loop points in samples from the comments.
+// A negative length means that the loop continues to the end.
+func parseLoopPoints(comments []string) (start, length int64, ok bool) {
+	var hasStart, hasLength, hasEnd bool
+	var end int64
+	for _, c := range comments {
+		k, v, found := strings.Cut(c, "=")
+		if !found {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil || n < 0 {
+			continue
+		}
+		switch strings.ToUpper(k) {
+		case "LOOPSTART":
+			start = n
+			hasStart = true
+		case "LOOPLENGTH":
+			length = n
+			hasLength = true
+		case "LOOPEND":
+			end = n
+			hasEnd = true
+		}
+	}
+	if !hasStart {
+		return 0, 0, false
+	}
+	if hasLength {
+		return start, length, true
+	}
+	if hasEnd && end > start {
+		return start, end - start, true
+	}
+	return start, -1, true
+}
+
+// setLoopPoints sets the loop points in bytes from the comments.
+func (s *Stream) setLoopPoints(comments []string, origSampleRate, sampleRate int) {
+	start, length, ok := parseLoopPoints(comments)
+	if !ok {
+		return
+	}
+	const bytesPerSample = 4
+	start = start * int64(sampleRate) / int64(origSampleRate) * bytesPerSample
+	if start > s.size {
+		return
+	}
+	if length < 0 {
+		length = s.size - start
+	} else {
+		length = length * int64(sampleRate) / int64(origSampleRate) * bytesPerSample
+	}
+	if length <= 0 {
+		return
+	}
+	s.loopStart = start
+	s.loopLength = length
+	s.hasLoop = true
+}
+
 type decoder interface {
 	Read([]float32) (int, error)
 	SetPosition(int64) error
@@ -63,6 +152,7 @@ type decoded struct {
 	posInBytes int
 	decoder    decoder
 	decoderr   io.Reader
+	comments   []string
 }
 
 func (d *decoded) Read(b []byte) (int, error) {
@@ -131,6 +221,7 @@ func decode(in io.Reader) (*decoded, int, int, error) {
 		totalBytes: int(r.Length()) * r.Channels() * 2, // 2 means 16bit per sample.
 		posInBytes: 0,
 		decoder:    r,
+		comments:   r.CommentHeader().Comments,
 	}
 	if _, ok := in.(io.Seeker); ok {
 		if _, err := d.Read(make([]byte, 65536)); err != nil && err != io.EOF {
@@ -152,7 +243,7 @@ func decode(in io.Reader) (*decoded, int, int, error) {
 // A Stream doesn't close src even if src implements io.Closer.
 // Closing the source is src owner's responsibility.
 func DecodeWithoutResampling(src io.Reader) (*Stream, error) {
-	decoded, channelCount, _, err := decode(src)
+	decoded, channelCount, origSampleRate, err := decode(src)
 	if err != nil {
 		return nil, err
 	}
@@ -169,6 +260,7 @@ func DecodeWithoutResampling(src io.Reader) (*Stream, error) {
 		decoded: s,
 		size:    size,
 	}
+	stream.setLoopPoints(decoded.comments, origSampleRate, origSampleRate)
 	return stream, nil
 }
 
@@ -202,6 +294,7 @@ func DecodeWithSampleRate(sampleRate int, src io.Reader) (*Stream, error) {
 		size = r.Length()
 	}
 	stream := &Stream{decoded: s, size: size}
+	stream.setLoopPoints(decoded.comments, origSampleRate, sampleRate)
 	return stream, nil
 }
 
@@ -32,6 +32,10 @@ var (
 
 	//go:embed test_tooshort.ogg
 	test_tooshort_ogg []byte
+
+	// test_loop.ogg has the same audio data as test_mono.ogg with the comments LOOPSTART=4410 and LOOPLENGTH=8820.
+	//go:embed test_loop.ogg
+	test_loop_ogg []byte
 )
 
 var audioContext = audio.NewContext(44100)
@@ -99,3 +103,125 @@ func TestNonSeeker(t *testing.T) {
 		t.Errorf("s.Length(): got: %d, want: %d", got, want)
 	}
 }
+
+func TestParseLoopPoints(t *testing.T) {
+	cases := []struct {
+		Comments []string
+		Start    int64
+		Length   int64
+		OK       bool
+	}{
+		{
+			Comments: nil,
+			OK:       false,
+		},
+		{
+			Comments: []string{"TITLE=foo", "LOOPSTART=100", "LOOPLENGTH=200"},
+			Start:    100,
+			Length:   200,
+			OK:       true,
+		},
+		{
+			Comments: []string{"loopstart=100", "loopend=250"},
+			Start:    100,
+			Length:   150,
+			OK:       true,
+		},
+		{
+			Comments: []string{"LOOPSTART=100"},
+			Start:    100,
+			Length:   -1,
+			OK:       true,
+		},
+		{
+			Comments: []string{"LOOPSTART=abc", "LOOPLENGTH=200"},
+			OK:       false,
+		},
+	}
+	for _, c := range cases {
+		start, length, ok := vorbis.ParseLoopPointsForTesting(c.Comments)
+		if start != c.Start || length != c.Length || ok != c.OK {
+			t.Errorf("ParseLoopPointsForTesting(%q): got: (%d, %d, %t), want: (%d, %d, %t)", c.Comments, start, length, ok, c.Start, c.Length, c.OK)
+		}
+	}
+}
+
+func TestNoLoopPoints(t *testing.T) {
+	s, err := vorbis.DecodeWithSampleRate(audioContext.SampleRate(), bytes.NewReader(test_mono_ogg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := s.LoopPoints(); ok {
+		t.Errorf("LoopPoints must not be specified")
+	}
+}
+
+func TestLoopPoints(t *testing.T) {
+	// The loop points in the comments are in samples. LoopPoints returns them in bytes of the 16bit stereo stream.
+	for _, c := range []struct {
+		SampleRate int
+		Start      int64
+		Length     int64
+	}{
+		{
+			SampleRate: 44100,
+			Start:      4410 * 4,
+			Length:     8820 * 4,
+		},
+		{
+			SampleRate: 22050,
+			Start:      2205 * 4,
+			Length:     4410 * 4,
+		},
+	} {
+		s, err := vorbis.DecodeWithSampleRate(c.SampleRate, bytes.NewReader(test_loop_ogg))
+		if err != nil {
+			t.Fatal(err)
+		}
+		start, length, ok := s.LoopPoints()
+		if start != c.Start || length != c.Length || !ok {
+			t.Errorf("LoopPoints() with sample rate %d: got: (%d, %d, %t), want: (%d, %d, true)", c.SampleRate, start, length, ok, c.Start, c.Length)
+		}
+	}
+}
+
+func TestNewInfiniteLoop(t *testing.T) {
+	s, err := vorbis.DecodeWithSampleRate(audioContext.SampleRate(), bytes.NewReader(test_loop_ogg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := io.ReadAll(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		start = 4410 * 4
+		end   = start + 8820*4
+	)
+
+	l := vorbis.NewInfiniteLoop(s)
+
+	// The intro and the first loop are the same as the original stream.
+	got := make([]byte, end)
+	if _, err := io.ReadFull(l, got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want[:end]) {
+		t.Errorf("the intro and the first loop mismatch")
+	}
+
+	// The second loop starts at the loop start. The beginning of the loop is blended with the data after the loop
+	// end to make the joint smooth, so skip it.
+	const blendLength = 256 * 4
+	got = make([]byte, end-start)
+	if _, err := io.ReadFull(l, got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got[blendLength:], want[start+blendLength:end]) {
+		t.Errorf("the second loop mismatch")
+	}
+}
@@ -185,6 +185,25 @@ const (
 	//     c_out = (BlendFactorDestinationRGB) × c_dst - (BlendFactorSourceRGB) × c_src
 	//     α_out = (BlendFactorDestinationAlpha) × α_dst - (BlendFactorSourceAlpha) × α_src
 	BlendOperationReverseSubtract
+
+	// BlendOperationMin represents the minimum of the source and destination color.
+	// The blend factors are ignored.
+	//
+	//     c_out = min(c_src, c_dst)
+	//     α_out = min(α_src, α_dst)
+	//
+	// BlendOperationMin is not available with OpenGL ES 2.0 or WebGL 1 without the extension EXT_blend_minmax.
+	// In this case, drawing with BlendOperationMin causes an error in the game loop.
+	BlendOperationMin
+
+	// BlendOperationMax represents the maximum of the source and destination color.
+	// The blend factors are ignored.
+	//
+	//     c_out = max(c_src, c_dst)
+	//     α_out = max(α_src, α_dst)
+	//
+	// BlendOperationMax has the same restriction as BlendOperationMin.
+	BlendOperationMax
 )
 
 func (b BlendOperation) internalBlendOperation() graphicsdriver.BlendOperation {
@@ -195,6 +214,10 @@ func (b BlendOperation) internalBlendOperation() graphicsdriver.BlendOperation {
 		return graphicsdriver.BlendOperationSubtract
 	case BlendOperationReverseSubtract:
 		return graphicsdriver.BlendOperationReverseSubtract
+	case BlendOperationMin:
+		return graphicsdriver.BlendOperationMin
+	case BlendOperationMax:
+		return graphicsdriver.BlendOperationMax
 	default:
 		panic(fmt.Sprintf("ebiten: invalid blend operation: %d", b))
 	}
@@ -374,4 +397,31 @@ var (
 		BlendOperationRGB:           BlendOperationAdd,
 		BlendOperationAlpha:         BlendOperationAdd,
 	}
+
+	// BlendMultiply is a preset Blend for 'multiply'.
+	// This is the same as CSS's 'multiply' when the destination is opaque.
+	//
+	//     c_out = c_src × c_dst + c_dst × (1 - α_src)
+	//     α_out = α_src + α_dst × (1 - α_src)
+	BlendMultiply = Blend{
+		BlendFactorSourceRGB:        BlendFactorDestinationColor,
+		BlendFactorSourceAlpha:      BlendFactorOne,
+		BlendFactorDestinationRGB:   BlendFactorOneMinusSourceAlpha,
+		BlendFactorDestinationAlpha: BlendFactorOneMinusSourceAlpha,
+		BlendOperationRGB:           BlendOperationAdd,
+		BlendOperationAlpha:         BlendOperationAdd,
+	}
+
+	// BlendScreen is a preset Blend for 'screen'.
+	//
+	//     c_out = c_src + c_dst × (1 - c_src)
+	//     α_out = α_src + α_dst × (1 - α_src)
+	BlendScreen = Blend{
+		BlendFactorSourceRGB:        BlendFactorOne,
+		BlendFactorSourceAlpha:      BlendFactorOne,
+		BlendFactorDestinationRGB:   BlendFactorOneMinusSourceColor,
+		BlendFactorDestinationAlpha: BlendFactorOneMinusSourceAlpha,
+		BlendOperationRGB:           BlendOperationAdd,
+		BlendOperationAlpha:         BlendOperationAdd,
+	}
 )
@@ -0,0 +1,92 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package camera provides a 2D camera to convert coordinates between a game world and the screen.
+package camera
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Camera represents a 2D camera.
+//
+// The world point (X, Y) is shown at the center of Viewport.
+type Camera struct {
+	// X and Y are the position of the camera in the world coordinate.
+	X float64
+	Y float64
+
+	// Zoom is the scale of the camera. A value larger than 1 zooms in.
+	// If Zoom is 0, 1 is used.
+	Zoom float64
+
+	// Rotation is the rotation of the camera in radians.
+	// A positive value rotates the camera clockwise, i.e. the world is rendered rotated counterclockwise.
+	Rotation float64
+
+	// Viewport is the region on the screen where the world is rendered.
+	// If Viewport is empty, the world point (X, Y) is shown at the origin (0, 0) of the screen.
+	Viewport image.Rectangle
+}
+
+// GeoM returns a geometry matrix that converts the world coordinate to the screen coordinate.
+func (c *Camera) GeoM() ebiten.GeoM {
+	var g ebiten.GeoM
+	g.Translate(-c.X, -c.Y)
+	g.Rotate(-c.Rotation)
+	z := c.Zoom
+	if z == 0 {
+		z = 1
+	}
+	g.Scale(z, z)
+	if !c.Viewport.Empty() {
+		g.Translate(float64(c.Viewport.Min.X)+float64(c.Viewport.Dx())/2, float64(c.Viewport.Min.Y)+float64(c.Viewport.Dy())/2)
+	}
+	return g
+}
+
+// WorldToScreen converts the world coordinate (x, y) to the screen coordinate.
+func (c *Camera) WorldToScreen(x, y float64) (float64, float64) {
+	g := c.GeoM()
+	return g.Apply(x, y)
+}
+
+// ScreenToWorld converts the screen coordinate (x, y) to the world coordinate.
+//
+// ScreenToWorld is useful to know which world position the cursor points to.
+func (c *Camera) ScreenToWorld(x, y float64) (float64, float64) {
+	g := c.GeoM()
+	g.Invert()
+	return g.Apply(x, y)
+}
+
+// DrawImage draws src on dst with the camera transformation.
+//
+// options.GeoM is treated as a transformation in the world coordinate, and the camera transformation is applied after it.
+// If Viewport is not empty, the rendering is clipped by Viewport.
+//
+// DrawImage doesn't modify options.
+func (c *Camera) DrawImage(dst, src *ebiten.Image, options *ebiten.DrawImageOptions) {
+	op := &ebiten.DrawImageOptions{}
+	if options != nil {
+		*op = *options
+	}
+	op.GeoM.Concat(c.GeoM())
+	if !c.Viewport.Empty() {
+		dst = dst.SubImage(c.Viewport).(*ebiten.Image)
+	}
+	dst.DrawImage(src, op)
+}
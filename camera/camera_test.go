@@ -0,0 +1,61 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package camera_test
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/camera"
+)
+
+func TestCameraWorldToScreen(t *testing.T) {
+	c := &camera.Camera{
+		X:        100,
+		Y:        50,
+		Zoom:     2,
+		Rotation: math.Pi / 2,
+		Viewport: image.Rect(10, 20, 330, 260),
+	}
+	cases := []struct {
+		WorldX  float64
+		WorldY  float64
+		ScreenX float64
+		ScreenY float64
+	}{
+		{WorldX: 100, WorldY: 50, ScreenX: 170, ScreenY: 140},
+		{WorldX: 110, WorldY: 50, ScreenX: 170, ScreenY: 120},
+		{WorldX: 100, WorldY: 60, ScreenX: 190, ScreenY: 140},
+	}
+	for _, tc := range cases {
+		sx, sy := c.WorldToScreen(tc.WorldX, tc.WorldY)
+		if math.Abs(sx-tc.ScreenX) > 1e-9 || math.Abs(sy-tc.ScreenY) > 1e-9 {
+			t.Errorf("WorldToScreen(%f, %f): got: (%f, %f), want: (%f, %f)", tc.WorldX, tc.WorldY, sx, sy, tc.ScreenX, tc.ScreenY)
+		}
+		wx, wy := c.ScreenToWorld(tc.ScreenX, tc.ScreenY)
+		if math.Abs(wx-tc.WorldX) > 1e-9 || math.Abs(wy-tc.WorldY) > 1e-9 {
+			t.Errorf("ScreenToWorld(%f, %f): got: (%f, %f), want: (%f, %f)", tc.ScreenX, tc.ScreenY, wx, wy, tc.WorldX, tc.WorldY)
+		}
+	}
+}
+
+func TestCameraZeroZoom(t *testing.T) {
+	c := &camera.Camera{X: 10, Y: 20}
+	x, y := c.WorldToScreen(15, 25)
+	if x != 5 || y != 5 {
+		t.Errorf("WorldToScreen(15, 25): got: (%f, %f), want: (5, 5)", x, y)
+	}
+}
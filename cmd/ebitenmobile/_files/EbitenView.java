@@ -19,23 +19,30 @@ import java.util.Collections;
 import java.util.Comparator;
 import java.util.List;
 
+import android.app.Activity;
 import android.content.Context;
+import android.content.pm.ActivityInfo;
 import android.hardware.input.InputManager;
+import android.os.Build;
 import android.os.Handler;
 import android.os.Looper;
 import android.util.AttributeSet;
 import android.util.DisplayMetrics;
 import android.util.Log;
 import android.view.Display;
+import android.view.DisplayCutout;
 import android.view.KeyEvent;
 import android.view.InputDevice;
 import android.view.MotionEvent;
 import android.view.ViewGroup;
+import android.view.WindowInsets;
 import android.view.WindowManager;
 
 import {{.JavaPkg}}.ebitenmobileview.Ebitenmobileview;
+import {{.JavaPkg}}.ebitenmobileview.ScreenKeepAwakeSetter;
+import {{.JavaPkg}}.ebitenmobileview.ScreenOrientationLocker;
 
-public class EbitenView extends ViewGroup implements InputManager.InputDeviceListener {
+public class EbitenView extends ViewGroup implements InputManager.InputDeviceListener, ScreenOrientationLocker, ScreenKeepAwakeSetter {
     static class Gamepad {
         public int deviceId;
         public ArrayList<InputDevice.MotionRange> axes;
@@ -88,6 +95,50 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
         for (int id : this.inputManager.getInputDeviceIds()) {
             this.onInputDeviceAdded(id);
         }
+
+        Ebitenmobileview.setScreenOrientationLocker(this);
+        Ebitenmobileview.setScreenKeepAwakeSetter(this);
+    }
+
+    @Override
+    public void setScreenKeepAwake(final boolean keepAwake) {
+        new Handler(Looper.getMainLooper()).post(new Runnable() {
+            @Override
+            public void run() {
+                // This is equivalent to WindowManager.LayoutParams.FLAG_KEEP_SCREEN_ON while this view is visible.
+                EbitenView.this.setKeepScreenOn(keepAwake);
+            }
+        });
+    }
+
+    @Override
+    public void lockScreenOrientation(long orientation) {
+        Context context = getContext();
+        if (!(context instanceof Activity)) {
+            return;
+        }
+        final Activity activity = (Activity)context;
+
+        // The values must be synced with ScreenOrientation in internal/ui.
+        final int requestedOrientation;
+        switch ((int)orientation) {
+        case 1:
+            requestedOrientation = ActivityInfo.SCREEN_ORIENTATION_SENSOR_PORTRAIT;
+            break;
+        case 2:
+            requestedOrientation = ActivityInfo.SCREEN_ORIENTATION_SENSOR_LANDSCAPE;
+            break;
+        default:
+            requestedOrientation = ActivityInfo.SCREEN_ORIENTATION_UNSPECIFIED;
+            break;
+        }
+
+        new Handler(Looper.getMainLooper()).post(new Runnable() {
+            @Override
+            public void run() {
+                activity.setRequestedOrientation(requestedOrientation);
+            }
+        });
     }
 
     @Override
@@ -96,6 +147,25 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
         double widthInDp = pxToDp(right - left);
         double heightInDp = pxToDp(bottom - top);
         Ebitenmobileview.layout(widthInDp, heightInDp);
+        updateSafeAreaInsets();
+    }
+
+    private void updateSafeAreaInsets() {
+        if (Build.VERSION.SDK_INT < Build.VERSION_CODES.P) {
+            return;
+        }
+        WindowInsets insets = getRootWindowInsets();
+        if (insets == null) {
+            return;
+        }
+        DisplayCutout cutout = insets.getDisplayCutout();
+        if (cutout == null) {
+            Ebitenmobileview.setSafeAreaInsets(0, 0, 0, 0);
+            return;
+        }
+        Ebitenmobileview.setSafeAreaInsets(
+            pxToDp(cutout.getSafeInsetTop()), pxToDp(cutout.getSafeInsetRight()),
+            pxToDp(cutout.getSafeInsetBottom()), pxToDp(cutout.getSafeInsetLeft()));
     }
 
     @Override
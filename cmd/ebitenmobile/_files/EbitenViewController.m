@@ -20,7 +20,7 @@
 
 #import "Ebitenmobileview.objc.h"
 
-@interface {{.PrefixUpper}}EbitenViewController : UIViewController<EbitenmobileviewRenderRequester>
+@interface {{.PrefixUpper}}EbitenViewController : UIViewController<EbitenmobileviewRenderRequester, EbitenmobileviewScreenKeepAwakeSetter>
 @end
 
 @implementation {{.PrefixUpper}}EbitenViewController {
@@ -116,6 +116,7 @@
   displayLink_ = [CADisplayLink displayLinkWithTarget:self selector:@selector(drawFrame)];
   [displayLink_ addToRunLoop:[NSRunLoop currentRunLoop] forMode:NSDefaultRunLoopMode];
   EbitenmobileviewSetRenderRequester(self);
+  EbitenmobileviewSetScreenKeepAwakeSetter(self);
 
   // Run the loop. This will never return.
   [[NSRunLoop currentRunLoop] run];
@@ -146,6 +147,17 @@
   CGRect viewRect = [[self view] frame];
 
   EbitenmobileviewLayout(viewRect.size.width, viewRect.size.height);
+
+  if (@available(iOS 11.0, *)) {
+    UIEdgeInsets insets = [[self view] safeAreaInsets];
+    EbitenmobileviewSetSafeAreaInsets(insets.top, insets.right, insets.bottom, insets.left);
+  }
+}
+
+- (void)viewSafeAreaInsetsDidChange {
+  [super viewSafeAreaInsetsDidChange];
+  UIEdgeInsets insets = [[self view] safeAreaInsets];
+  EbitenmobileviewSetSafeAreaInsets(insets.top, insets.right, insets.bottom, insets.left);
 }
 
 - (void)didReceiveMemoryWarning {
@@ -296,6 +308,12 @@
   }
 }
 
+- (void)setScreenKeepAwake:(BOOL)keepAwake {
+  dispatch_async(dispatch_get_main_queue(), ^{
+    [[UIApplication sharedApplication] setIdleTimerDisabled:keepAwake];
+  });
+}
+
 - (void)requestRenderIfNeeded {
   @synchronized(self) {
     if (explicitRendering_) {
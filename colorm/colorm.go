@@ -111,6 +111,36 @@ func (c *ColorM) ChangeHSV(hueTheta float64, saturationScale float64, valueScale
 	c.impl = affine.ChangeHSV(c.affineColorM(), hueTheta, float32(saturationScale), float32(valueScale))
 }
 
+// Grayscale changes the colors to grayscale.
+//
+// This is the same as ChangeHSV(0, 0, 1).
+func (c *ColorM) Grayscale() {
+	c.ChangeHSV(0, 0, 1)
+}
+
+// Sepia changes the colors to sepia tones.
+func (c *ColorM) Sepia() {
+	var m ColorM
+	m.SetElement(0, 0, 0.393)
+	m.SetElement(0, 1, 0.769)
+	m.SetElement(0, 2, 0.189)
+	m.SetElement(1, 0, 0.349)
+	m.SetElement(1, 1, 0.686)
+	m.SetElement(1, 2, 0.168)
+	m.SetElement(2, 0, 0.272)
+	m.SetElement(2, 1, 0.534)
+	m.SetElement(2, 2, 0.131)
+	c.Concat(m)
+}
+
+// InvertRGB inverts the RGB values, i.e., (r, g, b, a) becomes (1-r, 1-g, 1-b, a).
+//
+// Note that InvertRGB is different from Invert, which calculates the inverse matrix.
+func (c *ColorM) InvertRGB() {
+	c.Scale(-1, -1, -1, 1)
+	c.Translate(1, 1, 1, 0)
+}
+
 // Element returns a value of a matrix at (i, j).
 func (c *ColorM) Element(i, j int) float64 {
 	return float64(c.affineColorM().At(i, j))
@@ -145,6 +145,46 @@ func TestColorMMonochrome(t *testing.T) {
 	}
 }
 
+func TestColorMGrayscale(t *testing.T) {
+	expected := [4][5]float64{
+		{0.2990, 0.5870, 0.1140, 0, 0},
+		{0.2990, 0.5870, 0.1140, 0, 0},
+		{0.2990, 0.5870, 0.1140, 0, 0},
+		{0, 0, 0, 1, 0},
+	}
+	m := colorm.ColorM{}
+	m.Grayscale()
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 5; j++ {
+			got := m.Element(i, j)
+			want := expected[i][j]
+			if math.Abs(want-got) > 0.0001 {
+				t.Errorf("m.Element(%d, %d) = %f, want %f", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestColorMSepia(t *testing.T) {
+	expected := [4][5]float64{
+		{0.393, 0.769, 0.189, 0, 0},
+		{0.349, 0.686, 0.168, 0, 0},
+		{0.272, 0.534, 0.131, 0, 0},
+		{0, 0, 0, 1, 0},
+	}
+	m := colorm.ColorM{}
+	m.Sepia()
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 5; j++ {
+			got := m.Element(i, j)
+			want := expected[i][j]
+			if math.Abs(want-got) > 0.0001 {
+				t.Errorf("m.Element(%d, %d) = %f, want %f", i, j, got, want)
+			}
+		}
+	}
+}
+
 func TestColorMConcatSelf(t *testing.T) {
 	expected := [4][5]float64{
 		{30, 40, 30, 25, 30},
@@ -187,6 +227,9 @@ func TestColorMApply(t *testing.T) {
 	shift := colorm.ColorM{}
 	shift.Translate(0.5, 0.5, 0.5, 0.5)
 
+	invert := colorm.ColorM{}
+	invert.InvertRGB()
+
 	cases := []struct {
 		ColorM colorm.ColorM
 		In     color.Color
@@ -223,6 +266,12 @@ func TestColorMApply(t *testing.T) {
 			Out:    color.RGBA{R: 0x40, G: 0x40, B: 0x40, A: 0x80},
 			Delta:  0x101,
 		},
+		{
+			ColorM: invert,
+			In:     color.RGBA{R: 0xff, G: 0x80, A: 0xff},
+			Out:    color.RGBA{R: 0, G: 0x7f, B: 0xff, A: 0xff},
+			Delta:  0x101,
+		},
 	}
 	for _, c := range cases {
 		out := c.ColorM.Apply(c.In)
@@ -88,8 +88,22 @@
 // `EBITENGINE_OPENGL` environment variable specifies various parameters for OpenGL.
 // You can specify multiple values separated by a comma. The default value is empty (i.e. no parameters).
 //
-//	"es":     Use OpenGL ES. Without this, OpenGL and OpenGL ES are automatically chosen.
-//	"webgl1": Use WebGL 1. This is valid only on browsers.
+//	"es":                    Use OpenGL ES. Without this, OpenGL and OpenGL ES are automatically chosen.
+//	"webgl1":                Use WebGL 1. This is valid only on browsers.
+//	"antialias":             Set the WebGL context attribute antialias to true. This is valid only on browsers.
+//	"preservedrawingbuffer": Set the WebGL context attribute preserveDrawingBuffer to true. This is valid only on browsers.
+//	"desynchronized":        Set the WebGL context attribute desynchronized to true. This is valid only on browsers.
+//	"lowpower":              Set the WebGL context attribute powerPreference to "low-power". This is valid only on browsers.
+//	"highperformance":       Set the WebGL context attribute powerPreference to "high-performance". This is valid only on browsers.
+//
+// On browsers, the WebGL context attributes alpha and premultipliedAlpha are always true,
+// as Ebitengine's rendering results are premultiplied-alpha. Use RunGameOptions.ScreenTransparent to make the screen transparent.
+//
+// # Browsers
+//
+// On browsers, the canvas is appended to the body element and fills the page by default.
+// If an element with the `data-ebitengine-container` attribute exists when the program starts, the canvas is appended to the element
+// instead, the canvas fills the element, and the page's styles are not modified. This is useful to embed a game in a web page.
 //
 // # Build tags
 //
@@ -101,6 +115,11 @@
 // They must be called from the main thread or the same goroutine as the given game's callback functions like Update
 // to RunGame. `ebitenginesinglethread` works only with desktops.
 //
+// `ebitengineheadless` runs games without any window, GPU, input device or audio device. This is useful to run
+// Update and Draw in environments like CI containers. Update and Draw are called as usual, but nothing is actually
+// rendered and audio players consume their sources without outputting anything. The screen size is always 640x480.
+// `ebitengineheadless` works only with desktops, and doesn't require Cgo on Linux.
+//
 // `microsoftgdk` is for Microsoft GDK (e.g. Xbox).
 //
 // `nintendosdk` is for NintendoSDK (e.g. Nintendo Switch).
@@ -0,0 +1,153 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// AnimationMode represents how an animation is played.
+type AnimationMode int
+
+const (
+	// AnimationModeLoop plays the frames repeatedly.
+	AnimationModeLoop AnimationMode = iota
+
+	// AnimationModePingPong plays the frames forward and then backward repeatedly.
+	AnimationModePingPong
+
+	// AnimationModeOnce plays the frames once and then keeps showing the last frame.
+	AnimationModeOnce
+)
+
+// Animation represents a sprite animation made from a sprite sheet.
+type Animation struct {
+	frames    []*ebiten.Image
+	durations []int
+	mode      AnimationMode
+	total     int
+}
+
+// NewAnimation creates a new animation from a sprite sheet.
+//
+// The frames are taken from sheet from left to right and then top to bottom, with the size (frameWidth, frameHeight).
+// durations specifies the duration of each frame in ticks, and the number of frames is len(durations).
+//
+// NewAnimation panics if sheet doesn't have enough frames, or a duration is not positive.
+func NewAnimation(sheet *ebiten.Image, frameWidth, frameHeight int, durations []int, mode AnimationMode) *Animation {
+	if frameWidth <= 0 || frameHeight <= 0 {
+		panic("ebitenutil: frameWidth and frameHeight must be positive")
+	}
+	if len(durations) == 0 {
+		panic("ebitenutil: durations must not be empty")
+	}
+
+	b := sheet.Bounds()
+	cols := b.Dx() / frameWidth
+	rows := b.Dy() / frameHeight
+	if cols*rows < len(durations) {
+		panic("ebitenutil: the sprite sheet doesn't have enough frames")
+	}
+
+	a := &Animation{
+		durations: make([]int, len(durations)),
+		mode:      mode,
+	}
+	copy(a.durations, durations)
+	for i, d := range durations {
+		if d <= 0 {
+			panic("ebitenutil: durations must be positive")
+		}
+		a.total += d
+
+		x := b.Min.X + (i%cols)*frameWidth
+		y := b.Min.Y + (i/cols)*frameHeight
+		a.frames = append(a.frames, sheet.SubImage(image.Rect(x, y, x+frameWidth, y+frameHeight)).(*ebiten.Image))
+	}
+	return a
+}
+
+// FrameCount returns the number of the frames.
+func (a *Animation) FrameCount() int {
+	return len(a.frames)
+}
+
+// Duration returns the total duration of the frames in ticks.
+func (a *Animation) Duration() int {
+	return a.total
+}
+
+// IsFinished reports whether the animation is finished at the given tick.
+//
+// IsFinished always returns false unless the mode is AnimationModeOnce.
+func (a *Animation) IsFinished(tick int) bool {
+	return a.mode == AnimationModeOnce && tick >= a.total
+}
+
+// FrameIndex returns the index of the frame at the given tick.
+//
+// tick is the number of ticks since the animation started, e.g., a counter incremented in every Update.
+func (a *Animation) FrameIndex(tick int) int {
+	if tick < 0 {
+		tick = 0
+	}
+
+	switch a.mode {
+	case AnimationModeLoop:
+		return a.forwardIndex(tick % a.total)
+	case AnimationModePingPong:
+		// The first and the last frames are not repeated at the turning points.
+		back := 0
+		for i := 1; i < len(a.durations)-1; i++ {
+			back += a.durations[i]
+		}
+		t := tick % (a.total + back)
+		if t < a.total {
+			return a.forwardIndex(t)
+		}
+		t -= a.total
+		for i := len(a.durations) - 2; i > 0; i-- {
+			if t < a.durations[i] {
+				return i
+			}
+			t -= a.durations[i]
+		}
+		return 0
+	case AnimationModeOnce:
+		if tick >= a.total {
+			return len(a.frames) - 1
+		}
+		return a.forwardIndex(tick)
+	default:
+		panic("ebitenutil: invalid AnimationMode")
+	}
+}
+
+func (a *Animation) forwardIndex(t int) int {
+	for i, d := range a.durations {
+		if t < d {
+			return i
+		}
+		t -= d
+	}
+	return len(a.durations) - 1
+}
+
+// Frame returns the frame image at the given tick.
+func (a *Animation) Frame(tick int) *ebiten.Image {
+	return a.frames[a.FrameIndex(tick)]
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func TestAnimationFrameIndex(t *testing.T) {
+	sheet := ebiten.NewImage(64, 32)
+	durations := []int{2, 1, 3}
+
+	cases := []struct {
+		Mode ebitenutil.AnimationMode
+		Want []int
+	}{
+		{
+			Mode: ebitenutil.AnimationModeLoop,
+			Want: []int{0, 0, 1, 2, 2, 2, 0, 0, 1, 2},
+		},
+		{
+			Mode: ebitenutil.AnimationModePingPong,
+			Want: []int{0, 0, 1, 2, 2, 2, 1, 0, 0, 1},
+		},
+		{
+			Mode: ebitenutil.AnimationModeOnce,
+			Want: []int{0, 0, 1, 2, 2, 2, 2, 2, 2, 2},
+		},
+	}
+	for _, c := range cases {
+		a := ebitenutil.NewAnimation(sheet, 16, 16, durations, c.Mode)
+		for tick, want := range c.Want {
+			if got := a.FrameIndex(tick); got != want {
+				t.Errorf("mode: %d, a.FrameIndex(%d): got: %d, want: %d", c.Mode, tick, got, want)
+			}
+		}
+	}
+}
+
+func TestAnimationFrame(t *testing.T) {
+	sheet := ebiten.NewImage(48, 32)
+	a := ebitenutil.NewAnimation(sheet, 16, 16, []int{1, 1, 1, 1}, ebitenutil.AnimationModeLoop)
+	if got, want := a.Frame(3).Bounds(), image.Rect(0, 16, 16, 32); got != want {
+		t.Errorf("a.Frame(3).Bounds(): got: %v, want: %v", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"fmt"
+	"image/color"
+	"runtime"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	debugOverlayFrameTimeCount   = 120
+	debugOverlayGraphHeight      = 50
+	debugOverlayMemStatsInterval = time.Second
+)
+
+type debugOverlay struct {
+	frameTimes     [debugOverlayFrameTimeCount]time.Duration
+	frameTimeIndex int
+	lastFrame      time.Time
+
+	memStats         runtime.MemStats
+	lastMemStatsRead time.Time
+}
+
+var theDebugOverlay debugOverlay
+
+// DrawDebugOverlay draws diagnostics on the image on the left top corner.
+//
+// The diagnostics include FPS, TPS, the frame time and its graph, the GPU time, the number of draw calls, the number and the size of images
+// in the graphics library, and the heap size and the GC statistics.
+// The GPU time is 0 when it is not available. See also ebiten.DebugInfo.
+//
+// DrawDebugOverlay is expected to be called at the end of Draw every frame. The frame time is measured between the calls.
+// In order to toggle the overlay, call DrawDebugOverlay only when the overlay is enabled.
+//
+// DrawDebugOverlay is intended to be used mainly for debugging or profiling purpose.
+func DrawDebugOverlay(image *ebiten.Image) {
+	theDebugOverlay.draw(image)
+}
+
+func (d *debugOverlay) draw(image *ebiten.Image) {
+	now := time.Now()
+	if !d.lastFrame.IsZero() {
+		d.frameTimes[d.frameTimeIndex] = now.Sub(d.lastFrame)
+		d.frameTimeIndex = (d.frameTimeIndex + 1) % len(d.frameTimes)
+	}
+	d.lastFrame = now
+
+	// runtime.ReadMemStats stops the world. Avoid calling this every frame.
+	if d.lastMemStatsRead.IsZero() || now.Sub(d.lastMemStatsRead) >= debugOverlayMemStatsInterval {
+		runtime.ReadMemStats(&d.memStats)
+		d.lastMemStatsRead = now
+	}
+
+	var info ebiten.DebugInfo
+	ebiten.ReadDebugInfo(&info)
+
+	const mib = 1024 * 1024
+	lastFrameTime := d.frameTimes[(d.frameTimeIndex+len(d.frameTimes)-1)%len(d.frameTimes)]
+	var lastGCPause time.Duration
+	if d.memStats.NumGC > 0 {
+		lastGCPause = time.Duration(d.memStats.PauseNs[(d.memStats.NumGC+255)%256])
+	}
+	str := fmt.Sprintf(`FPS: %0.2f
+TPS: %0.2f
+Frame time: %0.2f ms (GPU: %0.2f ms)
+Draw calls: %d
+Images: %d (%0.2f MiB)
+Heap: %0.2f MiB
+GC: %d (last pause: %0.3f ms)`,
+		ebiten.ActualFPS(),
+		ebiten.ActualTPS(),
+		float64(lastFrameTime)/float64(time.Millisecond),
+		float64(info.GPUFrameTime)/float64(time.Millisecond),
+		info.DrawCallCount,
+		info.ImageCount,
+		float64(info.ImageMemoryInBytes)/mib,
+		float64(d.memStats.HeapAlloc)/mib,
+		d.memStats.NumGC,
+		float64(lastGCPause)/float64(time.Millisecond))
+
+	const (
+		lineCount  = 7
+		lineHeight = 16
+		graphY     = lineCount*lineHeight + 4
+	)
+
+	// Draw the frame time graph. A bar's height represents the frame time in milliseconds.
+	vector.DrawFilledRect(image, 0, graphY, debugOverlayFrameTimeCount, debugOverlayGraphHeight, color.RGBA{0, 0, 0, 0x80})
+	for i := 0; i < len(d.frameTimes); i++ {
+		t := d.frameTimes[(d.frameTimeIndex+i)%len(d.frameTimes)]
+		h := float32(t) / float32(time.Millisecond)
+		if h > debugOverlayGraphHeight {
+			h = debugOverlayGraphHeight
+		}
+		clr := color.RGBA{0, 0xff, 0, 0xff}
+		if t > time.Second/30 {
+			clr = color.RGBA{0xff, 0, 0, 0xff}
+		}
+		vector.DrawFilledRect(image, float32(i), graphY+debugOverlayGraphHeight-h, 1, h, clr)
+	}
+	// Draw a line at 1/60 second.
+	vector.DrawFilledRect(image, 0, graphY+debugOverlayGraphHeight-float32(time.Second/60)/float32(time.Millisecond), debugOverlayFrameTimeCount, 1, color.RGBA{0xff, 0xff, 0, 0xff})
+
+	DebugPrint(image, str)
+}
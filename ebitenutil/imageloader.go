@@ -0,0 +1,76 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"io/fs"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ImageLoader loads images from a file system and caches them.
+//
+// The file system can be any fs.FS, e.g., embed.FS, os.DirFS or a file system returned by NewURLFS,
+// so the same loading code works on any environments.
+//
+// Image decoders must be imported when using ImageLoader. For example,
+// if you want to load a PNG image, you'd need to add `_ "image/png"` to the import section.
+//
+// To load audio files, open the file with fs.FS's Open and pass it to a decoder like mp3.DecodeWithSampleRate.
+// Files returned by embed.FS and NewURLFS implement io.Seeker.
+type ImageLoader struct {
+	fsys   fs.FS
+	images map[string]*ebiten.Image
+	m      sync.Mutex
+}
+
+// NewImageLoader creates a new ImageLoader with the given file system.
+func NewImageLoader(fsys fs.FS) *ImageLoader {
+	return &ImageLoader{
+		fsys:   fsys,
+		images: map[string]*ebiten.Image{},
+	}
+}
+
+// Load loads the image at path.
+//
+// If the image at path was already loaded, Load returns the cached image.
+// Failures are not cached.
+//
+// Load is concurrent-safe.
+func (l *ImageLoader) Load(path string) (*ebiten.Image, error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if img, ok := l.images[path]; ok {
+		return img, nil
+	}
+	img, _, err := NewImageFromFileSystem(l.fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	l.images[path] = img
+	return img, nil
+}
+
+// Forget removes the cached image at path.
+//
+// Forget doesn't dispose the image.
+func (l *ImageLoader) Forget(path string) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	delete(l.images, path)
+}
@@ -0,0 +1,41 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"image"
+	_ "image/png"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func TestImageLoader(t *testing.T) {
+	l := ebitenutil.NewImageLoader(images)
+	img0, err := l.Load("text.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := img0.Bounds().Size(), image.Pt(192, 128); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	img1, err := l.Load("text.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img0 != img1 {
+		t.Errorf("Load must return the cached image")
+	}
+}
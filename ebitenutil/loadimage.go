@@ -15,9 +15,11 @@
 package ebitenutil
 
 import (
+	"fmt"
 	"image"
 	"io"
 	"net/http"
+	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -56,3 +58,93 @@ func NewImageFromURL(url string) (*ebiten.Image, error) {
 	eimg := ebiten.NewImageFromImage(img)
 	return eimg, nil
 }
+
+// ImageRequest represents an asynchronous image loading started by NewImageFromURLAsync.
+type ImageRequest struct {
+	loaded int64
+	total  int64
+
+	img  *ebiten.Image
+	err  error
+	done chan struct{}
+}
+
+// NewImageFromURLAsync starts loading an image from the given URL in another goroutine and returns a request
+// to observe the progress.
+//
+// NewImageFromURLAsync is useful to show a loading bar while assets are downloaded, especially on browsers.
+//
+// Image decoders must be imported when using NewImageFromURLAsync. For example,
+// if you want to load a PNG image, you'd need to add `_ "image/png"` to the import section.
+func NewImageFromURLAsync(url string) *ImageRequest {
+	r := &ImageRequest{
+		total: -1,
+		done:  make(chan struct{}),
+	}
+	go func() {
+		defer close(r.done)
+		r.img, r.err = r.load(url)
+	}()
+	return r
+}
+
+func (r *ImageRequest) load(url string) (*ebiten.Image, error) {
+	res, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("ebitenutil: unexpected HTTP status: %s", res.Status)
+	}
+	atomic.StoreInt64(&r.total, res.ContentLength)
+
+	img, _, err := image.Decode(&progressReader{r: res.Body, loaded: &r.loaded})
+	if err != nil {
+		return nil, err
+	}
+	return ebiten.NewImageFromImage(img), nil
+}
+
+// Progress returns the number of loaded bytes and the total number of bytes.
+//
+// total is -1 when the total size is not known yet or not provided by the server.
+//
+// Progress is concurrent-safe.
+func (r *ImageRequest) Progress() (loaded, total int64) {
+	return atomic.LoadInt64(&r.loaded), atomic.LoadInt64(&r.total)
+}
+
+// IsDone reports whether the loading finished, successfully or not.
+//
+// IsDone is concurrent-safe.
+func (r *ImageRequest) IsDone() bool {
+	select {
+	case <-r.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Result waits for the loading to finish and returns the loaded image or an error.
+//
+// To avoid blocking Update, call Result after IsDone returns true.
+func (r *ImageRequest) Result() (*ebiten.Image, error) {
+	<-r.done
+	return r.img, r.err
+}
+
+type progressReader struct {
+	r      io.Reader
+	loaded *int64
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	atomic.AddInt64(p.loaded, int64(n))
+	return n, err
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"image"
+	_ "image/png"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func TestNewImageFromURLAsync(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.FS(images)))
+	defer server.Close()
+
+	r := ebitenutil.NewImageFromURLAsync(server.URL + "/text.png")
+	img, err := r.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.IsDone() {
+		t.Errorf("r.IsDone() must be true after Result returns")
+	}
+	if got, want := img.Bounds().Size(), image.Pt(192, 128); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	stat, err := fs.Stat(images, "text.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, total := r.Progress()
+	if loaded != stat.Size() || total != stat.Size() {
+		t.Errorf("r.Progress(): got: (%d, %d), want: (%d, %d)", loaded, total, stat.Size(), stat.Size())
+	}
+
+	r = ebitenutil.NewImageFromURLAsync(server.URL + "/missing.png")
+	if _, err := r.Result(); err == nil {
+		t.Errorf("Result must return an error for a missing file")
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// maxConcurrentPrefetches is the maximum number of concurrent HTTP requests by a Prefetcher.
+const maxConcurrentPrefetches = 4
+
+type prefetchFile struct {
+	loaded int64
+	total  int64
+
+	data    []byte
+	fetched bool
+}
+
+// Prefetcher fetches files from a web server into memory in background.
+//
+// Prefetcher is useful to show a loading screen with the progress before the files are used, especially on browsers.
+// Check the progress in Update and Draw, and use FS after IsDone returns true.
+type Prefetcher struct {
+	files map[string]*prefetchFile
+	paths []string
+
+	err  error
+	errM sync.Mutex
+
+	done chan struct{}
+}
+
+// NewPrefetcher starts fetching the files at paths relative to baseURL.
+//
+// The paths are slash-separated and must be valid as fs.ValidPath reports.
+func NewPrefetcher(baseURL string, paths []string) *Prefetcher {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	p := &Prefetcher{
+		files: map[string]*prefetchFile{},
+		done:  make(chan struct{}),
+	}
+	for _, path := range paths {
+		if _, ok := p.files[path]; ok {
+			continue
+		}
+		p.files[path] = &prefetchFile{
+			total: -1,
+		}
+		p.paths = append(p.paths, path)
+	}
+
+	go func() {
+		defer close(p.done)
+
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, maxConcurrentPrefetches)
+		for _, path := range p.paths {
+			path := path
+			f := p.files[path]
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				if err := f.fetch(baseURL, path); err != nil {
+					p.setError(err)
+				}
+			}()
+		}
+		wg.Wait()
+	}()
+
+	return p
+}
+
+func (f *prefetchFile) fetch(baseURL string, path string) error {
+	if !fs.ValidPath(path) {
+		return &fs.PathError{Op: "fetch", Path: path, Err: fs.ErrInvalid}
+	}
+
+	res, err := http.Get(baseURL + "/" + path)
+	if err != nil {
+		return &fs.PathError{Op: "fetch", Path: path, Err: err}
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return &fs.PathError{Op: "fetch", Path: path, Err: fmt.Errorf("ebitenutil: unexpected HTTP status: %s", res.Status)}
+	}
+	atomic.StoreInt64(&f.total, res.ContentLength)
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, &progressReader{r: res.Body, loaded: &f.loaded}); err != nil {
+		return &fs.PathError{Op: "fetch", Path: path, Err: err}
+	}
+	f.data = buf.Bytes()
+	f.fetched = true
+	// Fix the total size when the server doesn't provide it.
+	atomic.StoreInt64(&f.total, int64(len(f.data)))
+	return nil
+}
+
+func (p *Prefetcher) setError(err error) {
+	p.errM.Lock()
+	defer p.errM.Unlock()
+	if p.err == nil {
+		p.err = err
+	}
+}
+
+// IsDone reports whether fetching all the files finished, successfully or not.
+//
+// IsDone is concurrent-safe.
+func (p *Prefetcher) IsDone() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Err returns the first error that happened while fetching, if any.
+//
+// Err is concurrent-safe.
+func (p *Prefetcher) Err() error {
+	p.errM.Lock()
+	defer p.errM.Unlock()
+	return p.err
+}
+
+// Progress returns the total number of loaded bytes and the total number of bytes of all the files.
+//
+// total is -1 when the size of any file is not known yet.
+//
+// Progress is concurrent-safe.
+func (p *Prefetcher) Progress() (loaded, total int64) {
+	for _, f := range p.files {
+		loaded += atomic.LoadInt64(&f.loaded)
+		t := atomic.LoadInt64(&f.total)
+		if t < 0 || total < 0 {
+			total = -1
+			continue
+		}
+		total += t
+	}
+	return
+}
+
+// FileProgress returns the number of loaded bytes and the total number of bytes of the file at path.
+//
+// total is -1 when the size of the file is not known yet. If path is not requested, FileProgress returns (0, -1).
+//
+// FileProgress is concurrent-safe.
+func (p *Prefetcher) FileProgress(path string) (loaded, total int64) {
+	f, ok := p.files[path]
+	if !ok {
+		return 0, -1
+	}
+	return atomic.LoadInt64(&f.loaded), atomic.LoadInt64(&f.total)
+}
+
+// FS waits for fetching to finish and returns a file system to read the fetched files.
+//
+// The returned file system has only the files fetched successfully. Directories cannot be opened.
+func (p *Prefetcher) FS() fs.FS {
+	<-p.done
+	return prefetchFS{p: p}
+}
+
+type prefetchFS struct {
+	p *Prefetcher
+}
+
+// Open implements fs.FS.
+func (p prefetchFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	f, ok := p.p.files[name]
+	if !ok || !f.fetched {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return newMemFile(name, f.data), nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"errors"
+	"image"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func TestPrefetcher(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.FS(images)))
+	defer server.Close()
+
+	p := ebitenutil.NewPrefetcher(server.URL, []string{"text.png", "text.png"})
+	fsys := p.FS()
+	if !p.IsDone() {
+		t.Errorf("p.IsDone() must be true after FS returns")
+	}
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	stat, err := fs.Stat(images, "text.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded, total := p.Progress(); loaded != stat.Size() || total != stat.Size() {
+		t.Errorf("p.Progress(): got: (%d, %d), want: (%d, %d)", loaded, total, stat.Size(), stat.Size())
+	}
+	if loaded, total := p.FileProgress("text.png"); loaded != stat.Size() || total != stat.Size() {
+		t.Errorf("p.FileProgress(): got: (%d, %d), want: (%d, %d)", loaded, total, stat.Size(), stat.Size())
+	}
+
+	img, _, err := ebitenutil.NewImageFromFileSystem(fsys, "text.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := img.Bounds().Size(), image.Pt(192, 128); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if _, err := fsys.Open("missing.png"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("got: %v, want: %v", err, fs.ErrNotExist)
+	}
+}
+
+func TestPrefetcherError(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.FS(images)))
+	defer server.Close()
+
+	p := ebitenutil.NewPrefetcher(server.URL, []string{"text.png", "missing.png"})
+	fsys := p.FS()
+	if p.Err() == nil {
+		t.Errorf("p.Err() must not be nil")
+	}
+	if _, err := fsys.Open("text.png"); err != nil {
+		t.Errorf("the fetched file must be available: %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+type urlFS struct {
+	baseURL string
+}
+
+// NewURLFS returns a file system that reads files from the given base URL via HTTP.
+//
+// The returned file system works on both desktops and browsers. On browsers, the files are fetched with the Fetch API.
+// The files are read into memory entirely when they are opened, and they implement io.Seeker.
+// Directories cannot be opened.
+//
+// This is useful to share asset loading code between an embedded file system (embed.FS), the local file system
+// (os.DirFS) and a web server.
+func NewURLFS(baseURL string) fs.FS {
+	return &urlFS{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Open implements fs.FS.
+func (u *urlFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	res, err := http.Get(u.baseURL + "/" + name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	case res.StatusCode == http.StatusForbidden:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+	case res.StatusCode < 200 || res.StatusCode >= 300:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fmt.Errorf("ebitenutil: unexpected HTTP status: %s", res.Status)}
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return newMemFile(name, body), nil
+}
+
+// memFile is a read-only file on memory.
+type memFile struct {
+	*bytes.Reader
+	name string
+	size int64
+}
+
+func newMemFile(name string, data []byte) *memFile {
+	return &memFile{
+		Reader: bytes.NewReader(data),
+		name:   path.Base(name),
+		size:   int64(len(data)),
+	}
+}
+
+// Stat implements fs.File.
+func (m *memFile) Stat() (fs.FileInfo, error) {
+	return m, nil
+}
+
+// Close implements fs.File.
+func (m *memFile) Close() error {
+	return nil
+}
+
+// Name implements fs.FileInfo.
+func (m *memFile) Name() string {
+	return m.name
+}
+
+// Size implements fs.FileInfo.
+func (m *memFile) Size() int64 {
+	return m.size
+}
+
+// Mode implements fs.FileInfo.
+func (m *memFile) Mode() fs.FileMode {
+	return 0444
+}
+
+// ModTime implements fs.FileInfo.
+func (m *memFile) ModTime() time.Time {
+	return time.Time{}
+}
+
+// IsDir implements fs.FileInfo.
+func (m *memFile) IsDir() bool {
+	return false
+}
+
+// Sys implements fs.FileInfo.
+func (m *memFile) Sys() any {
+	return nil
+}
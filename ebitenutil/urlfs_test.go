@@ -0,0 +1,56 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+func TestURLFS(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.FS(images)))
+	defer server.Close()
+
+	fsys := ebitenutil.NewURLFS(server.URL + "/")
+
+	f, err := fsys.Open("text.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := fs.Stat(images, "text.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat.Size() != want.Size() {
+		t.Errorf("stat.Size(): got: %d, want: %d", stat.Size(), want.Size())
+	}
+	_ = f.Close()
+
+	if _, err := fsys.Open("missing.png"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("got: %v, want: %v", err, fs.ErrNotExist)
+	}
+	if _, err := fsys.Open("../text.png"); !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("got: %v, want: %v", err, fs.ErrInvalid)
+	}
+}
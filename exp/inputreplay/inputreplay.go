@@ -0,0 +1,416 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inputreplay provides functions to record the input state of every tick and replay it later.
+//
+// While replaying, the recorded input state replaces the actual input state, and functions like ebiten.IsKeyPressed
+// and ebiten.CursorPosition return the recorded values.
+// This is useful for bug reproduction, demo playback, and regression tests of game logic.
+//
+// Keys, mouse buttons, the cursor position, the wheel, touches and input characters are recorded.
+// Gamepads, device motions, dropped files and window closing are not recorded.
+//
+// For deterministic replay, the game's Update must depend only on the input state and its own state.
+// For example, a random number generator must be seeded with a fixed value.
+//
+// The package is experimental and the API might be changed in the future.
+package inputreplay
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+const magic = "EBINPUT1"
+
+const keyBytes = (int(ui.KeyMax) + 1 + 7) / 8
+
+const (
+	flagKeys = 1 << iota
+	flagMouseButtons
+	flagCursor
+	flagWheel
+	flagTouches
+	flagRunes
+)
+
+type state struct {
+	recorder  *recorder
+	player    *player
+	playerErr error
+	m         sync.Mutex
+}
+
+var theState state
+
+func init() {
+	ui.SetInputStateHook(theState.onInputState)
+}
+
+func (s *state) onInputState(inputState *ui.InputState) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.recorder != nil {
+		s.recorder.record(inputState)
+	}
+	if s.player != nil {
+		if !s.player.play(inputState) {
+			s.player = nil
+		}
+	}
+}
+
+// StartRecording starts recording the input state of every tick to w.
+//
+// The input state is written from the next tick until StopRecording is called.
+//
+// StartRecording returns an error when recording or replaying is already in progress.
+func StartRecording(w io.Writer) error {
+	theState.m.Lock()
+	defer theState.m.Unlock()
+
+	if theState.recorder != nil {
+		return errors.New("inputreplay: recording is already in progress")
+	}
+	if theState.player != nil {
+		return errors.New("inputreplay: replaying is in progress")
+	}
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString(magic); err != nil {
+		return err
+	}
+	if err := bw.WriteByte(byte(keyBytes)); err != nil {
+		return err
+	}
+	theState.recorder = &recorder{
+		w: bw,
+	}
+	return nil
+}
+
+// StopRecording stops recording and flushes the recorded data.
+//
+// StopRecording returns the first error that happened while recording, if any.
+func StopRecording() error {
+	theState.m.Lock()
+	defer theState.m.Unlock()
+
+	r := theState.recorder
+	if r == nil {
+		return errors.New("inputreplay: recording is not in progress")
+	}
+	theState.recorder = nil
+
+	if r.err != nil {
+		return r.err
+	}
+	return r.w.Flush()
+}
+
+// StartReplay starts replaying the input state recorded by StartRecording from r.
+//
+// The recorded input state is used from the next tick. Replaying stops automatically at the end of r.
+//
+// StartReplay returns an error when recording or replaying is already in progress, or r doesn't have valid data.
+func StartReplay(r io.Reader) error {
+	theState.m.Lock()
+	defer theState.m.Unlock()
+
+	if theState.recorder != nil {
+		return errors.New("inputreplay: recording is in progress")
+	}
+	if theState.player != nil {
+		return errors.New("inputreplay: replaying is already in progress")
+	}
+
+	br := bufio.NewReader(r)
+	header := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return fmt.Errorf("inputreplay: reading the header failed: %w", err)
+	}
+	if string(header[:len(magic)]) != magic {
+		return errors.New("inputreplay: invalid data")
+	}
+	if int(header[len(magic)]) != keyBytes {
+		return errors.New("inputreplay: the data was recorded with an incompatible version")
+	}
+	theState.player = &player{
+		r: br,
+	}
+	theState.playerErr = nil
+	return nil
+}
+
+// StopReplay stops replaying. If replaying is not in progress, StopReplay does nothing.
+func StopReplay() {
+	theState.m.Lock()
+	defer theState.m.Unlock()
+	theState.player = nil
+}
+
+// IsReplaying reports whether replaying is in progress.
+func IsReplaying() bool {
+	theState.m.Lock()
+	defer theState.m.Unlock()
+	return theState.player != nil
+}
+
+// Err returns the error that stopped the last replay, if any.
+// Reaching the end of the data is not treated as an error.
+func Err() error {
+	theState.m.Lock()
+	defer theState.m.Unlock()
+	return theState.playerErr
+}
+
+type recorder struct {
+	w    *bufio.Writer
+	prev ui.InputState
+	buf  []byte
+	err  error
+}
+
+func (r *recorder) record(s *ui.InputState) {
+	if r.err != nil {
+		return
+	}
+
+	var flags byte
+	if s.KeyPressed != r.prev.KeyPressed {
+		flags |= flagKeys
+	}
+	if s.MouseButtonPressed != r.prev.MouseButtonPressed {
+		flags |= flagMouseButtons
+	}
+	if s.CursorX != r.prev.CursorX || s.CursorY != r.prev.CursorY {
+		flags |= flagCursor
+	}
+	if s.WheelX != 0 || s.WheelY != 0 {
+		flags |= flagWheel
+	}
+	if !equalTouches(s.Touches, r.prev.Touches) {
+		flags |= flagTouches
+	}
+	if len(s.Runes) > 0 {
+		flags |= flagRunes
+	}
+
+	r.buf = append(r.buf[:0], flags)
+	if flags&flagKeys != 0 {
+		var bits [keyBytes]byte
+		for i, p := range s.KeyPressed {
+			if p {
+				bits[i/8] |= 1 << (i % 8)
+			}
+		}
+		r.buf = append(r.buf, bits[:]...)
+	}
+	if flags&flagMouseButtons != 0 {
+		var bits byte
+		for i, p := range s.MouseButtonPressed {
+			if p {
+				bits |= 1 << i
+			}
+		}
+		r.buf = append(r.buf, bits)
+	}
+	if flags&flagCursor != 0 {
+		r.buf = appendVarint(r.buf, int64(s.CursorX))
+		r.buf = appendVarint(r.buf, int64(s.CursorY))
+	}
+	if flags&flagWheel != 0 {
+		r.buf = appendUvarint(r.buf, math.Float64bits(s.WheelX))
+		r.buf = appendUvarint(r.buf, math.Float64bits(s.WheelY))
+	}
+	if flags&flagTouches != 0 {
+		r.buf = appendUvarint(r.buf, uint64(len(s.Touches)))
+		for _, t := range s.Touches {
+			r.buf = appendVarint(r.buf, int64(t.ID))
+			r.buf = appendVarint(r.buf, int64(t.X))
+			r.buf = appendVarint(r.buf, int64(t.Y))
+		}
+	}
+	if flags&flagRunes != 0 {
+		r.buf = appendUvarint(r.buf, uint64(len(s.Runes)))
+		for _, ch := range s.Runes {
+			r.buf = appendVarint(r.buf, int64(ch))
+		}
+	}
+
+	if _, err := r.w.Write(r.buf); err != nil {
+		r.err = err
+		return
+	}
+
+	r.prev.KeyPressed = s.KeyPressed
+	r.prev.MouseButtonPressed = s.MouseButtonPressed
+	r.prev.CursorX = s.CursorX
+	r.prev.CursorY = s.CursorY
+	r.prev.Touches = append(r.prev.Touches[:0], s.Touches...)
+}
+
+type player struct {
+	r    *bufio.Reader
+	prev ui.InputState
+}
+
+// play overwrites s with the recorded input state of the next tick.
+// play returns false when replaying ends.
+func (p *player) play(s *ui.InputState) bool {
+	if err := p.read(); err != nil {
+		if !errors.Is(err, io.EOF) {
+			theState.playerErr = err
+		}
+		return false
+	}
+
+	s.KeyPressed = p.prev.KeyPressed
+	s.MouseButtonPressed = p.prev.MouseButtonPressed
+	s.CursorX = p.prev.CursorX
+	s.CursorY = p.prev.CursorY
+	s.WheelX = p.prev.WheelX
+	s.WheelY = p.prev.WheelY
+	s.Touches = append(s.Touches[:0], p.prev.Touches...)
+	s.Runes = append(s.Runes[:0], p.prev.Runes...)
+	return true
+}
+
+func (p *player) read() error {
+	flags, err := p.r.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	if flags&flagKeys != 0 {
+		var bits [keyBytes]byte
+		if _, err := io.ReadFull(p.r, bits[:]); err != nil {
+			return unexpectedEOF(err)
+		}
+		for i := range p.prev.KeyPressed {
+			p.prev.KeyPressed[i] = bits[i/8]&(1<<(i%8)) != 0
+		}
+	}
+	if flags&flagMouseButtons != 0 {
+		bits, err := p.r.ReadByte()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		for i := range p.prev.MouseButtonPressed {
+			p.prev.MouseButtonPressed[i] = bits&(1<<i) != 0
+		}
+	}
+	if flags&flagCursor != 0 {
+		x, err := binary.ReadVarint(p.r)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		y, err := binary.ReadVarint(p.r)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		p.prev.CursorX = int(x)
+		p.prev.CursorY = int(y)
+	}
+	p.prev.WheelX = 0
+	p.prev.WheelY = 0
+	if flags&flagWheel != 0 {
+		x, err := binary.ReadUvarint(p.r)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		y, err := binary.ReadUvarint(p.r)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		p.prev.WheelX = math.Float64frombits(x)
+		p.prev.WheelY = math.Float64frombits(y)
+	}
+	if flags&flagTouches != 0 {
+		n, err := binary.ReadUvarint(p.r)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		p.prev.Touches = p.prev.Touches[:0]
+		for i := uint64(0); i < n; i++ {
+			var vs [3]int64
+			for j := range vs {
+				v, err := binary.ReadVarint(p.r)
+				if err != nil {
+					return unexpectedEOF(err)
+				}
+				vs[j] = v
+			}
+			p.prev.Touches = append(p.prev.Touches, ui.Touch{
+				ID: ui.TouchID(vs[0]),
+				X:  int(vs[1]),
+				Y:  int(vs[2]),
+			})
+		}
+	}
+	p.prev.Runes = p.prev.Runes[:0]
+	if flags&flagRunes != 0 {
+		n, err := binary.ReadUvarint(p.r)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		for i := uint64(0); i < n; i++ {
+			v, err := binary.ReadVarint(p.r)
+			if err != nil {
+				return unexpectedEOF(err)
+			}
+			p.prev.Runes = append(p.prev.Runes, rune(v))
+		}
+	}
+	return nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return fmt.Errorf("inputreplay: the data is truncated: %w", io.ErrUnexpectedEOF)
+	}
+	return err
+}
+
+func equalTouches(a, b []ui.Touch) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func appendVarint(buf []byte, v int64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(b[:], v)
+	return append(buf, b[:n]...)
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	return append(buf, b[:n]...)
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inputreplay
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	var states []ui.InputState
+	for i := 0; i < 4; i++ {
+		var s ui.InputState
+		s.KeyPressed[ui.KeyA] = i%2 == 0
+		s.KeyPressed[ui.KeyMax] = i == 3
+		s.MouseButtonPressed[ui.MouseButton1] = i >= 2
+		s.CursorX = 10 * i
+		s.CursorY = -5 * i
+		if i == 1 {
+			s.WheelY = 1.5
+			s.Runes = []rune{'a', 'あ'}
+		}
+		if i >= 2 {
+			s.Touches = []ui.Touch{{ID: 3, X: i, Y: 100}}
+		}
+		states = append(states, s)
+	}
+
+	var buf bytes.Buffer
+	if err := StartRecording(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for i := range states {
+		s := states[i]
+		theState.onInputState(&s)
+	}
+	if err := StopRecording(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := StartReplay(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range states {
+		var got ui.InputState
+		// The actual input must be overwritten.
+		got.CursorX = 1000
+		got.KeyPressed[ui.KeyB] = true
+		theState.onInputState(&got)
+		if len(want.Touches) == 0 {
+			want.Touches = []ui.Touch{}
+		}
+		if len(want.Runes) == 0 {
+			want.Runes = []rune{}
+		}
+		if got.Touches == nil {
+			got.Touches = []ui.Touch{}
+		}
+		if got.Runes == nil {
+			got.Runes = []rune{}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("tick %d: got: %+v, want: %+v", i, got, want)
+		}
+	}
+	if !IsReplaying() {
+		t.Errorf("IsReplaying(): got: false, want: true")
+	}
+
+	// Reaching the end of the data stops replaying.
+	var s ui.InputState
+	s.CursorX = 1000
+	theState.onInputState(&s)
+	if IsReplaying() {
+		t.Errorf("IsReplaying(): got: true, want: false")
+	}
+	if s.CursorX != 1000 {
+		t.Errorf("the input state must not be modified after replaying ends")
+	}
+	if err := Err(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReplayInvalidData(t *testing.T) {
+	if err := StartReplay(bytes.NewReader([]byte("invalid data"))); err == nil {
+		t.Errorf("StartReplay must return an error for invalid data")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recorder
+
+import (
+	"image"
+	"io"
+	"time"
+)
+
+type RecorderForTesting struct {
+	r *recorder
+}
+
+func NewRecorderForTesting(w io.Writer, options *Options) *RecorderForTesting {
+	return &RecorderForTesting{r: newRecorder(w, options)}
+}
+
+// AppendFrame appends a frame. AppendFrame waits while the encoder is busy, so that the frame is not dropped for that.
+func (r *RecorderForTesting) AppendFrame(img *image.RGBA, t time.Time) {
+	for {
+		r.r.m.Lock()
+		busy := len(r.r.frames) == cap(r.r.frames)
+		r.r.m.Unlock()
+		if !busy {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	r.r.appendFrame(img, t)
+}
+
+func (r *RecorderForTesting) Stop() error {
+	return r.r.stop()
+}
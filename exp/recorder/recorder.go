@@ -0,0 +1,240 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package recorder provides a recorder of the game screen as an animated GIF.
+//
+// This package is experimental and the API might be changed in the future.
+package recorder
+
+import (
+	"errors"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/internal/hooks"
+)
+
+// Options represents options for recording.
+type Options struct {
+	// FrameRate is the number of frames recorded per second.
+	// The actual frame rate might be lower than this when encoding frames cannot catch up with the game.
+	//
+	// The default (zero) value is 10.
+	FrameRate int
+
+	// MaxFrames is the maximum number of recorded frames.
+	// After the number of recorded frames reaches MaxFrames, the later frames are not recorded.
+	//
+	// The recorded frames are kept in memory until Stop is called, and each frame takes one byte per pixel.
+	//
+	// The default (zero) value is 600, i.e., 60 seconds with the default frame rate.
+	MaxFrames int
+}
+
+// frameBufferSize is the number of frames waiting for encoding.
+// If encoding cannot catch up with the game, new frames are dropped instead of stalling the game.
+const frameBufferSize = 8
+
+type frame struct {
+	image *image.RGBA
+	time  time.Time
+}
+
+type recorder struct {
+	w           io.Writer
+	interval    time.Duration
+	maxFrames   int
+	lastCapture time.Time
+
+	frames  chan frame
+	stopped bool
+	done    chan struct{}
+	err     error
+
+	// frameCount is the number of frames sent to the encoder.
+	frameCount int
+
+	m sync.Mutex
+}
+
+var (
+	theRecorder  *recorder
+	theRecorderM sync.Mutex
+)
+
+func init() {
+	hooks.AppendHookOnBeforeUpdate(func() error {
+		theRecorderM.Lock()
+		r := theRecorder
+		theRecorderM.Unlock()
+		if r != nil {
+			r.update()
+		}
+		return nil
+	})
+}
+
+// Start starts recording the game screen, and the recorded frames are written to w as an animated GIF when Stop is called.
+//
+// The frames are taken from the screen image given to Draw, i.e., the size is the one returned by Layout.
+// Frames whose size is different from the first frame's are skipped.
+// The colors are reduced to the Plan 9 palette.
+// The number of recorded frames is limited by Options.MaxFrames.
+//
+// Start returns an error when a recording is already in progress.
+//
+// Start is concurrent-safe.
+func Start(w io.Writer, options *Options) error {
+	theRecorderM.Lock()
+	defer theRecorderM.Unlock()
+
+	if theRecorder != nil {
+		return errors.New("recorder: recording is already in progress")
+	}
+
+	theRecorder = newRecorder(w, options)
+	return nil
+}
+
+func newRecorder(w io.Writer, options *Options) *recorder {
+	frameRate := 10
+	if options != nil && options.FrameRate > 0 {
+		frameRate = options.FrameRate
+	}
+	maxFrames := 600
+	if options != nil && options.MaxFrames > 0 {
+		maxFrames = options.MaxFrames
+	}
+
+	r := &recorder{
+		w:         w,
+		interval:  time.Second / time.Duration(frameRate),
+		maxFrames: maxFrames,
+		frames:    make(chan frame, frameBufferSize),
+		done:      make(chan struct{}),
+	}
+	go r.encode()
+	return r
+}
+
+// Stop stops recording, and writes the recorded frames to the writer given at Start.
+// Stop blocks until all the frames are encoded.
+//
+// Stop returns an error when no recording is in progress, when no frames are recorded, or when writing fails.
+//
+// Stop is concurrent-safe.
+func Stop() error {
+	theRecorderM.Lock()
+	r := theRecorder
+	theRecorder = nil
+	theRecorderM.Unlock()
+
+	if r == nil {
+		return errors.New("recorder: no recording is in progress")
+	}
+	return r.stop()
+}
+
+func (r *recorder) stop() error {
+	r.m.Lock()
+	r.stopped = true
+	close(r.frames)
+	r.m.Unlock()
+
+	<-r.done
+	return r.err
+}
+
+func (r *recorder) update() {
+	now := time.Now()
+	if !r.lastCapture.IsZero() && now.Sub(r.lastCapture) < r.interval {
+		return
+	}
+
+	// Skip the frame before reading back the screen, when the frame would be dropped anyway.
+	if !r.acceptsFrame() {
+		return
+	}
+	r.lastCapture = now
+
+	ebiten.TakeScreenshot(func(screenshot *image.RGBA) {
+		r.appendFrame(screenshot, now)
+	})
+}
+
+// acceptsFrame reports whether a new frame can be sent to the encoder.
+func (r *recorder) acceptsFrame() bool {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return !r.stopped && r.frameCount < r.maxFrames && len(r.frames) < cap(r.frames)
+}
+
+// appendFrame sends a frame to the encoder.
+// The frame is dropped when the encoder cannot catch up with the game, or when the number of frames reaches the limit.
+func (r *recorder) appendFrame(img *image.RGBA, t time.Time) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.stopped || r.frameCount >= r.maxFrames {
+		return
+	}
+	select {
+	case r.frames <- frame{image: img, time: t}:
+		r.frameCount++
+	default:
+	}
+}
+
+func (r *recorder) encode() {
+	defer close(r.done)
+
+	var g gif.GIF
+	var lastTime time.Time
+	for f := range r.frames {
+		b := f.image.Bounds()
+		if len(g.Image) > 0 {
+			if g.Image[0].Bounds() != b {
+				continue
+			}
+			g.Delay[len(g.Delay)-1] = centiseconds(f.time.Sub(lastTime))
+		}
+		p := image.NewPaletted(b, palette.Plan9)
+		draw.Draw(p, b, f.image, b.Min, draw.Src)
+		g.Image = append(g.Image, p)
+		g.Delay = append(g.Delay, centiseconds(r.interval))
+		lastTime = f.time
+	}
+
+	if len(g.Image) == 0 {
+		r.err = errors.New("recorder: no frames were recorded")
+		return
+	}
+	if err := gif.EncodeAll(r.w, &g); err != nil {
+		r.err = err
+		return
+	}
+}
+
+func centiseconds(d time.Duration) int {
+	c := int((d + 5*time.Millisecond) / (10 * time.Millisecond))
+	if c < 1 {
+		c = 1
+	}
+	return c
+}
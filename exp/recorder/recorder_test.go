@@ -0,0 +1,123 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recorder_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/recorder"
+)
+
+func solidImage(width, height int, clr color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for j := 0; j < height; j++ {
+		for i := 0; i < width; i++ {
+			img.SetRGBA(i, j, clr)
+		}
+	}
+	return img
+}
+
+func TestRecord(t *testing.T) {
+	var buf bytes.Buffer
+	r := recorder.NewRecorderForTesting(&buf, &recorder.Options{
+		FrameRate: 10,
+	})
+
+	colors := []color.RGBA{
+		{R: 0xff, A: 0xff},
+		{G: 0xff, A: 0xff},
+		{B: 0xff, A: 0xff},
+	}
+	now := time.Now()
+	for i, c := range colors {
+		r.AppendFrame(solidImage(4, 3, c), now.Add(time.Duration(i)*200*time.Millisecond))
+	}
+	// A frame with a different size is skipped.
+	r.AppendFrame(solidImage(2, 2, colors[0]), now.Add(800*time.Millisecond))
+
+	if err := r.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(g.Image), len(colors); got != want {
+		t.Fatalf("len(g.Image): got: %d, want: %d", got, want)
+	}
+	for i, img := range g.Image {
+		if got, want := img.Bounds(), image.Rect(0, 0, 4, 3); got != want {
+			t.Errorf("g.Image[%d].Bounds(): got: %v, want: %v", i, got, want)
+		}
+		r, g, b, a := img.At(1, 1).RGBA()
+		if got, want := (color.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(a >> 8)}), colors[i]; got != want {
+			t.Errorf("g.Image[%d].At(1, 1): got: %v, want: %v", i, got, want)
+		}
+	}
+	// The delays are the intervals between the frames, and the last delay is the frame interval.
+	if got, want := g.Delay, []int{20, 20, 10}; !intsEqual(got, want) {
+		t.Errorf("g.Delay: got: %v, want: %v", got, want)
+	}
+}
+
+func TestRecordMaxFrames(t *testing.T) {
+	var buf bytes.Buffer
+	r := recorder.NewRecorderForTesting(&buf, &recorder.Options{
+		MaxFrames: 2,
+	})
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		r.AppendFrame(solidImage(2, 2, color.RGBA{A: 0xff}), now.Add(time.Duration(i)*100*time.Millisecond))
+	}
+	if err := r.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(g.Image), 2; got != want {
+		t.Errorf("len(g.Image): got: %d, want: %d", got, want)
+	}
+}
+
+func TestRecordNoFrames(t *testing.T) {
+	var buf bytes.Buffer
+	r := recorder.NewRecorderForTesting(&buf, nil)
+	if err := r.Stop(); err == nil {
+		t.Errorf("Stop must return an error when no frames are recorded")
+	}
+}
+
+func intsEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
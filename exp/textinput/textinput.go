@@ -0,0 +1,107 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package textinput provides a text-inputting controller with input method editors (IMEs).
+//
+// IMEs are supported only on browsers (GOOS=js), since the other platforms don't provide the composition
+// states of IMEs to Ebitengine yet. On the other environments, Start returns nil channels,
+// and ebiten.AppendInputChars should be used to get the settled characters.
+//
+// This package is experimental and the API might be changed in the future.
+package textinput
+
+import (
+	"sync"
+)
+
+// State represents the current state of text inputting.
+type State struct {
+	// Text represents the current inputting text.
+	Text string
+
+	// CompositionSelectionStartInBytes represents the start position of the selection in bytes.
+	CompositionSelectionStartInBytes int
+
+	// CompositionSelectionEndInBytes represents the end position of the selection in bytes.
+	CompositionSelectionEndInBytes int
+
+	// Committed reports whether the current Text is the settled text.
+	Committed bool
+
+	// Error is an error that happens during text inputting.
+	Error error
+}
+
+// Start starts the text inputting session and returns a channel to send the states repeatedly, and a function to end the session.
+//
+// x and y specify the position where an IME puts its candidate window, in the game screen coordinate.
+//
+// The channel should be read every tick. If the channel is not read for a while, old states might be dropped.
+//
+// The channel is closed when the session ends, e.g., when the function to end the session is called,
+// or when the text element loses the focus.
+// Calling Start again ends the current session.
+//
+// Start must be called from the game's Update.
+//
+// On environments where IMEs are not supported, Start returns a nil channel and a nil function.
+func Start(x, y int) (states <-chan State, close func()) {
+	return theTextInput.Start(x, y)
+}
+
+// stateBufferSize is the size of a channel's buffer to send states.
+// When the buffer is full, the oldest state is dropped.
+const stateBufferSize = 16
+
+type session struct {
+	ch chan State
+	m  sync.Mutex
+}
+
+func newSession() *session {
+	return &session{
+		ch: make(chan State, stateBufferSize),
+	}
+}
+
+func (s *session) end() {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.ch == nil {
+		return
+	}
+	close(s.ch)
+	s.ch = nil
+}
+
+func (s *session) trySend(state State) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.ch == nil {
+		return
+	}
+
+	for {
+		select {
+		case s.ch <- state:
+			return
+		default:
+			// The buffer is full. Drop the oldest state.
+			select {
+			case <-s.ch:
+			default:
+			}
+		}
+	}
+}
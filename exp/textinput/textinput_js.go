@@ -0,0 +1,167 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput
+
+import (
+	"fmt"
+	"syscall/js"
+	"unicode/utf16"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+var (
+	document = js.Global().Get("document")
+	body     = document.Get("body")
+)
+
+// textInput is a text-inputting controller with a hidden textarea element.
+// The textarea element takes the focus during a session so that an IME can work on it.
+type textInput struct {
+	textareaElement js.Value
+
+	session *session
+}
+
+var theTextInput textInput
+
+func (t *textInput) init() {
+	t.textareaElement = document.Call("createElement", "textarea")
+	t.textareaElement.Call("setAttribute", "autocapitalize", "off")
+	t.textareaElement.Call("setAttribute", "spellcheck", "false")
+	t.textareaElement.Call("setAttribute", "translate", "no")
+	t.textareaElement.Call("setAttribute", "wrap", "off")
+	t.textareaElement.Call("setAttribute", "tabindex", "-1")
+
+	style := t.textareaElement.Get("style")
+	style.Set("position", "absolute")
+	style.Set("left", "0")
+	style.Set("top", "0")
+	style.Set("opacity", "0")
+	style.Set("resize", "none")
+	style.Set("pointerEvents", "none")
+	style.Set("overflow", "hidden")
+	style.Set("width", "1px")
+	style.Set("height", "1px")
+
+	t.textareaElement.Call("addEventListener", "compositionend", js.FuncOf(func(this js.Value, args []js.Value) any {
+		t.trySend(true)
+		return nil
+	}))
+	t.textareaElement.Call("addEventListener", "focusout", js.FuncOf(func(this js.Value, args []js.Value) any {
+		if t.session != nil {
+			t.session.end()
+			t.session = nil
+		}
+		return nil
+	}))
+	t.textareaElement.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) any {
+		e := args[0]
+		if e.Get("code").String() == "Tab" {
+			e.Call("preventDefault")
+		}
+		// The textarea element takes the keyboard events instead of the canvas.
+		// Send the events to the game unless the IME is working.
+		if !e.Get("isComposing").Bool() {
+			ui.Get().UpdateInputFromEvent(e)
+		}
+		return nil
+	}))
+	t.textareaElement.Call("addEventListener", "keyup", js.FuncOf(func(this js.Value, args []js.Value) any {
+		e := args[0]
+		if !e.Get("isComposing").Bool() {
+			ui.Get().UpdateInputFromEvent(e)
+		}
+		return nil
+	}))
+	t.textareaElement.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) any {
+		e := args[0]
+		if e.Get("isComposing").Bool() {
+			t.trySend(false)
+			return nil
+		}
+		t.trySend(true)
+		return nil
+	}))
+
+	body.Call("appendChild", t.textareaElement)
+}
+
+func (t *textInput) Start(x, y int) (<-chan State, func()) {
+	// document is undefined on node.js.
+	if !document.Truthy() {
+		return nil, nil
+	}
+
+	if !t.textareaElement.Truthy() {
+		t.init()
+	}
+
+	if t.session != nil {
+		t.session.end()
+		t.session = nil
+	}
+
+	cx, cy := ui.Get().LogicalPositionToClientPosition(float64(x), float64(y))
+	style := t.textareaElement.Get("style")
+	style.Set("left", fmt.Sprintf("%0.2fpx", cx))
+	style.Set("top", fmt.Sprintf("%0.2fpx", cy))
+
+	s := newSession()
+	t.session = s
+	t.textareaElement.Set("value", "")
+	t.textareaElement.Call("focus")
+
+	return s.ch, func() {
+		s.end()
+		if t.session != s {
+			return
+		}
+		t.session = nil
+		t.textareaElement.Call("blur")
+		ui.Get().FocusCanvas()
+	}
+}
+
+func (t *textInput) trySend(committed bool) {
+	if t.session == nil {
+		return
+	}
+
+	value := t.textareaElement.Get("value").String()
+	start := t.textareaElement.Get("selectionStart").Int()
+	end := t.textareaElement.Get("selectionEnd").Int()
+
+	t.session.trySend(State{
+		Text:                             value,
+		CompositionSelectionStartInBytes: convertUTF16CountToByteCount(value, start),
+		CompositionSelectionEndInBytes:   convertUTF16CountToByteCount(value, end),
+		Committed:                        committed,
+	})
+
+	if committed {
+		t.textareaElement.Set("value", "")
+	}
+}
+
+// convertUTF16CountToByteCount converts the count of UTF-16 code units, which JavaScript uses for a string index,
+// to the count of bytes in UTF-8.
+func convertUTF16CountToByteCount(text string, c int) int {
+	u := utf16.Encode([]rune(text))
+	if c > len(u) {
+		c = len(u)
+	}
+	return len(string(utf16.Decode(u[:c])))
+}
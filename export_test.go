@@ -14,6 +14,23 @@
 
 package ebiten
 
+import (
+	"image"
+)
+
 var (
-	ImageToBytes = imageToBytes
+	ImageToBytes          = imageToBytes
+	QuadWeightsForTesting = quadWeights
 )
+
+type ScreenshotRequestsForTesting struct {
+	s screenshotRequests
+}
+
+func (s *ScreenshotRequestsForTesting) Add(f func(screenshot *image.RGBA)) {
+	s.s.add(f)
+}
+
+func (s *ScreenshotRequestsForTesting) Take(screen *Image) {
+	s.s.take(screen)
+}
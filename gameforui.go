@@ -21,6 +21,7 @@ import (
 	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
+	"github.com/hajimehoshi/ebiten/v2/internal/clock"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
 )
 
@@ -162,10 +163,15 @@ func (g *gameForUI) Update() error {
 }
 
 func (g *gameForUI) DrawOffscreen() error {
-	g.game.Draw(g.offscreen)
+	if d, ok := g.game.(InterpolatedDrawer); ok {
+		d.DrawInterpolated(g.offscreen, clock.Interpolation())
+	} else {
+		g.game.Draw(g.offscreen)
+	}
 	if err := g.imageDumper.dump(g.offscreen, g.transparent); err != nil {
 		return err
 	}
+	theScreenshotRequests.take(g.offscreen)
 	return nil
 }
 
@@ -749,7 +749,7 @@ func main() {
 		case filepath.Join("internal", "ui", "keys_mobile.go"):
 			buildTag = "//go:build (android || ios) && !nintendosdk"
 		case filepath.Join("internal", "ui", "keys_glfw.go"):
-			buildTag = "//go:build !android && !ios && !js && !nintendosdk"
+			buildTag = "//go:build !android && !ios && !js && !nintendosdk && !ebitengineheadless"
 		}
 		// NOTE: According to godoc, maps are automatically sorted by key.
 		if err := tmpl.Execute(f, struct {
@@ -0,0 +1,129 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gesture provides recognition of gestures like taps, swipes and pinches.
+//
+// Gestures are recognized from touches and the left mouse button.
+// The mouse is ignored while touching and for a short while after touching, as browsers emulate mouse events from
+// touches.
+// Importing this package starts the recognition, and the recognized gestures can be got by AppendEvents every tick.
+package gesture
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/internal/hooks"
+)
+
+// Type represents a gesture type.
+type Type int
+
+const (
+	// TypeTap is a short press and release without moving.
+	TypeTap Type = iota
+
+	// TypeDoubleTap is a tap just after another tap at the same position.
+	// The second tap is reported as TypeDoubleTap instead of TypeTap.
+	TypeDoubleTap
+
+	// TypeLongPress is a long press without moving.
+	// TypeLongPress is reported once while pressing, and no tap is reported on the release.
+	TypeLongPress
+
+	// TypeSwipe is a fast move and release.
+	TypeSwipe
+
+	// TypePinch is a move of two pointers changing the distance between them.
+	// TypePinch is reported every tick while pinching.
+	TypePinch
+)
+
+// String returns a string representing the gesture type.
+func (t Type) String() string {
+	switch t {
+	case TypeTap:
+		return "Tap"
+	case TypeDoubleTap:
+		return "DoubleTap"
+	case TypeLongPress:
+		return "LongPress"
+	case TypeSwipe:
+		return "Swipe"
+	case TypePinch:
+		return "Pinch"
+	}
+	panic(fmt.Sprintf("gesture: invalid Type: %d", t))
+}
+
+// Event represents a recognized gesture.
+type Event struct {
+	// Type is the gesture type.
+	Type Type
+
+	// X and Y are the position of the gesture.
+	// For TypeSwipe, X and Y are the position where the pointer is released.
+	// For TypePinch, X and Y are the center of the two pointers.
+	X int
+	Y int
+
+	// VelocityX and VelocityY are the velocity in pixels per tick.
+	// VelocityX and VelocityY are valid only for TypeSwipe.
+	VelocityX float64
+	VelocityY float64
+
+	// Scale is the ratio of the current distance between the two pointers to the initial distance.
+	// Scale is valid only for TypePinch.
+	Scale float64
+}
+
+var theRecognizer = newRecognizer()
+
+func init() {
+	var touchIDs []ebiten.TouchID
+	var pointers []pointer
+	hooks.AppendHookOnBeforeUpdate(func() error {
+		pointers = pointers[:0]
+
+		touchIDs = ebiten.AppendTouchIDs(touchIDs[:0])
+		for _, id := range touchIDs {
+			x, y := ebiten.TouchPosition(id)
+			pointers = append(pointers, pointer{
+				id: pointerID{touch: int(id)},
+				x:  x,
+				y:  y,
+			})
+		}
+
+		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			x, y := ebiten.CursorPosition()
+			pointers = append(pointers, pointer{
+				id: pointerID{mouse: true},
+				x:  x,
+				y:  y,
+			})
+		}
+
+		theRecognizer.update(pointers)
+		return nil
+	})
+}
+
+// AppendEvents appends the gestures recognized in the current tick to events, and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+//
+// AppendEvents is concurrent-safe.
+func AppendEvents(events []Event) []Event {
+	return theRecognizer.appendEvents(events)
+}
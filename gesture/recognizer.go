@@ -0,0 +1,322 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gesture
+
+import (
+	"math"
+	"sync"
+)
+
+const (
+	// slop is the distance in pixels a pointer can move without being regarded as moving.
+	slop = 8
+
+	// tapMaxTicks is the maximum duration of a tap.
+	tapMaxTicks = 15
+
+	// doubleTapMaxTicks is the maximum interval between the releases of two taps of a double tap.
+	doubleTapMaxTicks = 20
+
+	// longPressTicks is the duration to recognize a long press.
+	longPressTicks = 30
+
+	// swipeMinDistance is the minimum distance of a swipe in pixels.
+	swipeMinDistance = 32
+
+	// swipeMinVelocity is the minimum velocity of a swipe in pixels per tick.
+	swipeMinVelocity = 2
+
+	// velocityTicks is the number of ticks to calculate a velocity.
+	velocityTicks = 4
+
+	// mouseIgnoreTicksAfterTouch is the duration to ignore the mouse after all the touches are released.
+	// Browsers emulate mouse events after touch events, and the emulated events can be delayed by up to about 300ms.
+	mouseIgnoreTicksAfterTouch = 30
+)
+
+type pointerID struct {
+	mouse bool
+	touch int
+}
+
+type pointer struct {
+	id pointerID
+	x  int
+	y  int
+}
+
+type position struct {
+	x int
+	y int
+}
+
+func distance(x0, y0, x1, y1 int) float64 {
+	return math.Hypot(float64(x1-x0), float64(y1-y0))
+}
+
+type pointerState struct {
+	startX int
+	startY int
+
+	// history is the positions in the last ticks. The last item is the current position.
+	history []position
+
+	duration    int
+	moved       bool
+	longPressed bool
+}
+
+func (p *pointerState) position() (int, int) {
+	pos := p.history[len(p.history)-1]
+	return pos.x, pos.y
+}
+
+func (p *pointerState) velocity() (float64, float64) {
+	if len(p.history) < 2 {
+		return 0, 0
+	}
+	first := p.history[0]
+	last := p.history[len(p.history)-1]
+	n := float64(len(p.history) - 1)
+	return float64(last.x-first.x) / n, float64(last.y-first.y) / n
+}
+
+type recognizer struct {
+	tick     int
+	pointers map[pointerID]*pointerState
+
+	// multi reports whether two or more pointers have been pressed since all the pointers were released.
+	multi bool
+
+	pinchStarted  bool
+	pinching      bool
+	pinchDistance float64
+
+	hasLastTap  bool
+	lastTapTick int
+	lastTapX    int
+	lastTapY    int
+
+	hasLastTouch  bool
+	lastTouchTick int
+
+	// filteredPointers is a buffer for the pointers without ignored ones.
+	filteredPointers []pointer
+
+	events []Event
+
+	m sync.Mutex
+}
+
+func newRecognizer() *recognizer {
+	return &recognizer{
+		pointers: map[pointerID]*pointerState{},
+	}
+}
+
+func (r *recognizer) appendEvents(events []Event) []Event {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return append(events, r.events...)
+}
+
+func (r *recognizer) update(pointers []pointer) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.tick++
+	r.events = r.events[:0]
+
+	pointers = r.filterEmulatedMouse(pointers)
+
+	// Released pointers.
+	for id, p := range r.pointers {
+		var found bool
+		for _, ptr := range pointers {
+			if ptr.id == id {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		r.release(p)
+		delete(r.pointers, id)
+	}
+
+	// Pressed or moved pointers.
+	for _, ptr := range pointers {
+		p, ok := r.pointers[ptr.id]
+		if !ok {
+			r.pointers[ptr.id] = &pointerState{
+				startX:   ptr.x,
+				startY:   ptr.y,
+				history:  []position{{x: ptr.x, y: ptr.y}},
+				duration: 1,
+			}
+			continue
+		}
+		p.duration++
+		p.history = append(p.history, position{x: ptr.x, y: ptr.y})
+		if len(p.history) > velocityTicks+1 {
+			p.history = p.history[1:]
+		}
+		if !p.moved && distance(p.startX, p.startY, ptr.x, ptr.y) > slop {
+			p.moved = true
+		}
+	}
+
+	if len(r.pointers) >= 2 {
+		r.multi = true
+	}
+
+	if !r.multi {
+		for _, p := range r.pointers {
+			if p.moved || p.longPressed || p.duration < longPressTicks {
+				continue
+			}
+			p.longPressed = true
+			x, y := p.position()
+			r.events = append(r.events, Event{
+				Type: TypeLongPress,
+				X:    x,
+				Y:    y,
+			})
+		}
+	}
+
+	r.updatePinch()
+
+	if len(r.pointers) == 0 {
+		r.multi = false
+	}
+}
+
+// filterEmulatedMouse removes the mouse from pointers while touching and for a while after touching.
+// Without this, a tap would be recognized twice on browsers, which emulate mouse events from touch events.
+func (r *recognizer) filterEmulatedMouse(pointers []pointer) []pointer {
+	for _, ptr := range pointers {
+		if !ptr.id.mouse {
+			r.hasLastTouch = true
+			r.lastTouchTick = r.tick
+			break
+		}
+	}
+	if !r.hasLastTouch || r.tick-r.lastTouchTick > mouseIgnoreTicksAfterTouch {
+		return pointers
+	}
+
+	// Forget the mouse without recognizing a gesture on its release.
+	delete(r.pointers, pointerID{mouse: true})
+
+	r.filteredPointers = r.filteredPointers[:0]
+	for _, ptr := range pointers {
+		if ptr.id.mouse {
+			continue
+		}
+		r.filteredPointers = append(r.filteredPointers, ptr)
+	}
+	return r.filteredPointers
+}
+
+func (r *recognizer) release(p *pointerState) {
+	if r.multi || p.longPressed {
+		return
+	}
+
+	x, y := p.position()
+
+	if !p.moved {
+		if p.duration > tapMaxTicks {
+			return
+		}
+		if r.hasLastTap && r.tick-r.lastTapTick <= doubleTapMaxTicks && distance(r.lastTapX, r.lastTapY, x, y) <= 2*slop {
+			r.hasLastTap = false
+			r.events = append(r.events, Event{
+				Type: TypeDoubleTap,
+				X:    x,
+				Y:    y,
+			})
+			return
+		}
+		r.hasLastTap = true
+		r.lastTapTick = r.tick
+		r.lastTapX = x
+		r.lastTapY = y
+		r.events = append(r.events, Event{
+			Type: TypeTap,
+			X:    x,
+			Y:    y,
+		})
+		return
+	}
+
+	if distance(p.startX, p.startY, x, y) < swipeMinDistance {
+		return
+	}
+	vx, vy := p.velocity()
+	if math.Hypot(vx, vy) < swipeMinVelocity {
+		return
+	}
+	r.events = append(r.events, Event{
+		Type:      TypeSwipe,
+		X:         x,
+		Y:         y,
+		VelocityX: vx,
+		VelocityY: vy,
+	})
+}
+
+func (r *recognizer) updatePinch() {
+	if len(r.pointers) != 2 {
+		r.pinchStarted = false
+		r.pinching = false
+		return
+	}
+
+	var ps [2]*pointerState
+	var i int
+	for _, p := range r.pointers {
+		ps[i] = p
+		i++
+	}
+	x0, y0 := ps[0].position()
+	x1, y1 := ps[1].position()
+	d := distance(x0, y0, x1, y1)
+
+	if !r.pinchStarted {
+		if d == 0 {
+			return
+		}
+		r.pinchStarted = true
+		r.pinchDistance = d
+		return
+	}
+
+	if !r.pinching {
+		if math.Abs(d-r.pinchDistance) <= slop {
+			return
+		}
+		r.pinching = true
+	}
+
+	r.events = append(r.events, Event{
+		Type:  TypePinch,
+		X:     (x0 + x1) / 2,
+		Y:     (y0 + y1) / 2,
+		Scale: d / r.pinchDistance,
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gesture
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	touch0 = pointerID{touch: 0}
+	touch1 = pointerID{touch: 1}
+	mouse  = pointerID{mouse: true}
+)
+
+// run updates r with the given pointers for each tick, and returns all the recognized events.
+func run(r *recognizer, ticks [][]pointer) []Event {
+	var events []Event
+	for _, ps := range ticks {
+		r.update(ps)
+		events = r.appendEvents(events)
+	}
+	return events
+}
+
+func press(id pointerID, x, y int, ticks int) [][]pointer {
+	var ps [][]pointer
+	for i := 0; i < ticks; i++ {
+		ps = append(ps, []pointer{{id: id, x: x, y: y}})
+	}
+	return ps
+}
+
+func idle(ticks int) [][]pointer {
+	return make([][]pointer, ticks)
+}
+
+func concat(ticks ...[][]pointer) [][]pointer {
+	var ps [][]pointer
+	for _, t := range ticks {
+		ps = append(ps, t...)
+	}
+	return ps
+}
+
+func TestTap(t *testing.T) {
+	events := run(newRecognizer(), concat(press(touch0, 10, 20, 5), idle(1)))
+	if got, want := len(events), 1; got != want {
+		t.Fatalf("len(events): got: %d, want: %d", got, want)
+	}
+	if got, want := events[0], (Event{Type: TypeTap, X: 10, Y: 20}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestDoubleTap(t *testing.T) {
+	events := run(newRecognizer(), concat(press(touch0, 10, 20, 5), idle(5), press(touch0, 12, 20, 5), idle(1)))
+	if got, want := len(events), 2; got != want {
+		t.Fatalf("len(events): got: %d, want: %d", got, want)
+	}
+	if got, want := events[0].Type, TypeTap; got != want {
+		t.Errorf("events[0].Type: got: %v, want: %v", got, want)
+	}
+	if got, want := events[1].Type, TypeDoubleTap; got != want {
+		t.Errorf("events[1].Type: got: %v, want: %v", got, want)
+	}
+
+	// Too slow taps are two taps.
+	events = run(newRecognizer(), concat(press(touch0, 10, 20, 5), idle(30), press(touch0, 10, 20, 5), idle(1)))
+	if got, want := len(events), 2; got != want {
+		t.Fatalf("len(events): got: %d, want: %d", got, want)
+	}
+	for i, e := range events {
+		if got, want := e.Type, TypeTap; got != want {
+			t.Errorf("events[%d].Type: got: %v, want: %v", i, got, want)
+		}
+	}
+}
+
+func TestTapWithEmulatedMouse(t *testing.T) {
+	// Browsers emulate a mouse press after a touch.
+	events := run(newRecognizer(), concat(press(touch0, 10, 20, 5), idle(3), press(mouse, 10, 20, 2), idle(1)))
+	if got, want := len(events), 1; got != want {
+		t.Fatalf("len(events): got: %d, want: %d", got, want)
+	}
+	if got, want := events[0], (Event{Type: TypeTap, X: 10, Y: 20}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// The mouse is ignored while touching.
+	var ticks [][]pointer
+	for i := 0; i < 5; i++ {
+		ticks = append(ticks, []pointer{{id: touch0, x: 10, y: 20}, {id: mouse, x: 10, y: 20}})
+	}
+	events = run(newRecognizer(), concat(ticks, idle(1)))
+	if got, want := len(events), 1; got != want {
+		t.Fatalf("len(events): got: %d, want: %d", got, want)
+	}
+	if got, want := events[0].Type, TypeTap; got != want {
+		t.Errorf("events[0].Type: got: %v, want: %v", got, want)
+	}
+
+	// The mouse is available again a while after touching.
+	events = run(newRecognizer(), concat(press(touch0, 10, 20, 5), idle(mouseIgnoreTicksAfterTouch+1), press(mouse, 100, 20, 5), idle(1)))
+	if got, want := len(events), 2; got != want {
+		t.Fatalf("len(events): got: %d, want: %d", got, want)
+	}
+	if got, want := events[1], (Event{Type: TypeTap, X: 100, Y: 20}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestLongPress(t *testing.T) {
+	events := run(newRecognizer(), concat(press(touch0, 10, 20, longPressTicks*2), idle(1)))
+	if got, want := len(events), 1; got != want {
+		t.Fatalf("len(events): got: %d, want: %d", got, want)
+	}
+	if got, want := events[0], (Event{Type: TypeLongPress, X: 10, Y: 20}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestSwipe(t *testing.T) {
+	var ticks [][]pointer
+	for i := 0; i < 10; i++ {
+		ticks = append(ticks, []pointer{{id: touch0, x: 10 + 10*i, y: 20}})
+	}
+	ticks = append(ticks, nil)
+
+	events := run(newRecognizer(), ticks)
+	if got, want := len(events), 1; got != want {
+		t.Fatalf("len(events): got: %d, want: %d", got, want)
+	}
+	if got, want := events[0], (Event{Type: TypeSwipe, X: 100, Y: 20, VelocityX: 10}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestPinch(t *testing.T) {
+	var ticks [][]pointer
+	for i := 0; i < 10; i++ {
+		ticks = append(ticks, []pointer{
+			{id: touch0, x: 100 - 10*i, y: 100},
+			{id: touch1, x: 120 + 10*i, y: 100},
+		})
+	}
+	ticks = append(ticks, nil)
+
+	events := run(newRecognizer(), ticks)
+	if len(events) == 0 {
+		t.Fatalf("no events")
+	}
+	for i, e := range events {
+		if got, want := e.Type, TypePinch; got != want {
+			t.Errorf("events[%d].Type: got: %v, want: %v", i, got, want)
+		}
+	}
+	last := events[len(events)-1]
+	if got, want := last.X, 110; got != want {
+		t.Errorf("X: got: %d, want: %d", got, want)
+	}
+	if got, want := last.Scale, 200.0/20.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Scale: got: %f, want: %f", got, want)
+	}
+}
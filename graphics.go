@@ -16,8 +16,11 @@ package ebiten
 
 import (
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
 )
 
@@ -158,9 +161,32 @@ var _ [GraphicsLibraryAuto]int = [0]int{}
 type DebugInfo struct {
 	// GraphicsLibrary represents the graphics library currently in use.
 	GraphicsLibrary GraphicsLibrary
+
+	// DrawCallCount represents the number of draw calls issued to the graphics library in the last frame.
+	DrawCallCount int
+
+	// ImageMemoryInBytes represents the total size of the images allocated in the graphics library in bytes.
+	// The internal atlases are included, and the screen framebuffer is not included.
+	ImageMemoryInBytes int64
+
+	// ImageCount represents the number of images created by NewImage, NewImageWithOptions, NewImageFromImage, or
+	// NewImageFromImageWithOptions and not disposed or garbage-collected yet.
+	// Sub-images are not included.
+	ImageCount int
+
+	// GPUFrameTime represents the time the GPU took to process a frame.
+	// As GPUFrameTime is measured asynchronously, GPUFrameTime is the value of a few frames ago.
+	//
+	// GPUFrameTime is available only with OpenGL (not OpenGL ES) and with WebGL 2 supporting EXT_disjoint_timer_query_webgl2.
+	// Otherwise, GPUFrameTime is 0.
+	GPUFrameTime time.Duration
 }
 
 // ReadDebugInfo writes debug info (e.g. current graphics library) into a provided struct.
 func ReadDebugInfo(d *DebugInfo) {
 	d.GraphicsLibrary = GraphicsLibrary(ui.GetGraphicsLibrary())
+	d.DrawCallCount = graphicscommand.DrawCallCount()
+	d.ImageMemoryInBytes = graphicscommand.ImageMemoryInBytes()
+	d.ImageCount = int(atomic.LoadInt64(&imageCount))
+	d.GPUFrameTime, _ = ui.Get().GPUFrameTime()
 }
@@ -30,6 +30,13 @@ import (
 // Image represents a rectangle set of pixels.
 // The pixel format is alpha-premultiplied RGBA.
 // Image implements the standard image.Image and draw.Image interfaces.
+//
+// An image larger than the device-dependent maximum texture size is split into multiple textures internally,
+// and can be used like other images with some limitations:
+// such an image cannot be used as the second or later source image of a shader,
+// and the source region that a shader gets (e.g. imageSrcRegionOnTexture) is limited to each internal texture.
+// AddressRepeat and AddressMirroredRepeat don't work correctly with such an image either.
+// The fill rule EvenOdd cannot be used with such an image as a source image, and DrawTriangles(Shader) panics in this case.
 type Image struct {
 	// addr holds self to check copying.
 	// See strings.Builder for similar examples.
@@ -44,6 +51,9 @@ type Image struct {
 	// tmpUniforms must not be reused until the vertices are sent to the graphics command queue.
 	tmpUniforms []uint32
 
+	// tracker is non-nil when the image is created by the NewImage functions and not disposed yet.
+	tracker *imageTracker
+
 	// Do not add a 'buffering' member that are resolved lazily.
 	// This tends to forget resolving the buffer easily (#2362).
 }
@@ -311,6 +321,10 @@ const (
 
 	// AddressRepeat means that texture coordinates wrap to the other side of the texture.
 	AddressRepeat Address = Address(builtinshader.AddressRepeat)
+
+	// AddressMirroredRepeat means that texture coordinates wrap to the other side of the texture
+	// and the texture is mirrored every other repetition.
+	AddressMirroredRepeat Address = Address(builtinshader.AddressMirroredRepeat)
 )
 
 // FillRule is the rule whether an overlapped region is rendered with DrawTriangles(Shader).
@@ -322,6 +336,8 @@ const (
 
 	// EvenOdd means that triangles are rendered based on the even-odd rule.
 	// If and only if the number of overlaps is odd, the region is rendered.
+	//
+	// EvenOdd cannot be used with a source image larger than the maximum texture size.
 	EvenOdd
 )
 
@@ -411,6 +427,8 @@ const MaxIndicesNum = graphics.IndicesCount
 //
 // If len(indices) is more than MaxIndicesCount, DrawTriangles panics.
 //
+// If the fill rule is EvenOdd and the source image is larger than the maximum texture size, DrawTriangles panics.
+//
 // The rule in which DrawTriangles works effectively is same as DrawImage's.
 //
 // When the given image is disposed, DrawTriangles panics.
@@ -438,6 +456,10 @@ func (i *Image) DrawTriangles(vertices []Vertex, indices []uint16, img *Image, o
 		options = &DrawTrianglesOptions{}
 	}
 
+	if options.FillRule == EvenOdd && img != nil && img.image.NeedsTiling() {
+		panic("ebiten: the fill rule EvenOdd cannot be used with a source image larger than the maximum texture size")
+	}
+
 	var blend graphicsdriver.Blend
 	if options.CompositeMode == CompositeModeCustom {
 		blend = options.Blend.internalBlend()
@@ -557,6 +579,8 @@ var _ [len(DrawTrianglesShaderOptions{}.Images) - graphics.ShaderImageCount]stru
 //
 // If len(indices) is more than MaxIndicesCount, DrawTrianglesShader panics.
 //
+// If the fill rule is EvenOdd and the source image is larger than the maximum texture size, DrawTrianglesShader panics.
+//
 // When a specified image is non-nil and is disposed, DrawTrianglesShader panics.
 //
 // When the image i is disposed, DrawTrianglesShader does nothing.
@@ -627,6 +651,10 @@ func (i *Image) DrawTrianglesShader(vertices []Vertex, indices []uint16, shader
 		imgs[i] = img.image
 	}
 
+	if options.FillRule == EvenOdd && options.Images[0] != nil && options.Images[0].image.NeedsTiling() {
+		panic("ebiten: the fill rule EvenOdd cannot be used with a source image larger than the maximum texture size")
+	}
+
 	var sx, sy int
 	var sr graphicsdriver.Region
 	if img := options.Images[0]; img != nil {
@@ -932,6 +960,8 @@ func (i *Image) Set(x, y int, clr color.Color) {
 //
 // Calling Dispose is not mandatory. GC automatically collects internal resources that no objects refer to.
 // However, calling Dispose explicitly is helpful if memory usage matters.
+// With the build tag 'ebitenginedebug', an image garbage-collected without Dispose is logged with the stack trace
+// where the image was created.
 //
 // If the image is a sub-image, Dispose does nothing.
 //
@@ -947,6 +977,25 @@ func (i *Image) Dispose() {
 	}
 	i.image.MarkDisposed()
 	i.image = nil
+	i.untrack()
+}
+
+// AtlasID returns the identifier of the internal automatic texture atlas the image is currently on.
+// Images with the same identifier share the same texture.
+// AtlasID returns -1 if the image is not on an atlas, e.g., the image is unmanaged, used as a render target recently,
+// or disposed.
+//
+// An image is moved onto and off an atlas automatically, so the result might change frame by frame.
+// AtlasID is intended to be used for debugging purposes, e.g., to diagnose bleeding edges or unexpected copies.
+//
+// AtlasID works on a sub-image. The result is the same as the original image.
+func (i *Image) AtlasID() int {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return -1
+	}
+	return i.image.AtlasID()
 }
 
 // WritePixels replaces the pixels of the image.
@@ -983,7 +1032,7 @@ func (i *Image) ReplacePixels(pixels []byte) {
 
 // NewImage returns an empty image.
 //
-// If width or height is less than 1 or more than device-dependent maximum size, NewImage panics.
+// If width or height is less than 1, NewImage panics.
 //
 // NewImage should be called only when necessary.
 // For example, you should avoid to call NewImage every Update or Draw call.
@@ -991,7 +1040,9 @@ func (i *Image) ReplacePixels(pixels []byte) {
 //
 // NewImage panics if RunGame already finishes.
 func NewImage(width, height int) *Image {
-	return newImage(image.Rect(0, 0, width, height), atlas.ImageTypeRegular)
+	i := newImage(image.Rect(0, 0, width, height), atlas.ImageTypeRegular)
+	i.track()
+	return i
 }
 
 // NewImageOptions represents options for NewImage.
@@ -1002,12 +1053,32 @@ type NewImageOptions struct {
 	// An unmanaged image is never on an internal automatic texture atlas.
 	// A regular image is a part of an internal texture atlas, and locating them is done automatically in Ebitengine.
 	// Unmanaged is useful when you want finer controls over the image for performance and memory reasons.
+	// For example, an image used as a render target every frame should be unmanaged to avoid copying the image
+	// from and to an atlas.
 	Unmanaged bool
 }
 
+// SetMaxAtlasSize sets the maximum width and height of an internal automatic texture atlas in pixels.
+// size is rounded down to a power of 2, and is limited by the maximum texture size of the device.
+// A regular image larger than the maximum atlas size is never on an atlas.
+//
+// If size is 0, the maximum atlas size is the maximum texture size of the device. This is the default.
+//
+// SetMaxAtlasSize affects only atlases created after the call.
+//
+// If size is negative, SetMaxAtlasSize panics.
+//
+// SetMaxAtlasSize is concurrent-safe.
+func SetMaxAtlasSize(size int) {
+	if size < 0 {
+		panic(fmt.Sprintf("ebiten: size at SetMaxAtlasSize must be non-negative but %d", size))
+	}
+	atlas.SetMaxAtlasSize(size)
+}
+
 // NewImageWithOptions returns an empty image with the given bounds and the options.
 //
-// If width or height is less than 1 or more than device-dependent maximum size, NewImageWithOptions panics.
+// If width or height is less than 1, NewImageWithOptions panics.
 //
 // The rendering origin position is (0, 0) of the given bounds.
 // If DrawImage is called on a new image created by NewImageOptions,
@@ -1025,7 +1096,9 @@ func NewImageWithOptions(bounds image.Rectangle, options *NewImageOptions) *Imag
 	if options != nil && options.Unmanaged {
 		imageType = atlas.ImageTypeUnmanaged
 	}
-	return newImage(bounds, imageType)
+	i := newImage(bounds, imageType)
+	i.track()
+	return i
 }
 
 func newImage(bounds image.Rectangle, imageType atlas.ImageType) *Image {
@@ -1051,7 +1124,7 @@ func newImage(bounds image.Rectangle, imageType atlas.ImageType) *Image {
 
 // NewImageFromImage creates a new image with the given image (source).
 //
-// If source's width or height is less than 1 or more than device-dependent maximum size, NewImageFromImage panics.
+// If source's width or height is less than 1, NewImageFromImage panics.
 //
 // NewImageFromImage should be called only when necessary.
 // For example, you should avoid to call NewImageFromImage every Update or Draw call.
@@ -1081,7 +1154,7 @@ type NewImageFromImageOptions struct {
 
 // NewImageFromImageWithOptions creates a new image with the given image (source) with the given options.
 //
-// If source's width or height is less than 1 or more than device-dependent maximum size, NewImageFromImageWithOptions panics.
+// If source's width or height is less than 1, NewImageFromImageWithOptions panics.
 //
 // If options is nil, the default setting is used.
 //
@@ -340,6 +340,33 @@ func TestImageDispose(t *testing.T) {
 	}
 }
 
+func TestImageCount(t *testing.T) {
+	var info ebiten.DebugInfo
+	ebiten.ReadDebugInfo(&info)
+	n := info.ImageCount
+
+	img := ebiten.NewImage(16, 16)
+	ebiten.ReadDebugInfo(&info)
+	if got, want := info.ImageCount, n+1; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	// A sub-image is not counted.
+	sub := img.SubImage(image.Rect(0, 0, 8, 8)).(*ebiten.Image)
+	sub.Dispose()
+	ebiten.ReadDebugInfo(&info)
+	if got, want := info.ImageCount, n+1; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	img.Dispose()
+	img.Dispose()
+	ebiten.ReadDebugInfo(&info)
+	if got, want := info.ImageCount, n; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -1418,6 +1445,94 @@ func TestImageAddressRepeatNegativePosition(t *testing.T) {
 	}
 }
 
+func TestImageAddressMirroredRepeat(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	dst := ebiten.NewImage(w, h)
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (i + j*w)
+			if 4 <= i && i < 8 && 4 <= j && j < 8 {
+				pix[idx] = byte(i-4) * 0x10
+				pix[idx+1] = byte(j-4) * 0x10
+				pix[idx+2] = 0
+				pix[idx+3] = 0xff
+			} else {
+				pix[idx] = 0
+				pix[idx+1] = 0
+				pix[idx+2] = 0xff
+				pix[idx+3] = 0xff
+			}
+		}
+	}
+	src.WritePixels(pix)
+
+	vs := []ebiten.Vertex{
+		{
+			DstX:   0,
+			DstY:   0,
+			SrcX:   0,
+			SrcY:   0,
+			ColorR: 1,
+			ColorG: 1,
+			ColorB: 1,
+			ColorA: 1,
+		},
+		{
+			DstX:   w,
+			DstY:   0,
+			SrcX:   w,
+			SrcY:   0,
+			ColorR: 1,
+			ColorG: 1,
+			ColorB: 1,
+			ColorA: 1,
+		},
+		{
+			DstX:   0,
+			DstY:   h,
+			SrcX:   0,
+			SrcY:   h,
+			ColorR: 1,
+			ColorG: 1,
+			ColorB: 1,
+			ColorA: 1,
+		},
+		{
+			DstX:   w,
+			DstY:   h,
+			SrcX:   w,
+			SrcY:   h,
+			ColorR: 1,
+			ColorG: 1,
+			ColorB: 1,
+			ColorA: 1,
+		},
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+	op := &ebiten.DrawTrianglesOptions{}
+	op.Address = ebiten.AddressMirroredRepeat
+	dst.DrawTriangles(vs, is, src.SubImage(image.Rect(4, 4, 8, 8)).(*ebiten.Image), op)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			x, y := i%8, j%8
+			if x >= 4 {
+				x = 7 - x
+			}
+			if y >= 4 {
+				y = 7 - y
+			}
+			want := color.RGBA{R: byte(x) * 0x10, G: byte(y) * 0x10, A: 0xff}
+			if !sameColors(got, want, 1) {
+				t.Errorf("dst.At(%d, %d): got %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
 func TestImageWritePixelsAfterClear(t *testing.T) {
 	const w, h = 256, 256
 	img := ebiten.NewImage(w, h)
@@ -3577,6 +3692,18 @@ func TestImageBlendOperation(t *testing.T) {
 		}
 		return byte(x)
 	}
+	minByte := func(x, y byte) byte {
+		if x < y {
+			return x
+		}
+		return y
+	}
+	maxByte := func(x, y byte) byte {
+		if x > y {
+			return x
+		}
+		return y
+	}
 
 	dstPix := make([]byte, 4*w*h)
 	for i := 0; i < w; i++ {
@@ -3600,6 +3727,8 @@ func TestImageBlendOperation(t *testing.T) {
 		ebiten.BlendOperationAdd,
 		ebiten.BlendOperationSubtract,
 		ebiten.BlendOperationReverseSubtract,
+		ebiten.BlendOperationMin,
+		ebiten.BlendOperationMax,
 	}
 	for _, rgbOp := range operations {
 		for _, alphaOp := range operations {
@@ -3635,6 +3764,14 @@ func TestImageBlendOperation(t *testing.T) {
 					want.R = clamp(int(dr) - int(sr))
 					want.G = clamp(int(dg) - int(sg))
 					want.B = clamp(int(db) - int(sb))
+				case ebiten.BlendOperationMin:
+					want.R = minByte(sr, dr)
+					want.G = minByte(sg, dg)
+					want.B = minByte(sb, db)
+				case ebiten.BlendOperationMax:
+					want.R = maxByte(sr, dr)
+					want.G = maxByte(sg, dg)
+					want.B = maxByte(sb, db)
 				}
 				switch alphaOp {
 				case ebiten.BlendOperationAdd:
@@ -3643,6 +3780,10 @@ func TestImageBlendOperation(t *testing.T) {
 					want.A = clamp(int(sa) - int(da))
 				case ebiten.BlendOperationReverseSubtract:
 					want.A = clamp(int(da) - int(sa))
+				case ebiten.BlendOperationMin:
+					want.A = minByte(sa, da)
+				case ebiten.BlendOperationMax:
+					want.A = maxByte(sa, da)
 				}
 
 				if !sameColors(got, want, 1) {
@@ -0,0 +1,87 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/debug"
+)
+
+// imageCount is the number of images created by the NewImage functions and not disposed yet.
+var imageCount int64
+
+// imageTracker tracks an image created by the NewImage functions to count the alive images
+// and to report an image garbage-collected without Dispose.
+type imageTracker struct {
+	// stack is the stack trace where the image is created.
+	// stack is recorded only with the build tag 'ebitenginedebug'.
+	stack string
+}
+
+// track starts tracking the image.
+// track must be called only for an image created by the NewImage functions.
+func (i *Image) track() {
+	atomic.AddInt64(&imageCount, 1)
+
+	i.tracker = &imageTracker{}
+	if debug.IsDebug {
+		i.tracker.stack = callerStack()
+	}
+	runtime.SetFinalizer(i, (*Image).finalize)
+}
+
+// untrack stops tracking the image when the image is disposed.
+func (i *Image) untrack() {
+	if i.tracker == nil {
+		return
+	}
+	i.tracker = nil
+	atomic.AddInt64(&imageCount, -1)
+	runtime.SetFinalizer(i, nil)
+}
+
+// finalize is called when the image is garbage-collected without Dispose.
+// The internal resources are released by the internal packages' finalizers.
+func (i *Image) finalize() {
+	if i.tracker == nil {
+		return
+	}
+	if debug.IsDebug {
+		debug.Logf("ebiten: an image (%d, %d) was garbage-collected without Dispose. The image was created at:\n%s", i.bounds.Dx(), i.bounds.Dy(), i.tracker.stack)
+	}
+	i.tracker = nil
+	atomic.AddInt64(&imageCount, -1)
+}
+
+func callerStack() string {
+	pcs := make([]uintptr, 32)
+	// Skip runtime.Callers, callerStack, and (*Image).track.
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var sb strings.Builder
+	for {
+		f, more := frames.Next()
+		fmt.Fprintf(&sb, "  %s\n    %s:%d\n", f.Function, f.File, f.Line)
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
@@ -390,6 +390,83 @@ func TouchPosition(id TouchID) (int, int) {
 	return theInputState.touchPosition(id)
 }
 
+// DeviceMotion represents the state of a device's motion sensors.
+//
+// The axes are relative to the device in its natural orientation.
+// X is toward the right, Y is toward the top, and Z is toward the front of the screen.
+type DeviceMotion struct {
+	// AccelerationX, AccelerationY and AccelerationZ are the acceleration in m/s^2, including the gravity.
+	// For example, AccelerationZ is about 9.8 when the device lies face up.
+	//
+	// iOS reports the acceleration in G with the opposite sign, and the values are converted to match the other platforms.
+	AccelerationX float64
+	AccelerationY float64
+	AccelerationZ float64
+
+	// RotationRateX, RotationRateY and RotationRateZ are the rate of rotation around each axis in rad/s.
+	//
+	// Browsers report the rotation rates in deg/s, and the values are converted to match the other platforms.
+	RotationRateX float64
+	RotationRateY float64
+	RotationRateZ float64
+}
+
+// ReadDeviceMotion writes the current state of the device's motion sensors to motion,
+// and reports whether the sensors are available.
+// If the sensors are not available, motion is not modified.
+//
+// The sensors are available on mobiles and browsers on mobiles.
+// On iOS Safari, DeviceMotionEvent.requestPermission must be called by a user gesture in JavaScript to receive
+// the sensors' values.
+//
+// ReadDeviceMotion is concurrent-safe.
+func ReadDeviceMotion(motion *DeviceMotion) bool {
+	return theInputState.readDeviceMotion(motion)
+}
+
+// PenState represents the state of a pen (stylus).
+//
+// PenState is available only on browsers. See ReadPen.
+type PenState struct {
+	// X and Y are the pen's position in the logical screen coordinate.
+	X float64
+	Y float64
+
+	// Pressure is the pen's pressure in [0, 1]. Pressure is 0 when the pen doesn't touch the screen.
+	Pressure float64
+
+	// TiltX and TiltY are the pen's tilts in radians, in [-pi/2, pi/2].
+	// TiltX is the angle between the Y-Z plane and the plane containing the pen and the Y axis.
+	// TiltY is the angle between the X-Z plane and the plane containing the pen and the X axis.
+	TiltX float64
+	TiltY float64
+
+	// Touching reports whether the pen touches the screen.
+	Touching bool
+
+	// BarrelButtonPressed reports whether the pen's barrel button is pressed.
+	BarrelButtonPressed bool
+
+	// Eraser reports whether the pen's eraser touches the screen.
+	Eraser bool
+}
+
+// ReadPen writes the current state of a pen to pen, and reports whether a pen is available.
+// A pen is treated as available after the pen is used once.
+// If a pen is not available, pen is not modified.
+//
+// Pens are available only on browsers supporting Pointer Events.
+// On desktops and mobiles, pens are not implemented and ReadPen always returns false.
+// There, a pen works only as the mouse or a touch, and its pressure and tilts are not available.
+//
+// On browsers, a pen also works as the left mouse button and the cursor, so games not supporting pens still work with
+// pens.
+//
+// ReadPen is concurrent-safe.
+func ReadPen(pen *PenState) bool {
+	return theInputState.readPen(pen)
+}
+
 var theInputState inputState
 
 type inputState struct {
@@ -472,6 +549,42 @@ func (i *inputState) touchPosition(id TouchID) (int, int) {
 	return 0, 0
 }
 
+func (i *inputState) readDeviceMotion(motion *DeviceMotion) bool {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if !i.state.DeviceMotionAvailable {
+		return false
+	}
+	m := i.state.DeviceMotion
+	motion.AccelerationX = m.AccelerationX
+	motion.AccelerationY = m.AccelerationY
+	motion.AccelerationZ = m.AccelerationZ
+	motion.RotationRateX = m.RotationRateX
+	motion.RotationRateY = m.RotationRateY
+	motion.RotationRateZ = m.RotationRateZ
+	return true
+}
+
+func (i *inputState) readPen(pen *PenState) bool {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if !i.state.PenAvailable {
+		return false
+	}
+	p := i.state.Pen
+	pen.X = p.X
+	pen.Y = p.Y
+	pen.Pressure = p.Pressure
+	pen.TiltX = p.TiltX
+	pen.TiltY = p.TiltY
+	pen.Touching = p.Touching
+	pen.BarrelButtonPressed = p.BarrelButtonPressed
+	pen.Eraser = p.Eraser
+	return true
+}
+
 func (i *inputState) windowBeingClosed() bool {
 	i.m.Lock()
 	defer i.m.Unlock()
@@ -62,3 +62,25 @@ func (i *Image) EnsureIsolatedForTesting() {
 var FlushDeferredForTesting = flushDeferred
 
 var FloorPowerOf2 = floorPowerOf2
+
+func ClipTrianglesForTesting(vertices []float32, indices []uint16, x0, y0, x1, y1, ox, oy float32) ([]float32, []uint16) {
+	var vs []float32
+	var is []uint16
+	c := triangleClipper{
+		x0: x0,
+		y0: y0,
+		x1: x1,
+		y1: y1,
+		ox: ox,
+		oy: oy,
+		flush: func(vertices []float32, indices []uint16) {
+			vs = append(vs, vertices...)
+			is = append(is, indices...)
+		},
+	}
+	for i := 0; i < len(indices); i += 3 {
+		c.appendTriangle(vertices, indices[i], indices[i+1], indices[i+2])
+	}
+	c.flushAll()
+	return vs, is
+}
@@ -19,6 +19,7 @@ import (
 	"image"
 	"runtime"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
@@ -29,6 +30,10 @@ import (
 var (
 	minSize = 0
 	maxSize = 0
+
+	// maxAtlasSizeByUser is the maximum size of an atlas specified by SetMaxAtlasSize.
+	// 0 means that the size is not limited by the user.
+	maxAtlasSizeByUser int32
 )
 
 type temporaryBytes struct {
@@ -112,6 +117,10 @@ const baseCountToPutOnAtlas = 10
 
 func putImagesOnAtlas(graphicsDriver graphicsdriver.Graphics) error {
 	for i := range imagesToPutOnAtlas {
+		// The maximum atlas size might be changed after the image is registered.
+		if !i.canBePutOnAtlas() {
+			continue
+		}
 		i.usedAsSourceCount++
 		if i.usedAsSourceCount >= baseCountToPutOnAtlas*(1<<uint(min(i.isolatedCount, 31))) {
 			if err := i.putOnAtlas(graphicsDriver); err != nil {
@@ -136,6 +145,9 @@ type backend struct {
 	// page is an atlas map. Each part is called a node.
 	// If page is nil, the backend's image is isolated and not on an atlas.
 	page *packing.Page
+
+	// id is an identifier of the atlas. id is valid only when page is not nil.
+	id int
 }
 
 func (b *backend) tryAlloc(width, height int) (*packing.Node, bool) {
@@ -161,6 +173,8 @@ var (
 
 	imagesToPutOnAtlas = map[*Image]struct{}{}
 
+	nextBackendID int
+
 	deferred []func()
 
 	// deferredM is a mutex for the slice operations. This must not be used for other usages.
@@ -213,6 +227,10 @@ type Image struct {
 	// isolatedCount represents how many times the image on a texture atlas is changed into an isolated image.
 	// isolatedCount affects the calculation when to put the image onto a texture atlas again.
 	isolatedCount int
+
+	// tiles is non-nil when the image is larger than the maximum texture size.
+	// Such an image doesn't have its own backend, and consists of the tiles.
+	tiles []*tile
 }
 
 // moveTo moves its content to the given image dst.
@@ -387,6 +405,16 @@ func (i *Image) drawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices [
 	if i.disposed {
 		panic("atlas: the drawing target image must not be disposed (DrawTriangles)")
 	}
+
+	checkTiledSecondarySources(srcs)
+	if i.needsTiling() {
+		i.drawTrianglesToTiles(srcs, vertices, indices, blend, dstRegion, srcRegion, subimageOffsets, shader, uniforms, evenOdd)
+		return
+	}
+	if srcs[0] != nil && srcs[0].needsTiling() {
+		i.drawTrianglesFromTiledSource(srcs, vertices, indices, blend, dstRegion, srcRegion, subimageOffsets, shader, uniforms, evenOdd, keepOnAtlas)
+		return
+	}
 	if keepOnAtlas {
 		if i.backend == nil {
 			i.allocate(true)
@@ -483,6 +511,11 @@ func (i *Image) writePixels(pix []byte, x, y, width, height int) {
 		panic(fmt.Sprintf("atlas: len(p) must be %d but %d", l, len(pix)))
 	}
 
+	if i.needsTiling() {
+		i.writePixelsToTiles(pix, x, y, width, height)
+		return
+	}
+
 	i.resetUsedAsSourceCount()
 
 	if i.backend == nil {
@@ -553,6 +586,14 @@ func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte
 	// To prevent memory leaks, flush the deferred functions here.
 	flushDeferred()
 
+	return i.readPixels(graphicsDriver, pixels)
+}
+
+func (i *Image) readPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte) error {
+	if i.needsTiling() {
+		return i.readPixelsFromTiles(graphicsDriver, pixels)
+	}
+
 	if i.backend == nil || i.backend.restorable == nil {
 		for i := range pixels {
 			pixels[i] = 0
@@ -597,6 +638,14 @@ func (i *Image) dispose(markDisposed bool) {
 		return
 	}
 
+	if i.tiles != nil {
+		for _, t := range i.tiles {
+			t.img.dispose(true)
+		}
+		i.tiles = nil
+		return
+	}
+
 	if i.backend == nil {
 		// Not allocated yet.
 		return
@@ -644,7 +693,51 @@ func (i *Image) canBePutOnAtlas() bool {
 	if i.imageType != ImageTypeRegular {
 		return false
 	}
-	return i.width+2*i.paddingSize() <= maxSize && i.height+2*i.paddingSize() <= maxSize
+	s := maxAtlasSize()
+	return i.width+2*i.paddingSize() <= s && i.height+2*i.paddingSize() <= s
+}
+
+// maxAtlasSize returns the maximum size of an atlas.
+func maxAtlasSize() int {
+	s := maxSize
+	if u := int(atomic.LoadInt32(&maxAtlasSizeByUser)); u > 0 {
+		s = min(s, floorPowerOf2(u))
+	}
+	return s
+}
+
+// SetMaxAtlasSize sets the maximum size of an atlas.
+// size is rounded down to a power of 2. If size is 0, the size is determined by the graphics driver.
+//
+// SetMaxAtlasSize affects only atlases created after this call.
+//
+// SetMaxAtlasSize is concurrent-safe.
+func SetMaxAtlasSize(size int) {
+	atomic.StoreInt32(&maxAtlasSizeByUser, int32(size))
+}
+
+// AtlasID returns the identifier of the atlas the image is on.
+// AtlasID returns -1 if the image is not on an atlas.
+func (i *Image) AtlasID() int {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !i.isOnAtlas() {
+		return -1
+	}
+	return i.backend.id
+}
+
+// NeedsTiling reports whether the image is too big to be on one texture.
+// NeedsTiling returns false when the maximum texture size is not determined yet.
+func (i *Image) NeedsTiling() bool {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if maxSize == 0 {
+		return false
+	}
+	return i.needsTiling()
 }
 
 func (i *Image) allocate(putOnAtlas bool) {
@@ -684,9 +777,10 @@ func (i *Image) allocate(putOnAtlas bool) {
 			return
 		}
 	}
-	size := minSize
+	atlasSize := maxAtlasSize()
+	size := min(minSize, atlasSize)
 	for i.width+2*i.paddingSize() > size || i.height+2*i.paddingSize() > size {
-		if size == maxSize {
+		if size == atlasSize {
 			panic(fmt.Sprintf("atlas: the image being put on an atlas is too big: width: %d, height: %d", i.width, i.height))
 		}
 		size *= 2
@@ -698,8 +792,10 @@ func (i *Image) allocate(putOnAtlas bool) {
 	}
 	b := &backend{
 		restorable: restorable.NewImage(size, size, typ),
-		page:       packing.NewPage(size, maxSize),
+		page:       packing.NewPage(size, atlasSize),
+		id:         nextBackendID,
 	}
+	nextBackendID++
 	theBackends = append(theBackends, b)
 
 	n := b.page.Alloc(i.width+2*i.paddingSize(), i.height+2*i.paddingSize())
@@ -714,6 +810,10 @@ func (i *Image) DumpScreenshot(graphicsDriver graphicsdriver.Graphics, path stri
 	backendsM.Lock()
 	defer backendsM.Unlock()
 
+	if i.needsTiling() {
+		return "", fmt.Errorf("atlas: an image larger than the maximum texture size cannot be dumped: width: %d, height: %d", i.width, i.height)
+	}
+
 	return i.backend.restorable.Dump(graphicsDriver, path, blackbg, image.Rect(i.paddingSize(), i.paddingSize(), i.width+i.paddingSize(), i.height+i.paddingSize()))
 }
 
@@ -15,6 +15,7 @@
 package atlas_test
 
 import (
+	"bytes"
 	"image/color"
 	"runtime"
 	"testing"
@@ -570,18 +571,137 @@ func TestMaxImageSizeJust(t *testing.T) {
 }
 
 func TestMaxImageSizeExceeded(t *testing.T) {
-	// This tests that a too-big image is allocated correctly.
+	// This tests that a too-big image is split into tiles and doesn't panic.
 	s := maxImageSizeForTesting
-	img := atlas.NewImage(s+1, s, atlas.ImageTypeRegular)
+	w, h := s+1, 16
+	img := atlas.NewImage(w, h, atlas.ImageTypeRegular)
 	defer img.MarkDisposed()
 
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (j*w + i)
+			pix[idx] = byte(i)
+			pix[idx+1] = byte(i >> 8)
+			pix[idx+2] = byte(j)
+			pix[idx+3] = 0xff
+		}
+	}
+	img.WritePixels(pix, 0, 0, w, h)
+
+	// Overwrite a region across the tiles.
+	const x, y, rw, rh = 4085, 2, 10, 4
+	pix2 := make([]byte, 4*rw*rh)
+	for i := range pix2 {
+		pix2[i] = 0xff
+	}
+	img.WritePixels(pix2, x, y, rw, rh)
+	for j := y; j < y+rh; j++ {
+		for i := x; i < x+rw; i++ {
+			idx := 4 * (j*w + i)
+			pix[idx] = 0xff
+			pix[idx+1] = 0xff
+			pix[idx+2] = 0xff
+			pix[idx+3] = 0xff
+		}
+	}
+
+	got := make([]byte, 4*w*h)
+	if err := img.ReadPixels(ui.GraphicsDriverForTesting(), got); err != nil {
+		t.Fatal(err)
+	}
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (j*w + i)
+			if !bytes.Equal(got[idx:idx+4], pix[idx:idx+4]) {
+				t.Fatalf("at(%d, %d): got: %v, want: %v", i, j, got[idx:idx+4], pix[idx:idx+4])
+			}
+		}
+	}
+}
+
+func TestTiledImageDrawTriangles(t *testing.T) {
+	s := maxImageSizeForTesting
+	w, h := 2*s, 4
+	src := atlas.NewImage(w, h, atlas.ImageTypeRegular)
+	defer src.MarkDisposed()
+	dst := atlas.NewImage(w, h, atlas.ImageTypeRegular)
+	defer dst.MarkDisposed()
+
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (j*w + i)
+			pix[idx] = byte(i)
+			pix[idx+1] = byte(i >> 8)
+			pix[idx+2] = byte(j)
+			pix[idx+3] = 0xff
+		}
+	}
+	src.WritePixels(pix, 0, 0, w, h)
+
+	vs := quadVertices(w, h, 0, 0, 1)
+	is := graphics.QuadIndices()
+	dr := graphicsdriver.Region{
+		X:      0,
+		Y:      0,
+		Width:  float32(w),
+		Height: float32(h),
+	}
+	dst.DrawTriangles([graphics.ShaderImageCount]*atlas.Image{src}, vs, is, graphicsdriver.BlendCopy, dr, graphicsdriver.Region{}, [graphics.ShaderImageCount - 1][2]float32{}, atlas.NearestFilterShader, nil, false)
+
+	got := make([]byte, 4*w*h)
+	if err := dst.ReadPixels(ui.GraphicsDriverForTesting(), got); err != nil {
+		t.Fatal(err)
+	}
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (j*w + i)
+			if !bytes.Equal(got[idx:idx+4], pix[idx:idx+4]) {
+				t.Fatalf("at(%d, %d): got: %v, want: %v", i, j, got[idx:idx+4], pix[idx:idx+4])
+			}
+		}
+	}
+}
+
+func TestTiledImageDrawTrianglesEvenOdd(t *testing.T) {
+	s := maxImageSizeForTesting
+	w, h := 2*s, 4
+	src := atlas.NewImage(w, h, atlas.ImageTypeRegular)
+	defer src.MarkDisposed()
+	dst := atlas.NewImage(w, h, atlas.ImageTypeRegular)
+	defer dst.MarkDisposed()
+
 	defer func() {
-		if err := recover(); err == nil {
-			t.Errorf("WritePixels must panic but not")
+		if e := recover(); e == nil {
+			t.Errorf("DrawTriangles with the even-odd rule and a tiled source must panic")
 		}
 	}()
 
-	img.WritePixels(make([]byte, 4*(s+1)*s), 0, 0, s+1, s)
+	vs := quadVertices(w, h, 0, 0, 1)
+	is := graphics.QuadIndices()
+	dr := graphicsdriver.Region{
+		X:      0,
+		Y:      0,
+		Width:  float32(w),
+		Height: float32(h),
+	}
+	dst.DrawTriangles([graphics.ShaderImageCount]*atlas.Image{src}, vs, is, graphicsdriver.BlendCopy, dr, graphicsdriver.Region{}, [graphics.ShaderImageCount - 1][2]float32{}, atlas.NearestFilterShader, nil, true)
+}
+
+func TestNeedsTiling(t *testing.T) {
+	s := maxImageSizeForTesting
+	big := atlas.NewImage(2*s, 4, atlas.ImageTypeRegular)
+	defer big.MarkDisposed()
+	small := atlas.NewImage(16, 16, atlas.ImageTypeRegular)
+	defer small.MarkDisposed()
+
+	if !big.NeedsTiling() {
+		t.Errorf("NeedsTiling() for a %dx%d image must be true", 2*s, 4)
+	}
+	if small.NeedsTiling() {
+		t.Errorf("NeedsTiling() for a 16x16 image must be false")
+	}
 }
 
 // Issue #1421
@@ -785,3 +905,50 @@ func TestPowerOf2(t *testing.T) {
 }
 
 // TODO: Add tests to extend image on an atlas out of the main loop
+
+func TestMaxAtlasSize(t *testing.T) {
+	atlas.SetMaxAtlasSize(512)
+	defer atlas.SetMaxAtlasSize(0)
+
+	const size = 16
+	src0 := atlas.NewImage(size, size, atlas.ImageTypeRegular)
+	defer src0.MarkDisposed()
+	src1 := atlas.NewImage(size, size, atlas.ImageTypeRegular)
+	defer src1.MarkDisposed()
+	src2 := atlas.NewImage(600, 600, atlas.ImageTypeRegular)
+	defer src2.MarkDisposed()
+	dst := atlas.NewImage(size, size, atlas.ImageTypeRegular)
+	defer dst.MarkDisposed()
+
+	vs := quadVertices(size, size, 0, 0, 1)
+	is := graphics.QuadIndices()
+	dr := graphicsdriver.Region{
+		X:      0,
+		Y:      0,
+		Width:  size,
+		Height: size,
+	}
+	for _, src := range []*atlas.Image{src0, src1, src2} {
+		dst.DrawTriangles([graphics.ShaderImageCount]*atlas.Image{src}, vs, is, graphicsdriver.BlendCopy, dr, graphicsdriver.Region{}, [graphics.ShaderImageCount - 1][2]float32{}, atlas.NearestFilterShader, nil, false)
+	}
+
+	if got := src0.AtlasID(); got < 0 {
+		t.Errorf("src0.AtlasID(): got: %d, want: non-negative", got)
+	}
+	if got, want := src1.AtlasID(), src0.AtlasID(); got != want {
+		t.Errorf("src1.AtlasID(): got: %d, want: %d", got, want)
+	}
+
+	// src2 is bigger than the maximum atlas size.
+	if got, want := src2.AtlasID(), -1; got != want {
+		t.Errorf("src2.AtlasID(): got: %d, want: %d", got, want)
+	}
+	if got, want := src2.IsOnAtlasForTesting(), false; got != want {
+		t.Errorf("src2.IsOnAtlasForTesting(): got: %v, want: %v", got, want)
+	}
+
+	// dst is used as a render target and is not on an atlas.
+	if got, want := dst.AtlasID(), -1; got != want {
+		t.Errorf("dst.AtlasID(): got: %d, want: %d", got, want)
+	}
+}
@@ -0,0 +1,392 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atlas
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+)
+
+// tileMargin is the size of the overlapping region between adjacent tiles.
+// Thanks to the margin, linear filtering around the tile borders can refer to the neighboring pixels.
+const tileMargin = 1
+
+// tile is a part of an image larger than the maximum texture size.
+type tile struct {
+	img *Image
+
+	// bounds is the region of the tile in the original image including the margins.
+	bounds image.Rectangle
+
+	// clipX0, clipY0, clipX1 and clipY1 represents the region where the tile is responsible as a rendering source.
+	// The regions of the tiles don't overlap with each other.
+	// The region is unbounded at the image edges so that all the triangles belong to some tiles.
+	clipX0, clipY0, clipX1, clipY1 float32
+}
+
+// needsTiling reports whether the image is too big to be on one texture.
+func (i *Image) needsTiling() bool {
+	if i.imageType == ImageTypeScreen {
+		return false
+	}
+	return i.width+2*i.paddingSize() > maxSize || i.height+2*i.paddingSize() > maxSize
+}
+
+// ensureTiles creates the tiles if needed.
+func (i *Image) ensureTiles() {
+	if i.tiles != nil {
+		return
+	}
+
+	// A tile is always isolated from an atlas. Otherwise, copying a huge tile onto an atlas would happen.
+	typ := ImageTypeUnmanaged
+	if i.imageType == ImageTypeVolatile {
+		typ = ImageTypeVolatile
+	}
+
+	stride := maxSize - 2*tileMargin
+	for y := 0; y < i.height; y += stride {
+		for x := 0; x < i.width; x += stride {
+			b := image.Rect(x-tileMargin, y-tileMargin, x+stride+tileMargin, y+stride+tileMargin).Intersect(image.Rect(0, 0, i.width, i.height))
+			t := &tile{
+				img:    NewImage(b.Dx(), b.Dy(), typ),
+				bounds: b,
+				clipX0: float32(x),
+				clipY0: float32(y),
+				clipX1: float32(x + stride),
+				clipY1: float32(y + stride),
+			}
+			if x == 0 {
+				t.clipX0 = -math.MaxFloat32
+			}
+			if y == 0 {
+				t.clipY0 = -math.MaxFloat32
+			}
+			if x+stride >= i.width {
+				t.clipX1 = math.MaxFloat32
+			}
+			if y+stride >= i.height {
+				t.clipY1 = math.MaxFloat32
+			}
+			i.tiles = append(i.tiles, t)
+		}
+	}
+}
+
+// tileVertices is a scratch buffer to translate vertices for each tile.
+// The vertices are copied by the callee, so the buffer can be reused.
+// tileVertices is shared by all the images, and is safe to use only while backendsM is locked.
+var tileVertices []float32
+
+// drawTrianglesToTiles draws triangles onto the tiles of the image.
+func (i *Image) drawTrianglesToTiles(srcs [graphics.ShaderImageCount]*Image, vertices []float32, indices []uint16, blend graphicsdriver.Blend, dstRegion, srcRegion graphicsdriver.Region, subimageOffsets [graphics.ShaderImageCount - 1][2]float32, shader *Shader, uniforms []uint32, evenOdd bool) {
+	i.ensureTiles()
+
+	for _, t := range i.tiles {
+		r := graphicsdriver.Region{
+			X:      dstRegion.X - float32(t.bounds.Min.X),
+			Y:      dstRegion.Y - float32(t.bounds.Min.Y),
+			Width:  dstRegion.Width,
+			Height: dstRegion.Height,
+		}
+		r = intersectRegion(r, graphicsdriver.Region{
+			Width:  float32(t.bounds.Dx()),
+			Height: float32(t.bounds.Dy()),
+		})
+		if r.Width <= 0 || r.Height <= 0 {
+			continue
+		}
+
+		// drawTriangles modifies the vertices. Copy them for each tile.
+		if cap(tileVertices) < len(vertices) {
+			tileVertices = make([]float32, len(vertices))
+		}
+		vs := tileVertices[:len(vertices)]
+		copy(vs, vertices)
+		for j := 0; j < len(vs); j += graphics.VertexFloatCount {
+			vs[j] -= float32(t.bounds.Min.X)
+			vs[j+1] -= float32(t.bounds.Min.Y)
+		}
+		t.img.drawTriangles(srcs, vs, indices, blend, r, srcRegion, subimageOffsets, shader, uniforms, evenOdd, false)
+	}
+}
+
+// drawTrianglesFromTiledSource draws triangles with the tiled source srcs[0].
+// The triangles are clipped by each tile's region, and drawn with the tile as the source.
+//
+// The even-odd rule is not supported, since the triangles are drawn with one draw call per tile
+// and the overlaps across the tiles cannot be counted.
+func (i *Image) drawTrianglesFromTiledSource(srcs [graphics.ShaderImageCount]*Image, vertices []float32, indices []uint16, blend graphicsdriver.Blend, dstRegion, srcRegion graphicsdriver.Region, subimageOffsets [graphics.ShaderImageCount - 1][2]float32, shader *Shader, uniforms []uint32, evenOdd bool, keepOnAtlas bool) {
+	src := srcs[0]
+	if src.disposed {
+		panic("atlas: the drawing source image must not be disposed (DrawTriangles)")
+	}
+	if evenOdd {
+		panic(fmt.Sprintf("atlas: the even-odd rule cannot be used with a source image larger than the maximum texture size: width: %d, height: %d", src.width, src.height))
+	}
+	src.ensureTiles()
+
+	for _, t := range src.tiles {
+		ox, oy := float32(t.bounds.Min.X), float32(t.bounds.Min.Y)
+
+		// The source region must be limited to the tile, or the pixels outside of the tile would be read.
+		// An empty source region means no limitation.
+		tr := graphicsdriver.Region{
+			Width:  float32(t.bounds.Dx()),
+			Height: float32(t.bounds.Dy()),
+		}
+		sr := tr
+		if srcRegion.Width != 0 && srcRegion.Height != 0 {
+			sr = intersectRegion(graphicsdriver.Region{
+				X:      srcRegion.X - ox,
+				Y:      srcRegion.Y - oy,
+				Width:  srcRegion.Width,
+				Height: srcRegion.Height,
+			}, tr)
+			if sr.Width <= 0 || sr.Height <= 0 {
+				continue
+			}
+		}
+
+		var offsets [graphics.ShaderImageCount - 1][2]float32
+		for j := range subimageOffsets {
+			offsets[j][0] = subimageOffsets[j][0] + ox
+			offsets[j][1] = subimageOffsets[j][1] + oy
+		}
+
+		tileSrcs := srcs
+		tileSrcs[0] = t.img
+
+		var c triangleClipper
+		c.x0, c.y0, c.x1, c.y1 = t.clipX0, t.clipY0, t.clipX1, t.clipY1
+		c.ox, c.oy = ox, oy
+		c.flush = func(vs []float32, is []uint16) {
+			i.drawTriangles(tileSrcs, vs, is, blend, dstRegion, sr, offsets, shader, uniforms, evenOdd, keepOnAtlas)
+		}
+		for j := 0; j < len(indices); j += 3 {
+			c.appendTriangle(vertices, indices[j], indices[j+1], indices[j+2])
+		}
+		c.flushAll()
+	}
+}
+
+// triangleClipper clips triangles by a rectangle in the source coordinates,
+// and translates the source coordinates by (-ox, -oy).
+type triangleClipper struct {
+	x0, y0, x1, y1 float32
+	ox, oy         float32
+	flush          func(vertices []float32, indices []uint16)
+
+	vertices []float32
+	indices  []uint16
+
+	poly [2][7 * graphics.VertexFloatCount]float32
+}
+
+const maxVertexCount = 1 << 16
+
+// reserve flushes the triangles if the given numbers of vertices and indices cannot be added.
+func (c *triangleClipper) reserve(vertexCount, indexCount int) {
+	if len(c.vertices)/graphics.VertexFloatCount+vertexCount <= maxVertexCount && len(c.indices)+indexCount <= graphics.IndicesCount {
+		return
+	}
+	c.flushAll()
+}
+
+func (c *triangleClipper) flushAll() {
+	if len(c.indices) == 0 {
+		return
+	}
+	c.flush(c.vertices, c.indices)
+	// The vertices and the indices are copied by the callee, so the buffers can be reused.
+	c.vertices = c.vertices[:0]
+	c.indices = c.indices[:0]
+}
+
+func (c *triangleClipper) appendTriangle(vertices []float32, i0, i1, i2 uint16) {
+	const n = graphics.VertexFloatCount
+
+	v0 := vertices[n*int(i0) : n*int(i0)+n]
+	v1 := vertices[n*int(i1) : n*int(i1)+n]
+	v2 := vertices[n*int(i2) : n*int(i2)+n]
+
+	minX := min32(v0[2], min32(v1[2], v2[2]))
+	maxX := max32(v0[2], max32(v1[2], v2[2]))
+	minY := min32(v0[3], min32(v1[3], v2[3]))
+	maxY := max32(v0[3], max32(v1[3], v2[3]))
+
+	// The triangle belongs to another tile.
+	if maxX < c.x0 || c.x1 <= minX || maxY < c.y0 || c.y1 <= minY {
+		return
+	}
+	// A degenerate triangle on the tile border belongs to the both tiles, which is harmless.
+
+	var count int
+	if c.x0 <= minX && maxX <= c.x1 && c.y0 <= minY && maxY <= c.y1 {
+		// The triangle is entirely in the tile.
+		copy(c.poly[0][0:], v0)
+		copy(c.poly[0][n:], v1)
+		copy(c.poly[0][2*n:], v2)
+		count = 3
+	} else {
+		count = c.clip(v0, v1, v2)
+		if count < 3 {
+			return
+		}
+	}
+
+	c.reserve(count, 3*(count-2))
+
+	base := uint16(len(c.vertices) / n)
+	for j := 0; j < count; j++ {
+		v := c.poly[0][j*n : (j+1)*n]
+		c.vertices = append(c.vertices, v...)
+		l := len(c.vertices)
+		c.vertices[l-n+2] -= c.ox
+		c.vertices[l-n+3] -= c.oy
+	}
+	for j := 1; j < count-1; j++ {
+		c.indices = append(c.indices, base, base+uint16(j), base+uint16(j+1))
+	}
+}
+
+// clip clips the triangle with the Sutherland-Hodgman algorithm, and stores the result polygon to c.poly[0].
+// clip returns the number of the result polygon's vertices.
+func (c *triangleClipper) clip(v0, v1, v2 []float32) int {
+	const n = graphics.VertexFloatCount
+
+	copy(c.poly[0][0:], v0)
+	copy(c.poly[0][n:], v1)
+	copy(c.poly[0][2*n:], v2)
+	count := 3
+
+	// Each plane is represented as (the index of the component, the boundary value, the sign).
+	planes := [...]struct {
+		idx  int
+		v    float32
+		sign float32
+	}{
+		{2, c.x0, 1},
+		{2, c.x1, -1},
+		{3, c.y0, 1},
+		{3, c.y1, -1},
+	}
+	for _, p := range planes {
+		in := c.poly[0][:count*n]
+		out := c.poly[1][:0]
+		for j := 0; j < count; j++ {
+			a := in[j*n : (j+1)*n]
+			b := in[((j+1)%count)*n : ((j+1)%count+1)*n]
+			da := (a[p.idx] - p.v) * p.sign
+			db := (b[p.idx] - p.v) * p.sign
+			if da >= 0 {
+				out = append(out, a...)
+			}
+			if (da >= 0) != (db >= 0) {
+				t := da / (da - db)
+				for k := 0; k < n; k++ {
+					out = append(out, a[k]+(b[k]-a[k])*t)
+				}
+			}
+		}
+		count = len(out) / n
+		if count < 3 {
+			return 0
+		}
+		copy(c.poly[0][:], out)
+	}
+	return count
+}
+
+func (i *Image) writePixelsToTiles(pix []byte, x, y, width, height int) {
+	i.ensureTiles()
+
+	r := image.Rect(x, y, x+width, y+height)
+	for _, t := range i.tiles {
+		ir := r.Intersect(t.bounds)
+		if ir.Empty() {
+			continue
+		}
+		pix2 := theTemporaryBytes.alloc(4 * ir.Dx() * ir.Dy())
+		for j := 0; j < ir.Dy(); j++ {
+			srcIdx := 4 * ((ir.Min.Y-y+j)*width + (ir.Min.X - x))
+			copy(pix2[4*j*ir.Dx():4*(j+1)*ir.Dx()], pix[srcIdx:srcIdx+4*ir.Dx()])
+		}
+		t.img.writePixels(pix2, ir.Min.X-t.bounds.Min.X, ir.Min.Y-t.bounds.Min.Y, ir.Dx(), ir.Dy())
+	}
+}
+
+func (i *Image) readPixelsFromTiles(graphicsDriver graphicsdriver.Graphics, pixels []byte) error {
+	if i.tiles == nil {
+		for j := range pixels {
+			pixels[j] = 0
+		}
+		return nil
+	}
+
+	for _, t := range i.tiles {
+		pix := make([]byte, 4*t.bounds.Dx()*t.bounds.Dy())
+		if err := t.img.readPixels(graphicsDriver, pix); err != nil {
+			return err
+		}
+		for j := 0; j < t.bounds.Dy(); j++ {
+			dstIdx := 4 * ((t.bounds.Min.Y+j)*i.width + t.bounds.Min.X)
+			copy(pixels[dstIdx:dstIdx+4*t.bounds.Dx()], pix[4*j*t.bounds.Dx():4*(j+1)*t.bounds.Dx()])
+		}
+	}
+	return nil
+}
+
+func intersectRegion(a, b graphicsdriver.Region) graphicsdriver.Region {
+	x0 := max32(a.X, b.X)
+	y0 := max32(a.Y, b.Y)
+	x1 := min32(a.X+a.Width, b.X+b.Width)
+	y1 := min32(a.Y+a.Height, b.Y+b.Height)
+	if x1 <= x0 || y1 <= y0 {
+		return graphicsdriver.Region{}
+	}
+	return graphicsdriver.Region{
+		X:      x0,
+		Y:      y0,
+		Width:  x1 - x0,
+		Height: y1 - y0,
+	}
+}
+
+func min32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func checkTiledSecondarySources(srcs [graphics.ShaderImageCount]*Image) {
+	for _, src := range srcs[1:] {
+		if src != nil && src.needsTiling() {
+			panic(fmt.Sprintf("atlas: an image larger than the maximum texture size cannot be used as a second or later source: width: %d, height: %d", src.width, src.height))
+		}
+	}
+}
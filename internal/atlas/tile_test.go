@@ -0,0 +1,80 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atlas_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+)
+
+func TestClipTriangles(t *testing.T) {
+	// The destination positions are (source positions + (10, 20)), and the red values are proportional to
+	// the source X positions.
+	const size = 100
+	vs := []float32{
+		10, 20, 0, 0, 0, 1, 1, 1,
+		10 + size, 20, size, 0, 1, 1, 1, 1,
+		10, 20 + size, 0, size, 0, 1, 1, 1,
+		10 + size, 20 + size, size, size, 1, 1, 1, 1,
+	}
+	is := graphics.QuadIndices()
+
+	const inf = math.MaxFloat32
+	cases := []struct {
+		x0, y0, x1, y1 float32
+		ox, oy         float32
+		area           float32
+	}{
+		{-inf, -inf, inf, inf, 0, 0, size * size},
+		{-inf, -inf, 50, inf, 0, 0, 50 * size},
+		{50, -inf, inf, inf, 49, 0, 50 * size},
+		{25, 25, 75, 50, 24, 24, 50 * 25},
+		{200, -inf, inf, inf, 199, 0, 0},
+	}
+	for _, c := range cases {
+		gotVs, gotIs := atlas.ClipTrianglesForTesting(vs, is, c.x0, c.y0, c.x1, c.y1, c.ox, c.oy)
+
+		const n = graphics.VertexFloatCount
+		for i := 0; i < len(gotVs); i += n {
+			sx, sy := gotVs[i+2]+c.ox, gotVs[i+3]+c.oy
+			if sx < c.x0 || sx > c.x1 || sy < c.y0 || sy > c.y1 {
+				t.Errorf("clip(%v): source position (%f, %f) is out of the region", c, sx, sy)
+			}
+			if got, want := gotVs[i], sx+10; math.Abs(float64(got-want)) > 1e-3 {
+				t.Errorf("clip(%v): destination X: got: %f, want: %f", c, got, want)
+			}
+			if got, want := gotVs[i+1], sy+20; math.Abs(float64(got-want)) > 1e-3 {
+				t.Errorf("clip(%v): destination Y: got: %f, want: %f", c, got, want)
+			}
+			if got, want := gotVs[i+4], sx/size; math.Abs(float64(got-want)) > 1e-3 {
+				t.Errorf("clip(%v): red: got: %f, want: %f", c, got, want)
+			}
+		}
+
+		var area float32
+		for i := 0; i < len(gotIs); i += 3 {
+			x0, y0 := gotVs[n*int(gotIs[i])+2], gotVs[n*int(gotIs[i])+3]
+			x1, y1 := gotVs[n*int(gotIs[i+1])+2], gotVs[n*int(gotIs[i+1])+3]
+			x2, y2 := gotVs[n*int(gotIs[i+2])+2], gotVs[n*int(gotIs[i+2])+3]
+			area += float32(math.Abs(float64((x1-x0)*(y2-y0)-(x2-x0)*(y1-y0)))) / 2
+		}
+		if got, want := area, c.area; math.Abs(float64(got-want)) > 1e-2 {
+			t.Errorf("clip(%v): area: got: %f, want: %f", c, got, want)
+		}
+	}
+}
@@ -112,6 +112,24 @@ func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte
 	return nil
 }
 
+// AtlasID returns the identifier of the atlas the image is on, or -1 if the image is not on an atlas.
+func (i *Image) AtlasID() int {
+	if maybeCanAddDelayedCommand() {
+		// The image is not allocated before the game starts.
+		return -1
+	}
+	return i.img.AtlasID()
+}
+
+// NeedsTiling reports whether the image is too big to be on one texture.
+func (i *Image) NeedsTiling() bool {
+	if maybeCanAddDelayedCommand() {
+		// The maximum texture size is not determined before the game starts.
+		return false
+	}
+	return i.img.NeedsTiling()
+}
+
 func (i *Image) DumpScreenshot(graphicsDriver graphicsdriver.Graphics, name string, blackbg bool) (string, error) {
 	checkDelayedCommandsFlushed("Dump")
 	return i.img.DumpScreenshot(graphicsDriver, name, blackbg)
@@ -34,6 +34,7 @@ const (
 	AddressUnsafe Address = iota
 	AddressClampToZero
 	AddressRepeat
+	AddressMirroredRepeat
 )
 
 const (
@@ -66,6 +67,14 @@ func adjustTexelForAddressRepeat(p vec2) vec2 {
 }
 {{end}}
 
+{{if eq .Address .AddressMirroredRepeat}}
+func adjustTexelForAddressMirroredRepeat(p vec2) vec2 {
+	origin, size := imageSrcRegionOnTexture()
+	q := mod(p - origin, 2 * size)
+	return size - abs(size - q) + origin
+}
+{{end}}
+
 func Fragment(position vec4, texCoord vec2, color vec4) vec4 {
 {{if eq .Filter .FilterNearest}}
 {{if eq .Address .AddressUnsafe}}
@@ -74,6 +83,8 @@ func Fragment(position vec4, texCoord vec2, color vec4) vec4 {
 	clr := imageSrc0At(texCoord)
 {{else if eq .Address .AddressRepeat}}
 	clr := imageSrc0At(adjustTexelForAddressRepeat(texCoord))
+{{else if eq .Address .AddressMirroredRepeat}}
+	clr := imageSrc0At(adjustTexelForAddressMirroredRepeat(texCoord))
 {{end}}
 {{else if eq .Filter .FilterLinear}}
 	sourceSize := imageSrcTextureSize()
@@ -86,6 +97,11 @@ func Fragment(position vec4, texCoord vec2, color vec4) vec4 {
 {{if eq .Address .AddressRepeat}}
 	p0 = adjustTexelForAddressRepeat(p0)
 	p1 = adjustTexelForAddressRepeat(p1)
+{{else if eq .Address .AddressMirroredRepeat}}
+	// Calculate the rate before mirroring, as mirroring reverses the order of p0 and p1.
+	rate := fract(p0 * sourceSize)
+	p0 = adjustTexelForAddressMirroredRepeat(p0)
+	p1 = adjustTexelForAddressMirroredRepeat(p1)
 {{end}}
 
 {{if eq .Address .AddressUnsafe}}
@@ -100,7 +116,9 @@ func Fragment(position vec4, texCoord vec2, color vec4) vec4 {
 	c3 := imageSrc0At(p1)
 {{end}}
 
+{{if ne .Address .AddressMirroredRepeat}}
 	rate := fract(p0 * sourceSize)
+{{end}}
 	clr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)
 {{end}}
 
@@ -144,23 +162,25 @@ func Shader(filter Filter, address Address, useColorM bool) []byte {
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, struct {
-		Filter             Filter
-		FilterNearest      Filter
-		FilterLinear       Filter
-		Address            Address
-		AddressUnsafe      Address
-		AddressClampToZero Address
-		AddressRepeat      Address
-		UseColorM          bool
+		Filter                Filter
+		FilterNearest         Filter
+		FilterLinear          Filter
+		Address               Address
+		AddressUnsafe         Address
+		AddressClampToZero    Address
+		AddressRepeat         Address
+		AddressMirroredRepeat Address
+		UseColorM             bool
 	}{
-		Filter:             filter,
-		FilterNearest:      FilterNearest,
-		FilterLinear:       FilterLinear,
-		Address:            address,
-		AddressUnsafe:      AddressUnsafe,
-		AddressClampToZero: AddressClampToZero,
-		AddressRepeat:      AddressRepeat,
-		UseColorM:          useColorM,
+		Filter:                filter,
+		FilterNearest:         FilterNearest,
+		FilterLinear:          FilterLinear,
+		Address:               address,
+		AddressUnsafe:         AddressUnsafe,
+		AddressClampToZero:    AddressClampToZero,
+		AddressRepeat:         AddressRepeat,
+		AddressMirroredRepeat: AddressMirroredRepeat,
+		UseColorM:             useColorM,
 	}); err != nil {
 		panic(fmt.Sprintf("builtinshader: tmpl.Execute failed: %v", err))
 	}
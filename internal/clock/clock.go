@@ -35,6 +35,13 @@ var (
 	// lastSystemTime indicates the logical time in the game, so this can be bigger than the curren time.
 	lastSystemTime int64
 
+	// accumulatedTime is the elapsed time in nanoseconds that is not consumed by ticks yet.
+	// accumulatedTime is kept within the duration of a tick.
+	accumulatedTime int64
+
+	// interpolation is the ratio of the elapsed time since the last tick to the duration of a tick.
+	interpolation float64
+
 	actualFPS   float64
 	actualTPS   float64
 	prevTPS     int64
@@ -64,6 +71,15 @@ func ActualTPS() float64 {
 	return actualTPS
 }
 
+// Interpolation returns the ratio of the elapsed time since the last tick to the duration of a tick, in [0, 1].
+//
+// Interpolation is updated at UpdateFrame.
+func Interpolation() float64 {
+	m.Lock()
+	defer m.Unlock()
+	return interpolation
+}
+
 func max(a, b int64) int64 {
 	if a < b {
 		return b
@@ -71,7 +87,7 @@ func max(a, b int64) int64 {
 	return a
 }
 
-func calcCountFromTPS(tps int64, now int64) int {
+func calcCountFromTPS(tps int64, now int64, elapsed int64) int {
 	if tps == 0 {
 		return 0
 	}
@@ -81,6 +97,7 @@ func calcCountFromTPS(tps int64, now int64) int {
 
 	diff := now - lastSystemTime
 	if diff < 0 {
+		accumulateTime(tps, elapsed, 0)
 		return 0
 	}
 
@@ -110,13 +127,41 @@ func calcCountFromTPS(tps int64, now int64) int {
 
 	if syncWithSystemClock {
 		lastSystemTime = now
+		accumulatedTime = 0
 	} else {
 		lastSystemTime += int64(count) * int64(time.Second) / tps
+		accumulateTime(tps, elapsed, count)
 	}
 
 	return count
 }
 
+// accumulateTime adds the elapsed time of the frame to the accumulated time, and consumes it by the ticks.
+func accumulateTime(tps int64, elapsed int64, count int) {
+	tick := int64(time.Second) / tps
+	accumulatedTime += elapsed - int64(count)*tick
+	// The stabilization in calcCountFromTPS can run a tick earlier or later than the accumulated time.
+	// Clamp the accumulated time so that the error doesn't remain.
+	if accumulatedTime < 0 {
+		accumulatedTime = 0
+	}
+	if accumulatedTime > tick {
+		accumulatedTime = tick
+	}
+}
+
+// calcInterpolation returns the ratio of the accumulated time to the duration of a tick.
+//
+// The accumulated time is used instead of lastSystemTime, as lastSystemTime jumps by the stabilization in
+// calcCountFromTPS.
+func calcInterpolation(tps int64) float64 {
+	// Without a fixed tick, the game state is always the latest.
+	if tps <= 0 {
+		return 1
+	}
+	return float64(accumulatedTime) * float64(tps) / float64(time.Second)
+}
+
 func updateFPSAndTPS(now int64, count int) {
 	fpsCount++
 	tpsCount += count
@@ -143,21 +188,25 @@ func updateFPSAndTPS(now int64, count int) {
 func UpdateFrame() int {
 	m.Lock()
 	defer m.Unlock()
+	return updateFrame(now())
+}
 
-	n := now()
+func updateFrame(n int64) int {
 	if lastNow > n {
 		// This ensures that now() must be monotonic (#875).
 		panic("clock: lastNow must be older than n")
 	}
+	elapsed := n - lastNow
 	lastNow = n
 
 	c := 0
 	if tps == SyncWithFPS {
 		c = 1
 	} else if tps > 0 {
-		c = calcCountFromTPS(int64(tps), n)
+		c = calcCountFromTPS(int64(tps), n, elapsed)
 	}
 	updateFPSAndTPS(n, c)
+	interpolation = calcInterpolation(int64(tps))
 
 	return c
 }
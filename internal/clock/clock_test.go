@@ -0,0 +1,82 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/clock"
+)
+
+func testInterpolation(t *testing.T, tps int, frameDurations []time.Duration, wantCounts []int, wantInterpolations []float64) {
+	t.Helper()
+
+	origTPS := clock.TPS()
+	defer clock.SetTPS(origTPS)
+
+	clock.SetTPS(tps)
+	clock.ResetForTesting(0)
+
+	var now int64
+	for i, d := range frameDurations {
+		now += int64(d)
+		if got, want := clock.UpdateFrameForTesting(now), wantCounts[i]; got != want {
+			t.Errorf("frame %d: count: got: %d, want: %d", i, got, want)
+		}
+		if got, want := clock.Interpolation(), wantInterpolations[i]; math.Abs(got-want) > 1e-6 {
+			t.Errorf("frame %d: interpolation: got: %f, want: %f", i, got, want)
+		}
+	}
+}
+
+func TestInterpolationHighFPS(t *testing.T) {
+	// 240 FPS with 60 TPS. A tick runs every 4 frames.
+	// The first frame syncs the clock, and the stabilization runs the first tick one frame earlier.
+	const d = time.Second / 240
+	testInterpolation(t, 60,
+		[]time.Duration{d, d, d, d, d, d, d, d, d, d, d, d},
+		[]int{0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 1},
+		[]float64{0, 0.25, 0.5, 0, 0.25, 0.5, 0.75, 0, 0.25, 0.5, 0.75, 0})
+}
+
+func TestInterpolationJitter(t *testing.T) {
+	// About 60 FPS with 60 TPS and a jittery clock. A tick runs every frame.
+	// The interpolation is the time accumulated by the frames and not consumed by the ticks.
+	ms := time.Millisecond
+	testInterpolation(t, 60,
+		[]time.Duration{14 * ms, 19 * ms, 15 * ms, 18 * ms, 17 * ms, 16 * ms, 14 * ms, 19 * ms},
+		[]int{1, 1, 1, 1, 1, 1, 1, 1},
+		[]float64{0, 0.14, 0.04, 0.12, 0.14, 0.1, 0, 0.14})
+}
+
+func TestInterpolationPause(t *testing.T) {
+	// After a long pause, the clock is synced with the system clock and the interpolation restarts from 0.
+	// A tick still runs at the frame after the pause.
+	const d = time.Second / 240
+	testInterpolation(t, 60,
+		[]time.Duration{d, d, d, time.Second, d, d},
+		[]int{0, 0, 0, 1, 0, 0},
+		[]float64{0, 0.25, 0.5, 0, 0.25, 0.5})
+}
+
+func TestInterpolationSyncWithFPS(t *testing.T) {
+	const d = time.Second / 60
+	testInterpolation(t, clock.SyncWithFPS,
+		[]time.Duration{d, d, d},
+		[]int{1, 1, 1},
+		[]float64{1, 1, 1})
+}
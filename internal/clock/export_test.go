@@ -0,0 +1,37 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+// ResetForTesting resets the clock state as if the clock starts at the given time n in nanoseconds.
+func ResetForTesting(n int64) {
+	m.Lock()
+	defer m.Unlock()
+
+	lastNow = n
+	lastSystemTime = n
+	lastUpdated = n
+	accumulatedTime = 0
+	interpolation = 0
+	prevTPS = 0
+	fpsCount = 0
+	tpsCount = 0
+}
+
+// UpdateFrameForTesting is UpdateFrame with a fake current time n in nanoseconds.
+func UpdateFrameForTesting(n int64) int {
+	m.Lock()
+	defer m.Unlock()
+	return updateFrame(n)
+}
@@ -61,11 +61,7 @@ func ToMonitorCallback(cb func(monitor *Monitor, event PeripheralEvent)) Monitor
 		return nil
 	}
 	return func(monitor *glfw.Monitor, event glfw.PeripheralEvent) {
-		var m *Monitor
-		if monitor != nil {
-			m = &Monitor{monitor}
-		}
-		cb(m, PeripheralEvent(event))
+		cb(theMonitors.get(monitor), PeripheralEvent(event))
 	}
 }
 
@@ -57,6 +57,29 @@ func (w windows) get(win *glfw.Window) *Window {
 	return ww
 }
 
+type monitors map[*glfw.Monitor]*Monitor
+
+var (
+	theMonitors = monitors{}
+	monitorsM   sync.Mutex
+)
+
+// get returns a wrapper for the given monitor.
+// The same wrapper is returned for the same monitor so that monitors can be compared by their pointers.
+func (m monitors) get(monitor *glfw.Monitor) *Monitor {
+	if monitor == nil {
+		return nil
+	}
+	monitorsM.Lock()
+	defer monitorsM.Unlock()
+	if mm, ok := m[monitor]; ok {
+		return mm
+	}
+	mm := &Monitor{m: monitor}
+	m[monitor] = mm
+	return mm
+}
+
 type Cursor struct {
 	c *glfw.Cursor
 }
@@ -75,6 +98,10 @@ func (m *Monitor) GetContentScale() (float32, float32, error) {
 	return x, y, nil
 }
 
+func (m *Monitor) GetName() string {
+	return m.m.GetName()
+}
+
 func (m *Monitor) GetPos() (x, y int) {
 	return m.m.GetPos()
 }
@@ -126,11 +153,7 @@ func (w *Window) GetKey(key Key) Action {
 }
 
 func (w *Window) GetMonitor() *Monitor {
-	m := w.w.GetMonitor()
-	if m == nil {
-		return nil
-	}
-	return &Monitor{m}
+	return theMonitors.get(w.w.GetMonitor())
 }
 
 func (w *Window) GetMouseButton(button MouseButton) Action {
@@ -285,21 +308,13 @@ func GetKeyName(key Key, scancode int) string {
 func GetMonitors() []*Monitor {
 	ms := []*Monitor{}
 	for _, m := range glfw.GetMonitors() {
-		if m != nil {
-			ms = append(ms, &Monitor{m})
-		} else {
-			ms = append(ms, nil)
-		}
+		ms = append(ms, theMonitors.get(m))
 	}
 	return ms
 }
 
 func GetPrimaryMonitor() *Monitor {
-	m := glfw.GetPrimaryMonitor()
-	if m == nil {
-		return nil
-	}
-	return &Monitor{m}
+	return theMonitors.get(glfw.GetPrimaryMonitor())
 }
 
 func Init() error {
@@ -38,6 +38,14 @@ func (m *Monitor) GetContentScale() (float32, float32, error) {
 	return (*goglfw.Monitor)(m).GetContentScale()
 }
 
+func (m *Monitor) GetName() string {
+	n, err := (*goglfw.Monitor)(m).GetName()
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
 func (m *Monitor) GetPos() (int, int) {
 	x, y, err := (*goglfw.Monitor)(m).GetPos()
 	if err != nil {
@@ -194,6 +194,10 @@ func (q *commandQueue) flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 			err = err1
 		}
 
+		if endFrame {
+			theStats.endFrame()
+		}
+
 		// Release the commands explicitly (#1803).
 		// Apparently, the part of a slice between len and cap-1 still holds references.
 		// Then, resetting the length by [:0] doesn't release the references.
@@ -246,6 +250,7 @@ func (q *commandQueue) flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 			// introduced than drawTrianglesCommand.
 			if dtc, ok := c.(*drawTrianglesCommand); ok {
 				indexOffset += dtc.numIndices()
+				theStats.addDrawCalls(len(dtc.dstRegions))
 			}
 		}
 		cs = cs[nc:]
@@ -461,6 +466,7 @@ func (c *disposeImageCommand) String() string {
 // Exec executes the disposeImageCommand.
 func (c *disposeImageCommand) Exec(graphicsDriver graphicsdriver.Graphics, indexOffset int) error {
 	c.target.image.Dispose()
+	theStats.removeImage(c.target)
 	return nil
 }
 
@@ -498,6 +504,9 @@ func (c *newImageCommand) Exec(graphicsDriver graphicsdriver.Graphics, indexOffs
 		c.result.image, err = graphicsDriver.NewScreenFramebufferImage(c.width, c.height)
 	} else {
 		c.result.image, err = graphicsDriver.NewImage(c.width, c.height)
+		if err == nil {
+			theStats.addImage(c.result)
+		}
 	}
 	return err
 }
@@ -550,6 +559,7 @@ func ResetGraphicsDriverState(graphicsDriver graphicsdriver.Graphics) (err error
 	if r, ok := graphicsDriver.(interface{ Reset() error }); ok {
 		runOnRenderThread(func() {
 			err = r.Reset()
+			theStats.resetImages()
 		})
 	}
 	return nil
@@ -0,0 +1,58 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphicscommand
+
+import (
+	"sync/atomic"
+)
+
+// StatsForTesting is stats independent from the global stats.
+type StatsForTesting struct {
+	s stats
+}
+
+// AddImage counts a new image as if it is allocated by the graphics driver.
+func (s *StatsForTesting) AddImage(width, height int) *Image {
+	img := &Image{
+		width:  width,
+		height: height,
+	}
+	s.s.addImage(img)
+	return img
+}
+
+func (s *StatsForTesting) RemoveImage(img *Image) {
+	s.s.removeImage(img)
+}
+
+func (s *StatsForTesting) ResetImages() {
+	s.s.resetImages()
+}
+
+func (s *StatsForTesting) ImageMemoryInBytes() int64 {
+	return atomic.LoadInt64(&s.s.imageBytes)
+}
+
+func (s *StatsForTesting) AddDrawCalls(n int) {
+	s.s.addDrawCalls(n)
+}
+
+func (s *StatsForTesting) EndFrame() {
+	s.s.endFrame()
+}
+
+func (s *StatsForTesting) DrawCallCount() int {
+	return int(atomic.LoadInt64(&s.s.drawCallsInLastFrame))
+}
@@ -44,6 +44,12 @@ type Image struct {
 	id int
 
 	bufferedWP []*graphicsdriver.WritePixelsArgs
+
+	// statsBytes is the size in bytes counted in the stats, or 0 if the image is not counted.
+	statsBytes int64
+
+	// statsGeneration is the stats' generation when the image is counted.
+	statsGeneration int64
 }
 
 var nextID = 1
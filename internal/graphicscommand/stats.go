@@ -0,0 +1,86 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphicscommand
+
+import (
+	"sync/atomic"
+)
+
+// stats is statistics of the executed commands.
+type stats struct {
+	// drawCalls is the number of draw calls in the current frame.
+	// drawCalls is accessed only from the render thread.
+	drawCalls int64
+
+	// drawCallsInLastFrame is the number of draw calls in the last frame.
+	// drawCallsInLastFrame must be accessed atomically.
+	drawCallsInLastFrame int64
+
+	// imageBytes is the total size of the images on GPU in bytes, except for the screen framebuffers.
+	// imageBytes must be accessed atomically.
+	imageBytes int64
+
+	// imageGeneration is incremented when the graphics driver state is reset e.g. by a context loss.
+	// The images allocated in an older generation are already lost and not counted in imageBytes.
+	// imageGeneration is accessed only from the render thread.
+	imageGeneration int64
+}
+
+var theStats stats
+
+func (s *stats) addDrawCalls(n int) {
+	s.drawCalls += int64(n)
+}
+
+func (s *stats) endFrame() {
+	atomic.StoreInt64(&s.drawCallsInLastFrame, s.drawCalls)
+	s.drawCalls = 0
+}
+
+func (s *stats) addImage(img *Image) {
+	img.statsBytes = 4 * int64(img.width) * int64(img.height)
+	img.statsGeneration = s.imageGeneration
+	atomic.AddInt64(&s.imageBytes, img.statsBytes)
+}
+
+func (s *stats) removeImage(img *Image) {
+	if img.statsBytes == 0 || img.statsGeneration != s.imageGeneration {
+		return
+	}
+	atomic.AddInt64(&s.imageBytes, -img.statsBytes)
+	img.statsBytes = 0
+}
+
+// resetImages forgets all the counted images.
+// resetImages is called when the graphics driver state is reset, as all the images are lost then.
+func (s *stats) resetImages() {
+	s.imageGeneration++
+	atomic.StoreInt64(&s.imageBytes, 0)
+}
+
+// DrawCallCount returns the number of draw calls issued to the graphics driver in the last frame.
+//
+// DrawCallCount is concurrent-safe.
+func DrawCallCount() int {
+	return int(atomic.LoadInt64(&theStats.drawCallsInLastFrame))
+}
+
+// ImageMemoryInBytes returns the total size of the images allocated by the graphics driver in bytes.
+// The screen framebuffers are not included.
+//
+// ImageMemoryInBytes is concurrent-safe.
+func ImageMemoryInBytes() int64 {
+	return atomic.LoadInt64(&theStats.imageBytes)
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphicscommand_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
+)
+
+func TestStatsImageMemory(t *testing.T) {
+	var s graphicscommand.StatsForTesting
+
+	img0 := s.AddImage(16, 16)
+	img1 := s.AddImage(32, 8)
+	if got, want := s.ImageMemoryInBytes(), int64(4*16*16+4*32*8); got != want {
+		t.Errorf("ImageMemoryInBytes(): got: %d, want: %d", got, want)
+	}
+
+	s.RemoveImage(img0)
+	if got, want := s.ImageMemoryInBytes(), int64(4*32*8); got != want {
+		t.Errorf("ImageMemoryInBytes() after removing: got: %d, want: %d", got, want)
+	}
+
+	// Removing the same image twice must not change the counter.
+	s.RemoveImage(img0)
+	if got, want := s.ImageMemoryInBytes(), int64(4*32*8); got != want {
+		t.Errorf("ImageMemoryInBytes() after removing twice: got: %d, want: %d", got, want)
+	}
+
+	// Restoring after a context loss disposes the lost images and allocates them again.
+	s.ResetImages()
+	img2 := s.AddImage(32, 8)
+	s.RemoveImage(img1)
+	if got, want := s.ImageMemoryInBytes(), int64(4*32*8); got != want {
+		t.Errorf("ImageMemoryInBytes() after restoring: got: %d, want: %d", got, want)
+	}
+
+	s.RemoveImage(img2)
+	if got, want := s.ImageMemoryInBytes(), int64(0); got != want {
+		t.Errorf("ImageMemoryInBytes() after removing all: got: %d, want: %d", got, want)
+	}
+}
+
+func TestStatsDrawCalls(t *testing.T) {
+	var s graphicscommand.StatsForTesting
+
+	s.AddDrawCalls(3)
+	s.AddDrawCalls(2)
+	if got, want := s.DrawCallCount(), 0; got != want {
+		t.Errorf("DrawCallCount() in the first frame: got: %d, want: %d", got, want)
+	}
+
+	s.EndFrame()
+	if got, want := s.DrawCallCount(), 5; got != want {
+		t.Errorf("DrawCallCount() after the first frame: got: %d, want: %d", got, want)
+	}
+
+	s.EndFrame()
+	if got, want := s.DrawCallCount(), 0; got != want {
+		t.Errorf("DrawCallCount() after the second frame: got: %d, want: %d", got, want)
+	}
+}
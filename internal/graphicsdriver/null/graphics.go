@@ -0,0 +1,171 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package null provides a graphics driver that doesn't use any GPU.
+//
+// Images hold their pixels in the main memory, and pixels written by WritePixels can be read by ReadPixels.
+// DrawTriangles does nothing.
+package null
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+)
+
+type Graphics struct {
+	images  map[graphicsdriver.ImageID]*Image
+	shaders map[graphicsdriver.ShaderID]*Shader
+
+	nextImageID  graphicsdriver.ImageID
+	nextShaderID graphicsdriver.ShaderID
+}
+
+func NewGraphics() *Graphics {
+	return &Graphics{
+		images:  map[graphicsdriver.ImageID]*Image{},
+		shaders: map[graphicsdriver.ShaderID]*Shader{},
+	}
+}
+
+func (g *Graphics) genNextImageID() graphicsdriver.ImageID {
+	g.nextImageID++
+	return g.nextImageID
+}
+
+func (g *Graphics) genNextShaderID() graphicsdriver.ShaderID {
+	g.nextShaderID++
+	return g.nextShaderID
+}
+
+func (g *Graphics) Initialize() error {
+	return nil
+}
+
+func (g *Graphics) Begin() error {
+	return nil
+}
+
+func (g *Graphics) End(present bool) error {
+	return nil
+}
+
+func (g *Graphics) SetTransparent(transparent bool) {
+}
+
+func (g *Graphics) SetVertices(vertices []float32, indices []uint16) error {
+	return nil
+}
+
+func (g *Graphics) NewImage(width, height int) (graphicsdriver.Image, error) {
+	i := &Image{
+		id:       g.genNextImageID(),
+		graphics: g,
+		width:    width,
+		height:   height,
+		pixels:   make([]byte, 4*width*height),
+	}
+	g.images[i.id] = i
+	return i, nil
+}
+
+func (g *Graphics) NewScreenFramebufferImage(width, height int) (graphicsdriver.Image, error) {
+	return g.NewImage(width, height)
+}
+
+func (g *Graphics) SetVsyncEnabled(enabled bool) {
+}
+
+func (g *Graphics) NeedsRestoring() bool {
+	return false
+}
+
+func (g *Graphics) NeedsClearingScreen() bool {
+	return false
+}
+
+func (g *Graphics) IsGL() bool {
+	return false
+}
+
+func (g *Graphics) IsDirectX() bool {
+	return false
+}
+
+func (g *Graphics) MaxImageSize() int {
+	return 4096
+}
+
+func (g *Graphics) NewShader(program *shaderir.Program) (graphicsdriver.Shader, error) {
+	s := &Shader{
+		id:       g.genNextShaderID(),
+		graphics: g,
+	}
+	g.shaders[s.id] = s
+	return s, nil
+}
+
+// DrawTriangles does nothing as there is no GPU to render.
+func (g *Graphics) DrawTriangles(dst graphicsdriver.ImageID, srcs [graphics.ShaderImageCount]graphicsdriver.ImageID, shader graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, evenOdd bool) error {
+	return nil
+}
+
+type Image struct {
+	id       graphicsdriver.ImageID
+	graphics *Graphics
+	width    int
+	height   int
+	pixels   []byte
+}
+
+func (i *Image) ID() graphicsdriver.ImageID {
+	return i.id
+}
+
+func (i *Image) Dispose() {
+	delete(i.graphics.images, i.id)
+}
+
+func (i *Image) IsInvalidated() bool {
+	return false
+}
+
+func (i *Image) ReadPixels(buf []byte, x, y, width, height int) error {
+	for j := 0; j < height; j++ {
+		copy(buf[4*j*width:4*(j+1)*width], i.pixels[4*((y+j)*i.width+x):])
+	}
+	return nil
+}
+
+func (i *Image) WritePixels(args []*graphicsdriver.WritePixelsArgs) error {
+	for _, a := range args {
+		for j := 0; j < a.Height; j++ {
+			copy(i.pixels[4*((a.Y+j)*i.width+a.X):], a.Pixels[4*j*a.Width:4*(j+1)*a.Width])
+		}
+	}
+	return nil
+}
+
+type Shader struct {
+	id       graphicsdriver.ShaderID
+	graphics *Graphics
+}
+
+func (s *Shader) ID() graphicsdriver.ShaderID {
+	return s.id
+}
+
+func (s *Shader) Dispose() {
+	delete(s.graphics.shaders, s.id)
+}
@@ -47,6 +47,8 @@ const (
 	glFuncAdd             blendOperation = 0x8006
 	glFuncReverseSubtract blendOperation = 0x800b
 	glFuncSubtract        blendOperation = 0x800a
+	glMin                 blendOperation = 0x8007
+	glMax                 blendOperation = 0x8008
 )
 
 func convertBlendFactor(f graphicsdriver.BlendFactor) blendFactor {
@@ -86,6 +88,10 @@ func convertBlendOperation(o graphicsdriver.BlendOperation) blendOperation {
 		return glFuncSubtract
 	case graphicsdriver.BlendOperationReverseSubtract:
 		return glFuncReverseSubtract
+	case graphicsdriver.BlendOperationMin:
+		return glMin
+	case graphicsdriver.BlendOperationMax:
+		return glMax
 	default:
 		panic(fmt.Sprintf("opengl: invalid blend operation %d", o))
 	}
@@ -127,6 +133,9 @@ type context struct {
 	highpOnce          sync.Once
 	initOnce           sync.Once
 
+	// blendMinMaxAvailable reports whether the MIN and MAX blend equations are available.
+	blendMinMaxAvailable bool
+
 	contextPlatform
 }
 
@@ -200,6 +209,7 @@ func (c *context) reset() error {
 			err1 = err
 			return
 		}
+		c.blendMinMaxAvailable = c.isBlendMinMaxAvailable()
 	})
 	if err1 != nil {
 		return err1
@@ -220,6 +230,10 @@ func (c *context) reset() error {
 	return nil
 }
 
+func isBlendOperationMinMax(o graphicsdriver.BlendOperation) bool {
+	return o == graphicsdriver.BlendOperationMin || o == graphicsdriver.BlendOperationMax
+}
+
 func (c *context) blend(blend graphicsdriver.Blend) {
 	if c.lastBlend == blend {
 		return
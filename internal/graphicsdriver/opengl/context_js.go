@@ -23,6 +23,9 @@ import (
 type contextPlatform struct {
 	canvas js.Value
 	webGL2 bool
+
+	// blendMinMaxExtension reports whether EXT_blend_minmax is enabled on WebGL 1.
+	blendMinMaxExtension bool
 }
 
 func (c *context) glslVersion() glsl.GLSLVersion {
@@ -31,3 +34,8 @@ func (c *context) glslVersion() glsl.GLSLVersion {
 	}
 	return glsl.GLSLVersionES100
 }
+
+func (c *context) isBlendMinMaxAvailable() bool {
+	// MIN and MAX are core features of WebGL 2.
+	return c.webGL2 || c.blendMinMaxExtension
+}
@@ -17,6 +17,7 @@
 package opengl
 
 import (
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver/opengl/gl"
 	"github.com/hajimehoshi/ebiten/v2/internal/shaderir/glsl"
 )
 
@@ -29,3 +30,14 @@ func (c *context) glslVersion() glsl.GLSLVersion {
 	}
 	return glsl.GLSLVersionDefault
 }
+
+func (c *context) isBlendMinMaxAvailable() bool {
+	// MIN and MAX are core features of OpenGL 1.4 or later and OpenGL ES 3.0 or later.
+	if !c.ctx.IsES() {
+		return true
+	}
+	if major, _ := gl.Version(c.ctx); major >= 3 {
+		return true
+	}
+	return gl.HasExtension(c.ctx, "GL_EXT_blend_minmax")
+}
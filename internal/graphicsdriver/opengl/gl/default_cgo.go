@@ -30,6 +30,7 @@ package gl
 // typedef char GLchar;
 // typedef ptrdiff_t GLintptr;
 // typedef ptrdiff_t GLsizeiptr;
+// typedef uint64_t GLuint64;
 //
 // typedef void (APIENTRY *GLDEBUGPROC)(GLenum source,GLenum type,GLuint id,GLenum severity,GLsizei length,const GLchar *message,const void *userParam);
 // typedef void (APIENTRY *GLDEBUGPROCARB)(GLenum source,GLenum type,GLuint id,GLenum severity,GLsizei length,const GLchar *message,const void *userParam);
@@ -40,6 +41,7 @@ package gl
 //
 // typedef void  (APIENTRYP GPACTIVETEXTURE)(GLenum  texture);
 // typedef void  (APIENTRYP GPATTACHSHADER)(GLuint  program, GLuint  shader);
+// typedef void  (APIENTRYP GPBEGINQUERY)(GLenum  target, GLuint  id);
 // typedef void  (APIENTRYP GPBINDATTRIBLOCATION)(GLuint  program, GLuint  index, const GLchar * name);
 // typedef void  (APIENTRYP GPBINDBUFFER)(GLenum  target, GLuint  buffer);
 // typedef void  (APIENTRYP GPBINDFRAMEBUFFEREXT)(GLenum  target, GLuint  framebuffer);
@@ -58,6 +60,7 @@ package gl
 // typedef void  (APIENTRYP GPDELETEBUFFERS)(GLsizei  n, const GLuint * buffers);
 // typedef void  (APIENTRYP GPDELETEFRAMEBUFFERSEXT)(GLsizei  n, const GLuint * framebuffers);
 // typedef void  (APIENTRYP GPDELETEPROGRAM)(GLuint  program);
+// typedef void  (APIENTRYP GPDELETEQUERIES)(GLsizei  n, const GLuint * ids);
 // typedef void  (APIENTRYP GPDELETERENDERBUFFERSEXT)(GLsizei  n, const GLuint * renderbuffers);
 // typedef void  (APIENTRYP GPDELETESHADER)(GLuint  shader);
 // typedef void  (APIENTRYP GPDELETETEXTURES)(GLsizei  n, const GLuint * textures);
@@ -66,19 +69,23 @@ package gl
 // typedef void  (APIENTRYP GPDRAWELEMENTS)(GLenum  mode, GLsizei  count, GLenum  type, const uintptr_t indices);
 // typedef void  (APIENTRYP GPENABLE)(GLenum  cap);
 // typedef void  (APIENTRYP GPENABLEVERTEXATTRIBARRAY)(GLuint  index);
+// typedef void  (APIENTRYP GPENDQUERY)(GLenum  target);
 // typedef void  (APIENTRYP GPFLUSH)();
 // typedef void  (APIENTRYP GPFRAMEBUFFERRENDERBUFFEREXT)(GLenum  target, GLenum  attachment, GLenum  renderbuffertarget, GLuint  renderbuffer);
 // typedef void  (APIENTRYP GPFRAMEBUFFERTEXTURE2DEXT)(GLenum  target, GLenum  attachment, GLenum  textarget, GLuint  texture, GLint  level);
 // typedef void  (APIENTRYP GPGENBUFFERS)(GLsizei  n, GLuint * buffers);
 // typedef void  (APIENTRYP GPGENFRAMEBUFFERSEXT)(GLsizei  n, GLuint * framebuffers);
+// typedef void  (APIENTRYP GPGENQUERIES)(GLsizei  n, GLuint * ids);
 // typedef void  (APIENTRYP GPGENRENDERBUFFERSEXT)(GLsizei  n, GLuint * renderbuffers);
 // typedef void  (APIENTRYP GPGENTEXTURES)(GLsizei  n, GLuint * textures);
 // typedef GLenum  (APIENTRYP GPGETERROR)();
 // typedef void  (APIENTRYP GPGETINTEGERV)(GLenum  pname, GLint * data);
 // typedef void  (APIENTRYP GPGETPROGRAMINFOLOG)(GLuint  program, GLsizei  bufSize, GLsizei * length, GLchar * infoLog);
 // typedef void  (APIENTRYP GPGETPROGRAMIV)(GLuint  program, GLenum  pname, GLint * params);
+// typedef void  (APIENTRYP GPGETQUERYOBJECTUI64V)(GLuint  id, GLenum  pname, GLuint64 * params);
 // typedef void  (APIENTRYP GPGETSHADERINFOLOG)(GLuint  shader, GLsizei  bufSize, GLsizei * length, GLchar * infoLog);
 // typedef void  (APIENTRYP GPGETSHADERIV)(GLuint  shader, GLenum  pname, GLint * params);
+// typedef const GLchar *  (APIENTRYP GPGETSTRING)(GLenum  name);
 // typedef GLint  (APIENTRYP GPGETUNIFORMLOCATION)(GLuint  program, const GLchar * name);
 // typedef GLboolean  (APIENTRYP GPISFRAMEBUFFEREXT)(GLuint  framebuffer);
 // typedef GLboolean  (APIENTRYP GPISPROGRAM)(GLuint  program);
@@ -117,6 +124,9 @@ package gl
 // static void  glowAttachShader(GPATTACHSHADER fnptr, GLuint  program, GLuint  shader) {
 //   (*fnptr)(program, shader);
 // }
+// static void  glowBeginQuery(GPBEGINQUERY fnptr, GLenum  target, GLuint  id) {
+//   (*fnptr)(target, id);
+// }
 // static void  glowBindAttribLocation(GPBINDATTRIBLOCATION fnptr, GLuint  program, GLuint  index, const GLchar * name) {
 //   (*fnptr)(program, index, name);
 // }
@@ -171,6 +181,9 @@ package gl
 // static void  glowDeleteProgram(GPDELETEPROGRAM fnptr, GLuint  program) {
 //   (*fnptr)(program);
 // }
+// static void  glowDeleteQueries(GPDELETEQUERIES fnptr, GLsizei  n, const GLuint * ids) {
+//   (*fnptr)(n, ids);
+// }
 // static void  glowDeleteRenderbuffersEXT(GPDELETERENDERBUFFERSEXT fnptr, GLsizei  n, const GLuint * renderbuffers) {
 //   (*fnptr)(n, renderbuffers);
 // }
@@ -195,6 +208,9 @@ package gl
 // static void  glowEnableVertexAttribArray(GPENABLEVERTEXATTRIBARRAY fnptr, GLuint  index) {
 //   (*fnptr)(index);
 // }
+// static void  glowEndQuery(GPENDQUERY fnptr, GLenum  target) {
+//   (*fnptr)(target);
+// }
 // static void  glowFlush(GPFLUSH fnptr) {
 //   (*fnptr)();
 // }
@@ -210,6 +226,9 @@ package gl
 // static void  glowGenFramebuffersEXT(GPGENFRAMEBUFFERSEXT fnptr, GLsizei  n, GLuint * framebuffers) {
 //   (*fnptr)(n, framebuffers);
 // }
+// static void  glowGenQueries(GPGENQUERIES fnptr, GLsizei  n, GLuint * ids) {
+//   (*fnptr)(n, ids);
+// }
 // static void  glowGenRenderbuffersEXT(GPGENRENDERBUFFERSEXT fnptr, GLsizei  n, GLuint * renderbuffers) {
 //   (*fnptr)(n, renderbuffers);
 // }
@@ -228,12 +247,18 @@ package gl
 // static void  glowGetProgramiv(GPGETPROGRAMIV fnptr, GLuint  program, GLenum  pname, GLint * params) {
 //   (*fnptr)(program, pname, params);
 // }
+// static void  glowGetQueryObjectui64v(GPGETQUERYOBJECTUI64V fnptr, GLuint  id, GLenum  pname, GLuint64 * params) {
+//   (*fnptr)(id, pname, params);
+// }
 // static void  glowGetShaderInfoLog(GPGETSHADERINFOLOG fnptr, GLuint  shader, GLsizei  bufSize, GLsizei * length, GLchar * infoLog) {
 //   (*fnptr)(shader, bufSize, length, infoLog);
 // }
 // static void  glowGetShaderiv(GPGETSHADERIV fnptr, GLuint  shader, GLenum  pname, GLint * params) {
 //   (*fnptr)(shader, pname, params);
 // }
+// static const GLchar *  glowGetString(GPGETSTRING fnptr, GLenum  name) {
+//   return (*fnptr)(name);
+// }
 // static GLint  glowGetUniformLocation(GPGETUNIFORMLOCATION fnptr, GLuint  program, const GLchar * name) {
 //   return (*fnptr)(program, name);
 // }
@@ -338,6 +363,7 @@ import (
 type defaultContext struct {
 	gpActiveTexture              C.GPACTIVETEXTURE
 	gpAttachShader               C.GPATTACHSHADER
+	gpBeginQuery                 C.GPBEGINQUERY
 	gpBindAttribLocation         C.GPBINDATTRIBLOCATION
 	gpBindBuffer                 C.GPBINDBUFFER
 	gpBindFramebufferEXT         C.GPBINDFRAMEBUFFEREXT
@@ -356,6 +382,7 @@ type defaultContext struct {
 	gpDeleteBuffers              C.GPDELETEBUFFERS
 	gpDeleteFramebuffersEXT      C.GPDELETEFRAMEBUFFERSEXT
 	gpDeleteProgram              C.GPDELETEPROGRAM
+	gpDeleteQueries              C.GPDELETEQUERIES
 	gpDeleteRenderbuffersEXT     C.GPDELETERENDERBUFFERSEXT
 	gpDeleteShader               C.GPDELETESHADER
 	gpDeleteTextures             C.GPDELETETEXTURES
@@ -364,19 +391,23 @@ type defaultContext struct {
 	gpDrawElements               C.GPDRAWELEMENTS
 	gpEnable                     C.GPENABLE
 	gpEnableVertexAttribArray    C.GPENABLEVERTEXATTRIBARRAY
+	gpEndQuery                   C.GPENDQUERY
 	gpFlush                      C.GPFLUSH
 	gpFramebufferRenderbufferEXT C.GPFRAMEBUFFERRENDERBUFFEREXT
 	gpFramebufferTexture2DEXT    C.GPFRAMEBUFFERTEXTURE2DEXT
 	gpGenBuffers                 C.GPGENBUFFERS
 	gpGenFramebuffersEXT         C.GPGENFRAMEBUFFERSEXT
+	gpGenQueries                 C.GPGENQUERIES
 	gpGenRenderbuffersEXT        C.GPGENRENDERBUFFERSEXT
 	gpGenTextures                C.GPGENTEXTURES
 	gpGetError                   C.GPGETERROR
 	gpGetIntegerv                C.GPGETINTEGERV
 	gpGetProgramInfoLog          C.GPGETPROGRAMINFOLOG
 	gpGetProgramiv               C.GPGETPROGRAMIV
+	gpGetQueryObjectui64v        C.GPGETQUERYOBJECTUI64V
 	gpGetShaderInfoLog           C.GPGETSHADERINFOLOG
 	gpGetShaderiv                C.GPGETSHADERIV
+	gpGetString                  C.GPGETSTRING
 	gpGetUniformLocation         C.GPGETUNIFORMLOCATION
 	gpIsFramebufferEXT           C.GPISFRAMEBUFFEREXT
 	gpIsProgram                  C.GPISPROGRAM
@@ -409,7 +440,8 @@ type defaultContext struct {
 	gpVertexAttribPointer        C.GPVERTEXATTRIBPOINTER
 	gpViewport                   C.GPVIEWPORT
 
-	isES bool
+	isES                bool
+	timerQueryAvailable bool
 }
 
 func NewDefaultContext() (Context, error) {
@@ -431,6 +463,10 @@ func (c *defaultContext) IsES() bool {
 	return c.isES
 }
 
+func (c *defaultContext) IsTimerQueryAvailable() bool {
+	return c.timerQueryAvailable
+}
+
 func (c *defaultContext) ActiveTexture(texture uint32) {
 	C.glowActiveTexture(c.gpActiveTexture, (C.GLenum)(texture))
 }
@@ -439,6 +475,10 @@ func (c *defaultContext) AttachShader(program uint32, shader uint32) {
 	C.glowAttachShader(c.gpAttachShader, (C.GLuint)(program), (C.GLuint)(shader))
 }
 
+func (c *defaultContext) BeginQuery(target uint32, query uint32) {
+	C.glowBeginQuery(c.gpBeginQuery, (C.GLenum)(target), (C.GLuint)(query))
+}
+
 func (c *defaultContext) BindAttribLocation(program uint32, index uint32, name string) {
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
@@ -512,6 +552,12 @@ func (c *defaultContext) CreateProgram() uint32 {
 	return uint32(ret)
 }
 
+func (c *defaultContext) CreateQuery() uint32 {
+	var query uint32
+	C.glowGenQueries(c.gpGenQueries, 1, (*C.GLuint)(unsafe.Pointer(&query)))
+	return query
+}
+
 func (c *defaultContext) CreateRenderbuffer() uint32 {
 	var renderbuffer uint32
 	C.glowGenRenderbuffersEXT(c.gpGenRenderbuffersEXT, 1, (*C.GLuint)(unsafe.Pointer(&renderbuffer)))
@@ -541,6 +587,10 @@ func (c *defaultContext) DeleteProgram(program uint32) {
 	C.glowDeleteProgram(c.gpDeleteProgram, (C.GLuint)(program))
 }
 
+func (c *defaultContext) DeleteQuery(query uint32) {
+	C.glowDeleteQueries(c.gpDeleteQueries, 1, (*C.GLuint)(unsafe.Pointer(&query)))
+}
+
 func (c *defaultContext) DeleteRenderbuffer(renderbuffer uint32) {
 	C.glowDeleteRenderbuffersEXT(c.gpDeleteRenderbuffersEXT, 1, (*C.GLuint)(unsafe.Pointer(&renderbuffer)))
 }
@@ -573,6 +623,10 @@ func (c *defaultContext) EnableVertexAttribArray(index uint32) {
 	C.glowEnableVertexAttribArray(c.gpEnableVertexAttribArray, (C.GLuint)(index))
 }
 
+func (c *defaultContext) EndQuery(target uint32) {
+	C.glowEndQuery(c.gpEndQuery, (C.GLenum)(target))
+}
+
 func (c *defaultContext) Flush() {
 	C.glowFlush(c.gpFlush)
 }
@@ -609,6 +663,18 @@ func (c *defaultContext) GetProgrami(program uint32, pname uint32) int {
 	return int(dst)
 }
 
+func (c *defaultContext) GetQueryObjectui64(query uint32, pname uint32) uint64 {
+	var dst uint64
+	C.glowGetQueryObjectui64v(c.gpGetQueryObjectui64v, (C.GLuint)(query), (C.GLenum)(pname), (*C.GLuint64)(unsafe.Pointer(&dst)))
+	if c.isES && pname == QUERY_RESULT {
+		// When a disjoint operation happens, the result is not reliable.
+		if c.GetInteger(GPU_DISJOINT) != 0 {
+			return 0
+		}
+	}
+	return dst
+}
+
 func (c *defaultContext) GetShaderInfoLog(shader uint32) string {
 	bufSize := c.GetShaderi(shader, INFO_LOG_LENGTH)
 	infoLog := make([]byte, bufSize)
@@ -622,6 +688,14 @@ func (c *defaultContext) GetShaderi(shader uint32, pname uint32) int {
 	return int(dst)
 }
 
+func (c *defaultContext) GetString(pname uint32) string {
+	ret := C.glowGetString(c.gpGetString, (C.GLenum)(pname))
+	if ret == nil {
+		return ""
+	}
+	return C.GoString(ret)
+}
+
 func (c *defaultContext) GetUniformLocation(program uint32, name string) int32 {
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
@@ -781,6 +855,7 @@ func (c *defaultContext) LoadFunctions() error {
 	if c.gpAttachShader == nil {
 		return errors.New("gl: glAttachShader is missing")
 	}
+	c.gpBeginQuery = (C.GPBEGINQUERY)(c.getProcAddress("glBeginQuery"))
 	c.gpBindAttribLocation = (C.GPBINDATTRIBLOCATION)(c.getProcAddress("glBindAttribLocation"))
 	if c.gpBindAttribLocation == nil {
 		return errors.New("gl: glBindAttribLocation is missing")
@@ -841,6 +916,7 @@ func (c *defaultContext) LoadFunctions() error {
 	if c.gpDeleteProgram == nil {
 		return errors.New("gl: glDeleteProgram is missing")
 	}
+	c.gpDeleteQueries = (C.GPDELETEQUERIES)(c.getProcAddress("glDeleteQueries"))
 	c.gpDeleteRenderbuffersEXT = (C.GPDELETERENDERBUFFERSEXT)(c.getProcAddress("glDeleteRenderbuffersEXT"))
 	c.gpDeleteShader = (C.GPDELETESHADER)(c.getProcAddress("glDeleteShader"))
 	if c.gpDeleteShader == nil {
@@ -870,6 +946,7 @@ func (c *defaultContext) LoadFunctions() error {
 	if c.gpEnableVertexAttribArray == nil {
 		return errors.New("gl: glEnableVertexAttribArray is missing")
 	}
+	c.gpEndQuery = (C.GPENDQUERY)(c.getProcAddress("glEndQuery"))
 	c.gpFlush = (C.GPFLUSH)(c.getProcAddress("glFlush"))
 	if c.gpFlush == nil {
 		return errors.New("gl: glFlush is missing")
@@ -881,6 +958,7 @@ func (c *defaultContext) LoadFunctions() error {
 		return errors.New("gl: glGenBuffers is missing")
 	}
 	c.gpGenFramebuffersEXT = (C.GPGENFRAMEBUFFERSEXT)(c.getProcAddress("glGenFramebuffersEXT"))
+	c.gpGenQueries = (C.GPGENQUERIES)(c.getProcAddress("glGenQueries"))
 	c.gpGenRenderbuffersEXT = (C.GPGENRENDERBUFFERSEXT)(c.getProcAddress("glGenRenderbuffersEXT"))
 	c.gpGenTextures = (C.GPGENTEXTURES)(c.getProcAddress("glGenTextures"))
 	if c.gpGenTextures == nil {
@@ -902,6 +980,7 @@ func (c *defaultContext) LoadFunctions() error {
 	if c.gpGetProgramiv == nil {
 		return errors.New("gl: glGetProgramiv is missing")
 	}
+	c.gpGetQueryObjectui64v = (C.GPGETQUERYOBJECTUI64V)(c.getProcAddress("glGetQueryObjectui64v"))
 	c.gpGetShaderInfoLog = (C.GPGETSHADERINFOLOG)(c.getProcAddress("glGetShaderInfoLog"))
 	if c.gpGetShaderInfoLog == nil {
 		return errors.New("gl: glGetShaderInfoLog is missing")
@@ -910,6 +989,10 @@ func (c *defaultContext) LoadFunctions() error {
 	if c.gpGetShaderiv == nil {
 		return errors.New("gl: glGetShaderiv is missing")
 	}
+	c.gpGetString = (C.GPGETSTRING)(c.getProcAddress("glGetString"))
+	if c.gpGetString == nil {
+		return errors.New("gl: glGetString is missing")
+	}
 	c.gpGetUniformLocation = (C.GPGETUNIFORMLOCATION)(c.getProcAddress("glGetUniformLocation"))
 	if c.gpGetUniformLocation == nil {
 		return errors.New("gl: glGetUniformLocation is missing")
@@ -1025,5 +1108,25 @@ func (c *defaultContext) LoadFunctions() error {
 	if c.gpViewport == nil {
 		return errors.New("gl: glViewport is missing")
 	}
+
+	// On OpenGL ES, the query functions might be available only with the EXT suffix.
+	if c.isES {
+		if c.gpBeginQuery == nil {
+			c.gpBeginQuery = (C.GPBEGINQUERY)(c.getProcAddress("glBeginQueryEXT"))
+		}
+		if c.gpDeleteQueries == nil {
+			c.gpDeleteQueries = (C.GPDELETEQUERIES)(c.getProcAddress("glDeleteQueriesEXT"))
+		}
+		if c.gpEndQuery == nil {
+			c.gpEndQuery = (C.GPENDQUERY)(c.getProcAddress("glEndQueryEXT"))
+		}
+		if c.gpGenQueries == nil {
+			c.gpGenQueries = (C.GPGENQUERIES)(c.getProcAddress("glGenQueriesEXT"))
+		}
+		if c.gpGetQueryObjectui64v == nil {
+			c.gpGetQueryObjectui64v = (C.GPGETQUERYOBJECTUI64V)(c.getProcAddress("glGetQueryObjectui64vEXT"))
+		}
+	}
+	c.timerQueryAvailable = c.gpBeginQuery != nil && c.gpDeleteQueries != nil && c.gpEndQuery != nil && c.gpGenQueries != nil && c.gpGetQueryObjectui64v != nil && isTimerQuerySupported(c)
 	return nil
 }
@@ -16,6 +16,7 @@ package gl
 
 import (
 	"fmt"
+	"strings"
 	"syscall/js"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/jsutil"
@@ -24,6 +25,7 @@ import (
 type defaultContext struct {
 	fnActiveTexture            js.Value
 	fnAttachShader             js.Value
+	fnBeginQuery               js.Value
 	fnBindAttribLocation       js.Value
 	fnBindBuffer               js.Value
 	fnBindFramebuffer          js.Value
@@ -40,12 +42,14 @@ type defaultContext struct {
 	fnCreateBuffer             js.Value
 	fnCreateFramebuffer        js.Value
 	fnCreateProgram            js.Value
+	fnCreateQuery              js.Value
 	fnCreateRenderbuffer       js.Value
 	fnCreateShader             js.Value
 	fnCreateTexture            js.Value
 	fnDeleteBuffer             js.Value
 	fnDeleteFramebuffer        js.Value
 	fnDeleteProgram            js.Value
+	fnDeleteQuery              js.Value
 	fnDeleteRenderbuffer       js.Value
 	fnDeleteShader             js.Value
 	fnDeleteTexture            js.Value
@@ -54,6 +58,7 @@ type defaultContext struct {
 	fnDrawElements             js.Value
 	fnEnable                   js.Value
 	fnEnableVertexAttribArray  js.Value
+	fnEndQuery                 js.Value
 	fnFramebufferRenderbuffer  js.Value
 	fnFramebufferTexture2D     js.Value
 	fnFlush                    js.Value
@@ -61,8 +66,10 @@ type defaultContext struct {
 	fnGetParameter             js.Value
 	fnGetProgramInfoLog        js.Value
 	fnGetProgramParameter      js.Value
+	fnGetQueryParameter        js.Value
 	fnGetShaderInfoLog         js.Value
 	fnGetShaderParameter       js.Value
+	fnGetSupportedExtensions   js.Value
 	fnGetUniformLocation       js.Value
 	fnIsFramebuffer            js.Value
 	fnIsProgram                js.Value
@@ -98,8 +105,12 @@ type defaultContext struct {
 
 	webGL2 bool
 
+	// timerQueryExt is EXT_disjoint_timer_query_webgl2, or undefined if the extension is not available.
+	timerQueryExt js.Value
+
 	buffers          values
 	framebuffers     values
+	queries          values
 	programs         values
 	renderbuffers    values
 	shaders          values
@@ -192,6 +203,7 @@ func NewDefaultContext(v js.Value) (Context, error) {
 		fnGetProgramParameter:      v.Get("getProgramParameter").Call("bind", v),
 		fnGetShaderInfoLog:         v.Get("getShaderInfoLog").Call("bind", v),
 		fnGetShaderParameter:       v.Get("getShaderParameter").Call("bind", v),
+		fnGetSupportedExtensions:   v.Get("getSupportedExtensions").Call("bind", v),
 		fnGetUniformLocation:       v.Get("getUniformLocation").Call("bind", v),
 		fnIsFramebuffer:            v.Get("isFramebuffer").Call("bind", v),
 		fnIsProgram:                v.Get("isProgram").Call("bind", v),
@@ -230,6 +242,18 @@ func NewDefaultContext(v js.Value) (Context, error) {
 		g.webGL2 = v.InstanceOf(webGL2)
 	}
 
+	// The query functions and EXT_disjoint_timer_query_webgl2 are available only on WebGL 2.
+	if g.webGL2 {
+		g.fnBeginQuery = v.Get("beginQuery").Call("bind", v)
+		g.fnCreateQuery = v.Get("createQuery").Call("bind", v)
+		g.fnDeleteQuery = v.Get("deleteQuery").Call("bind", v)
+		g.fnEndQuery = v.Get("endQuery").Call("bind", v)
+		g.fnGetQueryParameter = v.Get("getQueryParameter").Call("bind", v)
+		if ext := v.Call("getExtension", "EXT_disjoint_timer_query_webgl2"); ext.Truthy() {
+			g.timerQueryExt = ext
+		}
+	}
+
 	return g, nil
 }
 
@@ -247,6 +271,10 @@ func (c *defaultContext) IsES() bool {
 	return true
 }
 
+func (c *defaultContext) IsTimerQueryAvailable() bool {
+	return c.timerQueryExt.Truthy()
+}
+
 func (c *defaultContext) ActiveTexture(texture uint32) {
 	c.fnActiveTexture.Invoke(texture)
 }
@@ -255,6 +283,10 @@ func (c *defaultContext) AttachShader(program uint32, shader uint32) {
 	c.fnAttachShader.Invoke(c.programs.get(program), c.shaders.get(shader))
 }
 
+func (c *defaultContext) BeginQuery(target uint32, query uint32) {
+	c.fnBeginQuery.Invoke(target, c.queries.get(query))
+}
+
 func (c *defaultContext) BindAttribLocation(program uint32, index uint32, name string) {
 	c.fnBindAttribLocation.Invoke(c.programs.get(program), index, name)
 }
@@ -325,6 +357,10 @@ func (c *defaultContext) CreateProgram() uint32 {
 	return c.programs.create(c.fnCreateProgram.Invoke())
 }
 
+func (c *defaultContext) CreateQuery() uint32 {
+	return c.queries.create(c.fnCreateQuery.Invoke())
+}
+
 func (c *defaultContext) CreateRenderbuffer() uint32 {
 	return c.renderbuffers.create(c.fnCreateRenderbuffer.Invoke())
 }
@@ -353,6 +389,11 @@ func (c *defaultContext) DeleteProgram(program uint32) {
 	delete(c.uniformLocations, program)
 }
 
+func (c *defaultContext) DeleteQuery(query uint32) {
+	c.fnDeleteQuery.Invoke(c.queries.get(query))
+	c.queries.delete(query)
+}
+
 func (c *defaultContext) DeleteRenderbuffer(renderbuffer uint32) {
 	c.fnDeleteRenderbuffer.Invoke(c.renderbuffers.get(renderbuffer))
 	c.renderbuffers.delete(renderbuffer)
@@ -388,6 +429,10 @@ func (c *defaultContext) EnableVertexAttribArray(index uint32) {
 	c.fnEnableVertexAttribArray.Invoke(index)
 }
 
+func (c *defaultContext) EndQuery(target uint32) {
+	c.fnEndQuery.Invoke(target)
+}
+
 func (c *defaultContext) Flush() {
 	c.fnFlush.Invoke()
 }
@@ -439,6 +484,27 @@ func (c *defaultContext) GetProgrami(program uint32, pname uint32) int {
 	}
 }
 
+func (c *defaultContext) GetQueryObjectui64(query uint32, pname uint32) uint64 {
+	v := c.fnGetQueryParameter.Invoke(c.queries.get(query), pname)
+	switch v.Type() {
+	case js.TypeNumber:
+		if pname == QUERY_RESULT {
+			// When a disjoint operation happens, the result is not reliable.
+			if c.fnGetParameter.Invoke(c.timerQueryExt.Get("GPU_DISJOINT_EXT")).Bool() {
+				return 0
+			}
+		}
+		return uint64(v.Float())
+	case js.TypeBoolean:
+		if v.Bool() {
+			return TRUE
+		}
+		return FALSE
+	default:
+		panic(fmt.Sprintf("gl: unexpected return type at GetQueryObjectui64: %v", v))
+	}
+}
+
 func (c *defaultContext) GetShaderInfoLog(shader uint32) string {
 	return c.fnGetShaderInfoLog.Invoke(c.shaders.get(shader)).String()
 }
@@ -459,6 +525,22 @@ func (c *defaultContext) GetShaderi(shader uint32, pname uint32) int {
 
 }
 
+func (c *defaultContext) GetString(pname uint32) string {
+	switch pname {
+	case EXTENSIONS:
+		// WebGL doesn't have the extension string. Emulate it with the supported extensions.
+		// Note that an extension must be enabled by getExtension before using it.
+		var names []string
+		exts := c.fnGetSupportedExtensions.Invoke()
+		for i := 0; i < exts.Length(); i++ {
+			names = append(names, exts.Index(i).String())
+		}
+		return strings.Join(names, " ")
+	default:
+		return c.fnGetParameter.Invoke(pname).String()
+	}
+}
+
 func (c *defaultContext) GetUniformLocation(program uint32, name string) int32 {
 	location := c.fnGetUniformLocation.Invoke(c.programs.get(program), name)
 	if c.uniformLocations == nil {
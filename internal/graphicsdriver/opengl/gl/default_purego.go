@@ -27,6 +27,7 @@ import (
 type defaultContext struct {
 	gpActiveTexture              uintptr
 	gpAttachShader               uintptr
+	gpBeginQuery                 uintptr
 	gpBindAttribLocation         uintptr
 	gpBindBuffer                 uintptr
 	gpBindFramebufferEXT         uintptr
@@ -45,6 +46,7 @@ type defaultContext struct {
 	gpDeleteBuffers              uintptr
 	gpDeleteFramebuffersEXT      uintptr
 	gpDeleteProgram              uintptr
+	gpDeleteQueries              uintptr
 	gpDeleteRenderbuffersEXT     uintptr
 	gpDeleteShader               uintptr
 	gpDeleteTextures             uintptr
@@ -53,19 +55,23 @@ type defaultContext struct {
 	gpDrawElements               uintptr
 	gpEnable                     uintptr
 	gpEnableVertexAttribArray    uintptr
+	gpEndQuery                   uintptr
 	gpFlush                      uintptr
 	gpFramebufferRenderbufferEXT uintptr
 	gpFramebufferTexture2DEXT    uintptr
 	gpGenBuffers                 uintptr
 	gpGenFramebuffersEXT         uintptr
+	gpGenQueries                 uintptr
 	gpGenRenderbuffersEXT        uintptr
 	gpGenTextures                uintptr
 	gpGetError                   uintptr
 	gpGetIntegerv                uintptr
 	gpGetProgramInfoLog          uintptr
 	gpGetProgramiv               uintptr
+	gpGetQueryObjectui64v        uintptr
 	gpGetShaderInfoLog           uintptr
 	gpGetShaderiv                uintptr
+	gpGetString                  uintptr
 	gpGetUniformLocation         uintptr
 	gpIsFramebufferEXT           uintptr
 	gpIsProgram                  uintptr
@@ -98,7 +104,8 @@ type defaultContext struct {
 	gpVertexAttribPointer        uintptr
 	gpViewport                   uintptr
 
-	isES bool
+	isES                bool
+	timerQueryAvailable bool
 }
 
 func NewDefaultContext() (Context, error) {
@@ -120,6 +127,10 @@ func (c *defaultContext) IsES() bool {
 	return c.isES
 }
 
+func (c *defaultContext) IsTimerQueryAvailable() bool {
+	return c.timerQueryAvailable
+}
+
 func (c *defaultContext) ActiveTexture(texture uint32) {
 	purego.SyscallN(c.gpActiveTexture, uintptr(texture))
 }
@@ -128,6 +139,10 @@ func (c *defaultContext) AttachShader(program uint32, shader uint32) {
 	purego.SyscallN(c.gpAttachShader, uintptr(program), uintptr(shader))
 }
 
+func (c *defaultContext) BeginQuery(target uint32, query uint32) {
+	purego.SyscallN(c.gpBeginQuery, uintptr(target), uintptr(query))
+}
+
 func (c *defaultContext) BindAttribLocation(program uint32, index uint32, name string) {
 	cname, free := cStr(name)
 	defer free()
@@ -201,6 +216,12 @@ func (c *defaultContext) CreateProgram() uint32 {
 	return uint32(ret)
 }
 
+func (c *defaultContext) CreateQuery() uint32 {
+	var query uint32
+	purego.SyscallN(c.gpGenQueries, 1, uintptr(unsafe.Pointer(&query)))
+	return query
+}
+
 func (c *defaultContext) CreateRenderbuffer() uint32 {
 	var renderbuffer uint32
 	purego.SyscallN(c.gpGenRenderbuffersEXT, 1, uintptr(unsafe.Pointer(&renderbuffer)))
@@ -230,6 +251,10 @@ func (c *defaultContext) DeleteProgram(program uint32) {
 	purego.SyscallN(c.gpDeleteProgram, uintptr(program))
 }
 
+func (c *defaultContext) DeleteQuery(query uint32) {
+	purego.SyscallN(c.gpDeleteQueries, 1, uintptr(unsafe.Pointer(&query)))
+}
+
 func (c *defaultContext) DeleteRenderbuffer(renderbuffer uint32) {
 	purego.SyscallN(c.gpDeleteRenderbuffersEXT, 1, uintptr(unsafe.Pointer(&renderbuffer)))
 }
@@ -262,6 +287,10 @@ func (c *defaultContext) EnableVertexAttribArray(index uint32) {
 	purego.SyscallN(c.gpEnableVertexAttribArray, uintptr(index))
 }
 
+func (c *defaultContext) EndQuery(target uint32) {
+	purego.SyscallN(c.gpEndQuery, uintptr(target))
+}
+
 func (c *defaultContext) Flush() {
 	purego.SyscallN(c.gpFlush)
 }
@@ -298,6 +327,18 @@ func (c *defaultContext) GetProgrami(program uint32, pname uint32) int {
 	return int(dst)
 }
 
+func (c *defaultContext) GetQueryObjectui64(query uint32, pname uint32) uint64 {
+	var dst uint64
+	purego.SyscallN(c.gpGetQueryObjectui64v, uintptr(query), uintptr(pname), uintptr(unsafe.Pointer(&dst)))
+	if c.isES && pname == QUERY_RESULT {
+		// When a disjoint operation happens, the result is not reliable.
+		if c.GetInteger(GPU_DISJOINT) != 0 {
+			return 0
+		}
+	}
+	return dst
+}
+
 func (c *defaultContext) GetShaderInfoLog(shader uint32) string {
 	bufSize := c.GetShaderi(shader, INFO_LOG_LENGTH)
 	infoLog := make([]byte, bufSize)
@@ -311,6 +352,11 @@ func (c *defaultContext) GetShaderi(shader uint32, pname uint32) int {
 	return int(dst)
 }
 
+func (c *defaultContext) GetString(pname uint32) string {
+	ret, _, _ := purego.SyscallN(c.gpGetString, uintptr(pname))
+	return goStr(ret)
+}
+
 func (c *defaultContext) GetUniformLocation(program uint32, name string) int32 {
 	cname, free := cStr(name)
 	defer free()
@@ -470,6 +516,7 @@ func (c *defaultContext) LoadFunctions() error {
 	if c.gpAttachShader == 0 {
 		return errors.New("gl: glAttachShader is missing")
 	}
+	c.gpBeginQuery = c.getProcAddress("glBeginQuery")
 	c.gpBindAttribLocation = c.getProcAddress("glBindAttribLocation")
 	if c.gpBindAttribLocation == 0 {
 		return errors.New("gl: glBindAttribLocation is missing")
@@ -530,6 +577,7 @@ func (c *defaultContext) LoadFunctions() error {
 	if c.gpDeleteProgram == 0 {
 		return errors.New("gl: glDeleteProgram is missing")
 	}
+	c.gpDeleteQueries = c.getProcAddress("glDeleteQueries")
 	c.gpDeleteRenderbuffersEXT = c.getProcAddress("glDeleteRenderbuffersEXT")
 	c.gpDeleteShader = c.getProcAddress("glDeleteShader")
 	if c.gpDeleteShader == 0 {
@@ -559,6 +607,7 @@ func (c *defaultContext) LoadFunctions() error {
 	if c.gpEnableVertexAttribArray == 0 {
 		return errors.New("gl: glEnableVertexAttribArray is missing")
 	}
+	c.gpEndQuery = c.getProcAddress("glEndQuery")
 	c.gpFlush = c.getProcAddress("glFlush")
 	if c.gpFlush == 0 {
 		return errors.New("gl: glFlush is missing")
@@ -570,6 +619,7 @@ func (c *defaultContext) LoadFunctions() error {
 		return errors.New("gl: glGenBuffers is missing")
 	}
 	c.gpGenFramebuffersEXT = c.getProcAddress("glGenFramebuffersEXT")
+	c.gpGenQueries = c.getProcAddress("glGenQueries")
 	c.gpGenRenderbuffersEXT = c.getProcAddress("glGenRenderbuffersEXT")
 	c.gpGenTextures = c.getProcAddress("glGenTextures")
 	if c.gpGenTextures == 0 {
@@ -591,6 +641,7 @@ func (c *defaultContext) LoadFunctions() error {
 	if c.gpGetProgramiv == 0 {
 		return errors.New("gl: glGetProgramiv is missing")
 	}
+	c.gpGetQueryObjectui64v = c.getProcAddress("glGetQueryObjectui64v")
 	c.gpGetShaderInfoLog = c.getProcAddress("glGetShaderInfoLog")
 	if c.gpGetShaderInfoLog == 0 {
 		return errors.New("gl: glGetShaderInfoLog is missing")
@@ -599,6 +650,10 @@ func (c *defaultContext) LoadFunctions() error {
 	if c.gpGetShaderiv == 0 {
 		return errors.New("gl: glGetShaderiv is missing")
 	}
+	c.gpGetString = c.getProcAddress("glGetString")
+	if c.gpGetString == 0 {
+		return errors.New("gl: glGetString is missing")
+	}
 	c.gpGetUniformLocation = c.getProcAddress("glGetUniformLocation")
 	if c.gpGetUniformLocation == 0 {
 		return errors.New("gl: glGetUniformLocation is missing")
@@ -714,6 +769,26 @@ func (c *defaultContext) LoadFunctions() error {
 	if c.gpViewport == 0 {
 		return errors.New("gl: glViewport is missing")
 	}
+
+	// On OpenGL ES, the query functions might be available only with the EXT suffix.
+	if c.isES {
+		if c.gpBeginQuery == 0 {
+			c.gpBeginQuery = c.getProcAddress("glBeginQueryEXT")
+		}
+		if c.gpDeleteQueries == 0 {
+			c.gpDeleteQueries = c.getProcAddress("glDeleteQueriesEXT")
+		}
+		if c.gpEndQuery == 0 {
+			c.gpEndQuery = c.getProcAddress("glEndQueryEXT")
+		}
+		if c.gpGenQueries == 0 {
+			c.gpGenQueries = c.getProcAddress("glGenQueriesEXT")
+		}
+		if c.gpGetQueryObjectui64v == 0 {
+			c.gpGetQueryObjectui64v = c.getProcAddress("glGetQueryObjectui64vEXT")
+		}
+	}
+	c.timerQueryAvailable = c.gpBeginQuery != 0 && c.gpDeleteQueries != 0 && c.gpEndQuery != 0 && c.gpGenQueries != 0 && c.gpGetQueryObjectui64v != 0 && isTimerQuerySupported(c)
 	return nil
 }
 
@@ -732,3 +807,18 @@ func cStr(str string) (cstr *byte, free func()) {
 		bs = nil
 	}
 }
+
+// goStr takes a null-terminated string returned by OpenGL and returns the Go counterpart.
+// cstr is the address returned by a system call. The string is owned by OpenGL and is not managed by Go's GC.
+func goStr(cstr uintptr) string {
+	if cstr == 0 {
+		return ""
+	}
+	// Convert the address via its pointer, as converting a uintptr into an unsafe.Pointer directly is reported by go vet.
+	p := *(**byte)(unsafe.Pointer(&cstr))
+	var n int
+	for *(*byte)(unsafe.Add(unsafe.Pointer(p), n)) != 0 {
+		n++
+	}
+	return string(unsafe.Slice(p, n))
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gl
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Version returns the major and minor version of OpenGL or OpenGL ES.
+// Version returns (0, 0) if the version string cannot be parsed.
+func Version(ctx Context) (major, minor int) {
+	return parseVersion(ctx.GetString(VERSION))
+}
+
+// parseVersion parses a version string like "4.6.0 NVIDIA 525.60.11", "OpenGL ES 3.2 Mesa 22.0.1" or
+// "WebGL 2.0 (OpenGL ES 3.0 Chromium)".
+func parseVersion(str string) (major, minor int) {
+	for _, prefix := range []string{"OpenGL ES-CM ", "OpenGL ES-CL ", "OpenGL ES ", "WebGL "} {
+		if strings.HasPrefix(str, prefix) {
+			str = str[len(prefix):]
+			break
+		}
+	}
+	if f := strings.Fields(str); len(f) > 0 {
+		str = f[0]
+	}
+	ns := strings.Split(str, ".")
+	if len(ns) < 2 {
+		return 0, 0
+	}
+	major, err := strconv.Atoi(ns[0])
+	if err != nil {
+		return 0, 0
+	}
+	minor, err = strconv.Atoi(ns[1])
+	if err != nil {
+		return 0, 0
+	}
+	return major, minor
+}
+
+// HasExtension reports whether the extension string includes the given extension name.
+//
+// HasExtension must not be used with a core profile of OpenGL 3.0 or later, where the extension string is not available.
+func HasExtension(ctx Context, name string) bool {
+	for _, ext := range strings.Fields(ctx.GetString(EXTENSIONS)) {
+		if ext == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isTimerQuerySupported reports whether timer queries are supported by the version or the extensions.
+func isTimerQuerySupported(ctx Context) bool {
+	if ctx.IsES() {
+		return HasExtension(ctx, "GL_EXT_disjoint_timer_query")
+	}
+	major, minor := Version(ctx)
+	if major > 3 || (major == 3 && minor >= 3) {
+		return true
+	}
+	// The extension string is not available with a core profile of OpenGL 3.2.
+	// There is no way to confirm ARB_timer_query without glGetStringi in this case.
+	if major < 3 {
+		return HasExtension(ctx, "GL_ARB_timer_query")
+	}
+	return false
+}
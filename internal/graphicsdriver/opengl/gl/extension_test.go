@@ -0,0 +1,46 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gl_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver/opengl/gl"
+)
+
+func TestParseVersion(t *testing.T) {
+	cases := []struct {
+		In    string
+		Major int
+		Minor int
+	}{
+		{"4.6.0 NVIDIA 525.60.11", 4, 6},
+		{"2.1 Metal - 83", 2, 1},
+		{"3.3 (Core Profile) Mesa 22.0.1", 3, 3},
+		{"OpenGL ES 3.2 Mesa 22.0.1", 3, 2},
+		{"OpenGL ES 2.0 (ANGLE 2.1.0)", 2, 0},
+		{"OpenGL ES-CM 1.1", 1, 1},
+		{"WebGL 1.0 (OpenGL ES 2.0 Chromium)", 1, 0},
+		{"WebGL 2.0", 2, 0},
+		{"", 0, 0},
+		{"invalid", 0, 0},
+	}
+	for _, c := range cases {
+		major, minor := gl.ParseVersionForTesting(c.In)
+		if major != c.Major || minor != c.Minor {
+			t.Errorf("parseVersion(%q): got: (%d, %d), want: (%d, %d)", c.In, major, minor, c.Major, c.Minor)
+		}
+	}
+}
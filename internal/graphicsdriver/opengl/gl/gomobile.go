@@ -43,6 +43,11 @@ func (g *gomobileContext) IsES() bool {
 	return true
 }
 
+func (g *gomobileContext) IsTimerQueryAvailable() bool {
+	// gomobile doesn't have query functions.
+	return false
+}
+
 func (g *gomobileContext) ActiveTexture(texture uint32) {
 	g.ctx.ActiveTexture(gl.Enum(texture))
 }
@@ -51,6 +56,10 @@ func (g *gomobileContext) AttachShader(program uint32, shader uint32) {
 	g.ctx.AttachShader(gmProgram(program), gl.Shader{Value: shader})
 }
 
+func (g *gomobileContext) BeginQuery(target uint32, query uint32) {
+	panic("gl: BeginQuery is not implemented")
+}
+
 func (g *gomobileContext) BindAttribLocation(program uint32, index uint32, name string) {
 	g.ctx.BindAttribLocation(gmProgram(program), gl.Attrib{Value: uint(index)}, name)
 }
@@ -115,6 +124,10 @@ func (g *gomobileContext) CreateProgram() uint32 {
 	return g.ctx.CreateProgram().Value
 }
 
+func (g *gomobileContext) CreateQuery() uint32 {
+	panic("gl: CreateQuery is not implemented")
+}
+
 func (g *gomobileContext) CreateRenderbuffer() uint32 {
 	return g.ctx.CreateRenderbuffer().Value
 }
@@ -139,6 +152,10 @@ func (g *gomobileContext) DeleteProgram(program uint32) {
 	g.ctx.DeleteProgram(gmProgram(program))
 }
 
+func (g *gomobileContext) DeleteQuery(query uint32) {
+	panic("gl: DeleteQuery is not implemented")
+}
+
 func (g *gomobileContext) DeleteRenderbuffer(renderbuffer uint32) {
 	g.ctx.DeleteRenderbuffer(gl.Renderbuffer{Value: renderbuffer})
 }
@@ -171,6 +188,10 @@ func (g *gomobileContext) EnableVertexAttribArray(index uint32) {
 	g.ctx.EnableVertexAttribArray(gl.Attrib{Value: uint(index)})
 }
 
+func (g *gomobileContext) EndQuery(target uint32) {
+	panic("gl: EndQuery is not implemented")
+}
+
 func (g *gomobileContext) Flush() {
 	g.ctx.Flush()
 }
@@ -199,6 +220,10 @@ func (g *gomobileContext) GetProgrami(program uint32, pname uint32) int {
 	return g.ctx.GetProgrami(gmProgram(program), gl.Enum(pname))
 }
 
+func (g *gomobileContext) GetQueryObjectui64(query uint32, pname uint32) uint64 {
+	panic("gl: GetQueryObjectui64 is not implemented")
+}
+
 func (g *gomobileContext) GetShaderInfoLog(shader uint32) string {
 	return g.ctx.GetShaderInfoLog(gl.Shader{Value: shader})
 }
@@ -207,6 +232,10 @@ func (g *gomobileContext) GetShaderi(shader uint32, pname uint32) int {
 	return g.ctx.GetShaderi(gl.Shader{Value: shader}, gl.Enum(pname))
 }
 
+func (g *gomobileContext) GetString(pname uint32) string {
+	return g.ctx.GetString(gl.Enum(pname))
+}
+
 func (g *gomobileContext) GetUniformLocation(program uint32, name string) int32 {
 	return g.ctx.GetUniformLocation(gmProgram(program), name).Value
 }
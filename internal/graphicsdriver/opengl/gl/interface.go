@@ -21,9 +21,11 @@ package gl
 type Context interface {
 	LoadFunctions() error
 	IsES() bool
+	IsTimerQueryAvailable() bool
 
 	ActiveTexture(texture uint32)
 	AttachShader(program uint32, shader uint32)
+	BeginQuery(target uint32, query uint32)
 	BindAttribLocation(program uint32, index uint32, name string)
 	BindBuffer(target uint32, buffer uint32)
 	BindFramebuffer(target uint32, framebuffer uint32)
@@ -40,12 +42,14 @@ type Context interface {
 	CreateBuffer() uint32
 	CreateFramebuffer() uint32
 	CreateProgram() uint32
+	CreateQuery() uint32
 	CreateRenderbuffer() uint32
 	CreateShader(xtype uint32) uint32
 	CreateTexture() uint32
 	DeleteBuffer(buffer uint32)
 	DeleteFramebuffer(framebuffer uint32)
 	DeleteProgram(program uint32)
+	DeleteQuery(query uint32)
 	DeleteRenderbuffer(renderbuffer uint32)
 	DeleteShader(shader uint32)
 	DeleteTexture(textures uint32)
@@ -54,6 +58,7 @@ type Context interface {
 	DrawElements(mode uint32, count int32, xtype uint32, offset int)
 	Enable(cap uint32)
 	EnableVertexAttribArray(index uint32)
+	EndQuery(target uint32)
 	Flush()
 	FramebufferRenderbuffer(target uint32, attachment uint32, renderbuffertarget uint32, renderbuffer uint32)
 	FramebufferTexture2D(target uint32, attachment uint32, textarget uint32, texture uint32, level int32)
@@ -61,8 +66,10 @@ type Context interface {
 	GetInteger(pname uint32) int
 	GetProgramInfoLog(program uint32) string
 	GetProgrami(program uint32, pname uint32) int
+	GetQueryObjectui64(query uint32, pname uint32) uint64
 	GetShaderInfoLog(shader uint32) string
 	GetShaderi(shader uint32, pname uint32) int
+	GetString(pname uint32) string
 	GetUniformLocation(program uint32, name string) int32
 	IsFramebuffer(framebuffer uint32) bool
 	IsProgram(program uint32) bool
@@ -17,6 +17,7 @@ package opengl
 import (
 	"fmt"
 	"runtime"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
@@ -50,10 +51,12 @@ type Graphics struct {
 	// activatedTextures is a set of activated textures.
 	// textureNative cannot be a map key unfortunately.
 	activatedTextures []activatedTexture
+
+	timerQuery timerQuery
 }
 
 func (g *Graphics) Begin() error {
-	// Do nothing.
+	g.timerQuery.begin(g.context.ctx)
 	return nil
 }
 
@@ -65,10 +68,19 @@ func (g *Graphics) End(present bool) error {
 	// The last uniforms must be reset after swapping the buffer (#2517).
 	if present {
 		g.state.resetLastUniforms()
+		g.timerQuery.end(g.context.ctx)
 	}
 	return nil
 }
 
+// GPUFrameTime returns the last measured GPU time of a frame.
+// GPUFrameTime returns false if the GPU time cannot be measured.
+//
+// GPUFrameTime is concurrent-safe.
+func (g *Graphics) GPUFrameTime() (time.Duration, bool) {
+	return g.timerQuery.elapsed()
+}
+
 func (g *Graphics) SetTransparent(transparent bool) {
 	// Do nothing.
 }
@@ -179,6 +191,9 @@ func (g *Graphics) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphics.
 	if shaderID == graphicsdriver.InvalidShaderID {
 		return fmt.Errorf("opengl: shader ID is invalid")
 	}
+	if !g.context.blendMinMaxAvailable && (isBlendOperationMinMax(blend.BlendOperationRGB) || isBlendOperationMinMax(blend.BlendOperationAlpha)) {
+		return fmt.Errorf("opengl: BlendOperationMin and BlendOperationMax are not available on this environment")
+	}
 
 	destination := g.images[dstID]
 
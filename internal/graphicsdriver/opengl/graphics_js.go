@@ -33,6 +33,7 @@ func NewGraphics(canvas js.Value) (graphicsdriver.Graphics, error) {
 	attr.Set("alpha", true)
 	attr.Set("premultipliedAlpha", true)
 	attr.Set("stencil", true)
+	setContextAttributesFromEnv(attr)
 
 	var webGL2 bool
 	if webGL2MightBeAvailable() {
@@ -65,6 +66,8 @@ func NewGraphics(canvas js.Value) (graphicsdriver.Graphics, error) {
 
 	if !webGL2 {
 		glContext.Call("getExtension", "OES_standard_derivatives")
+		// MIN and MAX blend equations are available with this extension on WebGL 1.
+		g.context.blendMinMaxExtension = glContext.Call("getExtension", "EXT_blend_minmax").Truthy()
 	}
 
 	return g, nil
@@ -80,3 +83,23 @@ func webGL2MightBeAvailable() bool {
 	}
 	return js.Global().Get("WebGL2RenderingContext").Truthy()
 }
+
+// setContextAttributesFromEnv sets the optional context attributes specified by the environment variable.
+// alpha and premultipliedAlpha cannot be changed as Ebitengine's rendering result is always premultiplied-alpha.
+func setContextAttributesFromEnv(attr js.Value) {
+	env := os.Getenv("EBITENGINE_OPENGL")
+	for _, t := range strings.Split(env, ",") {
+		switch strings.TrimSpace(t) {
+		case "antialias":
+			attr.Set("antialias", true)
+		case "preservedrawingbuffer":
+			attr.Set("preserveDrawingBuffer", true)
+		case "desynchronized":
+			attr.Set("desynchronized", true)
+		case "lowpower":
+			attr.Set("powerPreference", "low-power")
+		case "highperformance":
+			attr.Set("powerPreference", "high-performance")
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengl
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver/opengl/gl"
+)
+
+// maxPendingTimerQueries is the maximum number of timer queries whose results are not available yet.
+// If the number of pending queries reaches this, a new query is not issued until a result becomes available.
+const maxPendingTimerQueries = 8
+
+// timerQuery measures the GPU time of frames with timer queries asynchronously.
+type timerQuery struct {
+	active  uint32
+	pending []uint32
+	unused  []uint32
+
+	// available is 1 when timer queries are available, and 0 otherwise.
+	// available must be accessed atomically.
+	available int32
+
+	// lastElapsed is the last measured GPU time in nanoseconds.
+	// lastElapsed must be accessed atomically.
+	lastElapsed int64
+}
+
+// begin starts measuring the GPU time of the current frame if a measurement is not in progress.
+func (t *timerQuery) begin(ctx gl.Context) {
+	if !ctx.IsTimerQueryAvailable() {
+		return
+	}
+	atomic.StoreInt32(&t.available, 1)
+
+	if t.active != 0 {
+		return
+	}
+
+	// Collect the results in the order of the queries.
+	for len(t.pending) > 0 {
+		q := t.pending[0]
+		if ctx.GetQueryObjectui64(q, gl.QUERY_RESULT_AVAILABLE) == gl.FALSE {
+			break
+		}
+		if elapsed := ctx.GetQueryObjectui64(q, gl.QUERY_RESULT); elapsed > 0 {
+			atomic.StoreInt64(&t.lastElapsed, int64(elapsed))
+		}
+		t.pending = t.pending[1:]
+		t.unused = append(t.unused, q)
+	}
+
+	if len(t.pending) >= maxPendingTimerQueries {
+		return
+	}
+
+	var q uint32
+	if len(t.unused) > 0 {
+		q = t.unused[len(t.unused)-1]
+		t.unused = t.unused[:len(t.unused)-1]
+	} else {
+		q = ctx.CreateQuery()
+	}
+	ctx.BeginQuery(gl.TIME_ELAPSED, q)
+	t.active = q
+}
+
+// end ends measuring the GPU time of the current frame.
+func (t *timerQuery) end(ctx gl.Context) {
+	if t.active == 0 {
+		return
+	}
+	ctx.EndQuery(gl.TIME_ELAPSED)
+	t.pending = append(t.pending, t.active)
+	t.active = 0
+}
+
+// elapsed returns the last measured GPU time.
+// elapsed returns false if timer queries are not available.
+//
+// elapsed is concurrent-safe.
+func (t *timerQuery) elapsed() (time.Duration, bool) {
+	if atomic.LoadInt32(&t.available) == 0 {
+		return 0, false
+	}
+	return time.Duration(atomic.LoadInt64(&t.lastElapsed)), true
+}
@@ -56,6 +56,14 @@ func (m *Mipmap) DumpScreenshot(graphicsDriver graphicsdriver.Graphics, name str
 	return m.orig.DumpScreenshot(graphicsDriver, name, blackbg)
 }
 
+func (m *Mipmap) AtlasID() int {
+	return m.orig.AtlasID()
+}
+
+func (m *Mipmap) NeedsTiling() bool {
+	return m.orig.NeedsTiling()
+}
+
 func (m *Mipmap) WritePixels(pix []byte, x, y, width, height int) {
 	m.orig.WritePixels(pix, x, y, width, height)
 	m.disposeMipmaps()
@@ -176,8 +184,8 @@ func (m *Mipmap) level(level int) *buffered.Image {
 		m.setImg(level, nil)
 		return nil
 	}
-	// buffered.NewImage panics with a too big size when actual allocation happens.
-	// 4096 should be a safe size in most environments (#1399).
+	// A too big image is split into multiple textures when actual allocation happens, which is too expensive for
+	// a mipmap. 4096 should be a safe size in most environments (#1399).
 	// Unfortunately a precise max image size cannot be obtained here since this requires GPU access.
 	if w2 > 4096 || h2 > 4096 {
 		m.setImg(level, nil)
@@ -118,6 +118,9 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 		return nil
 	}
 
+	s, ox, oy := c.screenScaleAndOffsets()
+	theSafeAreaInsets.updateLogicalInsets(s, ox, oy, deviceScaleFactor)
+
 	// Ensure that Update is called once before Draw so that Update can be used for initialization.
 	if !c.updateCalled && updateCount == 0 {
 		updateCount = 1
@@ -130,6 +133,7 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 		// Read the input state and use it for one tick to give a consistent result for one tick (#2496, #2501).
 		c.game.UpdateInputState(func(inputState *InputState) {
 			ui.readInputState(inputState)
+			runInputStateHook(inputState)
 		})
 
 		if err := hooks.RunBeforeUpdateHooks(); err != nil {
@@ -261,6 +265,11 @@ func (c *context) clientPositionToLogicalPosition(x, y float64, deviceScaleFacto
 	return (x*deviceScaleFactor - ox) / s, (y*deviceScaleFactor - oy) / s
 }
 
+func (c *context) logicalPositionToClientPosition(x, y float64, deviceScaleFactor float64) (float64, float64) {
+	s, ox, oy := c.screenScaleAndOffsets()
+	return (x*s + ox) / deviceScaleFactor, (y*s + oy) / deviceScaleFactor
+}
+
 func (c *context) screenScaleAndOffsets() (scale, offsetX, offsetY float64) {
 	scaleX := c.screenWidth / c.offscreenWidth
 	scaleY := c.screenHeight / c.offscreenHeight
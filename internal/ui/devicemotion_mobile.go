@@ -0,0 +1,63 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build (android || ios) && !nintendosdk
+
+package ui
+
+import (
+	"time"
+
+	"golang.org/x/mobile/exp/sensor"
+)
+
+type sensorSender struct {
+	ui *userInterfaceImpl
+}
+
+func (s *sensorSender) Send(event any) {
+	e, ok := event.(sensor.Event)
+	if !ok || len(e.Data) < 3 {
+		return
+	}
+
+	s.ui.m.Lock()
+	defer s.ui.m.Unlock()
+
+	switch e.Sensor {
+	case sensor.Accelerometer:
+		s.ui.inputState.DeviceMotion.AccelerationX = e.Data[0] * accelerationScale
+		s.ui.inputState.DeviceMotion.AccelerationY = e.Data[1] * accelerationScale
+		s.ui.inputState.DeviceMotion.AccelerationZ = e.Data[2] * accelerationScale
+	case sensor.Gyroscope:
+		// Both Android and iOS report the rotation rates in rad/s.
+		s.ui.inputState.DeviceMotion.RotationRateX = e.Data[0]
+		s.ui.inputState.DeviceMotion.RotationRateY = e.Data[1]
+		s.ui.inputState.DeviceMotion.RotationRateZ = e.Data[2]
+	default:
+		return
+	}
+
+	// Some devices have only one of the sensors.
+	s.ui.inputState.DeviceMotionAvailable = true
+}
+
+// startMotionSensors starts to receive the events from the motion sensors.
+func (u *userInterfaceImpl) startMotionSensors() {
+	sensor.Notify(&sensorSender{ui: u})
+
+	// The sensors might not be available on the device. Ignore the errors.
+	_ = sensor.Enable(sensor.Accelerometer, time.Second/60)
+	_ = sensor.Enable(sensor.Gyroscope, time.Second/60)
+}
@@ -170,6 +170,20 @@ func (i *Image) ReadPixels(pixels []byte, x, y, width, height int) {
 	}
 }
 
+func (i *Image) AtlasID() int {
+	if i.mipmap == nil {
+		return -1
+	}
+	return i.mipmap.AtlasID()
+}
+
+func (i *Image) NeedsTiling() bool {
+	if i.mipmap == nil {
+		return false
+	}
+	return i.mipmap.NeedsTiling()
+}
+
 func (i *Image) DumpScreenshot(name string, blackbg bool) (string, error) {
 	i.flushBufferIfNeeded()
 	return theUI.dumpScreenshot(i.mipmap, name, blackbg)
@@ -38,6 +38,37 @@ type Touch struct {
 	Y  int
 }
 
+// DeviceMotion represents the state of a device's motion sensors.
+type DeviceMotion struct {
+	// AccelerationX, AccelerationY and AccelerationZ are in m/s^2, including the gravity.
+	AccelerationX float64
+	AccelerationY float64
+	AccelerationZ float64
+
+	// RotationRateX, RotationRateY and RotationRateZ are in rad/s.
+	RotationRateX float64
+	RotationRateY float64
+	RotationRateZ float64
+}
+
+// Pen represents the state of a pen (stylus).
+type Pen struct {
+	// X and Y are the position in the logical screen coordinate.
+	X float64
+	Y float64
+
+	// Pressure is the pressure in [0, 1].
+	Pressure float64
+
+	// TiltX and TiltY are the tilts in radians.
+	TiltX float64
+	TiltY float64
+
+	Touching            bool
+	BarrelButtonPressed bool
+	Eraser              bool
+}
+
 type InputState struct {
 	KeyPressed         [KeyMax + 1]bool
 	MouseButtonPressed [MouseButtonMax + 1]bool
@@ -49,6 +80,12 @@ type InputState struct {
 	Runes              []rune
 	WindowBeingClosed  bool
 	DroppedFiles       fs.FS
+
+	DeviceMotion          DeviceMotion
+	DeviceMotionAvailable bool
+
+	Pen          Pen
+	PenAvailable bool
 }
 
 func (i *InputState) copyAndReset(dst *InputState) {
@@ -62,6 +99,10 @@ func (i *InputState) copyAndReset(dst *InputState) {
 	dst.Runes = append(dst.Runes[:0], i.Runes...)
 	dst.WindowBeingClosed = i.WindowBeingClosed
 	dst.DroppedFiles = i.DroppedFiles
+	dst.DeviceMotion = i.DeviceMotion
+	dst.DeviceMotionAvailable = i.DeviceMotionAvailable
+	dst.Pen = i.Pen
+	dst.PenAvailable = i.PenAvailable
 
 	// Reset the members that are updated by deltas, rather than absolute values.
 	i.WheelX = 0
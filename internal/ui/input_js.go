@@ -15,6 +15,7 @@
 package ui
 
 import (
+	"math"
 	"syscall/js"
 	"unicode"
 )
@@ -74,6 +75,67 @@ func (u *userInterfaceImpl) mouseUp(code int) {
 	u.inputState.MouseButtonPressed[codeToMouseButton[code]] = false
 }
 
+func (u *userInterfaceImpl) updateDeviceMotionFromEvent(e js.Value) {
+	// The properties can be null when the device doesn't have the sensors.
+	a := e.Get("accelerationIncludingGravity")
+	if !a.Truthy() || a.Get("x").Type() != js.TypeNumber {
+		return
+	}
+	u.inputState.DeviceMotion.AccelerationX = a.Get("x").Float()
+	u.inputState.DeviceMotion.AccelerationY = a.Get("y").Float()
+	u.inputState.DeviceMotion.AccelerationZ = a.Get("z").Float()
+
+	// rotationRate's values are in deg/s.
+	// alpha, beta and gamma are the rotations around the Z, X and Y axes respectively.
+	if r := e.Get("rotationRate"); r.Truthy() && r.Get("alpha").Type() == js.TypeNumber {
+		u.inputState.DeviceMotion.RotationRateX = r.Get("beta").Float() * math.Pi / 180
+		u.inputState.DeviceMotion.RotationRateY = r.Get("gamma").Float() * math.Pi / 180
+		u.inputState.DeviceMotion.RotationRateZ = r.Get("alpha").Float() * math.Pi / 180
+	}
+
+	u.inputState.DeviceMotionAvailable = true
+}
+
+var (
+	stringPen           = js.ValueOf("pen")
+	stringPointerup     = js.ValueOf("pointerup")
+	stringPointercancel = js.ValueOf("pointercancel")
+)
+
+func (u *userInterfaceImpl) updatePenFromEvent(e js.Value) {
+	if !e.Get("pointerType").Equal(stringPen) {
+		return
+	}
+	if u.context == nil {
+		return
+	}
+
+	x, y := u.context.clientPositionToLogicalPosition(e.Get("clientX").Float(), e.Get("clientY").Float(), u.DeviceScaleFactor())
+	u.inputState.Pen.X = x
+	u.inputState.Pen.Y = y
+
+	// tiltX and tiltY are in degrees.
+	u.inputState.Pen.TiltX = e.Get("tiltX").Float() * math.Pi / 180
+	u.inputState.Pen.TiltY = e.Get("tiltY").Float() * math.Pi / 180
+
+	// buttons is a bit mask: 1 for the tip contact, 2 for the barrel button and 32 for the eraser.
+	buttons := e.Get("buttons").Int()
+	if t := e.Get("type"); t.Equal(stringPointerup) || t.Equal(stringPointercancel) {
+		buttons = 0
+	}
+	u.inputState.Pen.Touching = buttons&(1|32) != 0
+	u.inputState.Pen.BarrelButtonPressed = buttons&2 != 0
+	u.inputState.Pen.Eraser = buttons&32 != 0
+	if u.inputState.Pen.Touching {
+		u.inputState.Pen.Pressure = e.Get("pressure").Float()
+	} else {
+		u.inputState.Pen.Pressure = 0
+	}
+
+	u.inputState.PenAvailable = true
+	u.forceUpdateOnMinimumFPSMode()
+}
+
 func (u *userInterfaceImpl) updateInputFromEvent(e js.Value) error {
 	// Avoid using js.Value.String() as String creates a Uint8Array via a TextEncoder and causes a heavy
 	// overhead (#1437).
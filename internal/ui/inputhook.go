@@ -0,0 +1,43 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"sync"
+)
+
+var (
+	inputStateHook  func(*InputState)
+	inputStateHookM sync.Mutex
+)
+
+// SetInputStateHook sets a function that is called with the input state every tick.
+// The function is called after the input state is read from the platform and before the game's Update is called,
+// and it can modify the input state.
+//
+// The function must not call any input functions like ebiten.IsKeyPressed, or it causes a deadlock.
+func SetInputStateHook(f func(*InputState)) {
+	inputStateHookM.Lock()
+	defer inputStateHookM.Unlock()
+	inputStateHook = f
+}
+
+func runInputStateHook(inputState *InputState) {
+	inputStateHookM.Lock()
+	defer inputStateHookM.Unlock()
+	if inputStateHook != nil {
+		inputStateHook(inputState)
+	}
+}
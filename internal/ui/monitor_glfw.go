@@ -0,0 +1,213 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !android && !ios && !js && !nintendosdk && !ebitengineheadless
+
+package ui
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/devicescale"
+	"github.com/hajimehoshi/ebiten/v2/internal/glfw"
+	"github.com/hajimehoshi/ebiten/v2/internal/microsoftgdk"
+)
+
+// Monitor is a wrapper around glfw.Monitor.
+//
+// Monitor is immutable. A new Monitor is created when the monitor configuration changes.
+type Monitor struct {
+	m  *glfw.Monitor
+	vm *glfw.VidMode
+	// Pos of monitor in virtual coords
+	x int
+	y int
+
+	boundsInDIP       image.Rectangle
+	name              string
+	deviceScaleFactor float64
+}
+
+// Bounds returns the monitor's bounds in device-independent pixels.
+func (m *Monitor) Bounds() image.Rectangle {
+	return m.boundsInDIP
+}
+
+// Name returns the monitor's name.
+func (m *Monitor) Name() string {
+	return m.name
+}
+
+// DeviceScaleFactor returns the monitor's device scale factor.
+func (m *Monitor) DeviceScaleFactor() float64 {
+	return m.deviceScaleFactor
+}
+
+// monitors is the monitor list cache for desktop glfw compile targets.
+// populated by 'updateMonitors' which is called on init and every
+// monitor config change event.
+//
+// monitors must be manipulated on the main thread.
+var monitors []*Monitor
+
+func updateMonitors() {
+	// Clear the caches first as the monitors' scales are calculated below.
+	clearVideoModeScaleCache()
+	devicescale.ClearCache()
+
+	monitors = nil
+	ms := glfw.GetMonitors()
+	for _, m := range ms {
+		if m == nil {
+			continue
+		}
+		x, y := m.GetPos()
+		vm := m.GetVideoMode()
+		bx := int(theUI.dipFromGLFWMonitorPixel(float64(x), m))
+		by := int(theUI.dipFromGLFWMonitorPixel(float64(y), m))
+		bw := int(theUI.dipFromGLFWMonitorPixel(float64(vm.Width), m))
+		bh := int(theUI.dipFromGLFWMonitorPixel(float64(vm.Height), m))
+		monitors = append(monitors, &Monitor{
+			m:                 m,
+			vm:                vm,
+			x:                 x,
+			y:                 y,
+			boundsInDIP:       image.Rect(bx, by, bx+bw, by+bh),
+			name:              m.GetName(),
+			deviceScaleFactor: theUI.deviceScaleFactor(m),
+		})
+	}
+}
+
+func ensureMonitors() []*Monitor {
+	if len(monitors) == 0 {
+		updateMonitors()
+	}
+	return monitors
+}
+
+// getMonitorFromPosition returns a monitor for the given window x/y,
+// or returns nil if monitor is not found.
+//
+// getMonitorFromPosition must be called on the main thread.
+func getMonitorFromPosition(wx, wy int) *Monitor {
+	for _, m := range ensureMonitors() {
+		// TODO: Fix incorrectness in the cases of https://github.com/glfw/glfw/issues/1961.
+		// See also internal/devicescale/impl_desktop.go for a maybe better way of doing this.
+		if m.x <= wx && wx < m.x+m.vm.Width && m.y <= wy && wy < m.y+m.vm.Height {
+			return m
+		}
+	}
+	return nil
+}
+
+// getMonitorFromGLFWMonitor returns a Monitor for the given GLFW monitor,
+// or returns nil if monitor is not found.
+//
+// getMonitorFromGLFWMonitor must be called on the main thread.
+func getMonitorFromGLFWMonitor(monitor *glfw.Monitor) *Monitor {
+	for _, m := range ensureMonitors() {
+		if m.m == monitor {
+			return m
+		}
+	}
+	return nil
+}
+
+func (u *userInterfaceImpl) AppendMonitors(mons []*Monitor) []*Monitor {
+	if !u.isRunning() {
+		return append(mons, ensureMonitors()...)
+	}
+	u.mainThread.Call(func() {
+		mons = append(mons, ensureMonitors()...)
+	})
+	return mons
+}
+
+func (u *userInterfaceImpl) Monitor() *Monitor {
+	if !u.isRunning() {
+		return getMonitorFromGLFWMonitor(u.initMonitor)
+	}
+	var monitor *Monitor
+	u.mainThread.Call(func() {
+		monitor = getMonitorFromGLFWMonitor(u.currentMonitor())
+	})
+	return monitor
+}
+
+func (u *userInterfaceImpl) SetMonitor(monitor *Monitor) {
+	if monitor == nil {
+		panic("ui: monitor must not be nil at SetMonitor")
+	}
+	if !u.isRunning() {
+		u.setInitMonitor(monitor.m)
+		return
+	}
+	u.mainThread.Call(func() {
+		u.setWindowMonitor(monitor)
+	})
+}
+
+// setWindowMonitor moves the window to the given monitor with keeping the relative position in the monitor.
+//
+// setWindowMonitor must be called from the main thread.
+func (u *userInterfaceImpl) setWindowMonitor(monitor *Monitor) {
+	if microsoftgdk.IsXbox() {
+		// Do nothing. The monitor is always fixed.
+		return
+	}
+
+	// The monitor might be disconnected.
+	if getMonitorFromGLFWMonitor(monitor.m) == nil {
+		return
+	}
+
+	current := u.currentMonitor()
+	if current == monitor.m {
+		return
+	}
+
+	fullscreen := u.isFullscreen()
+	if fullscreen {
+		u.setFullscreen(false)
+	}
+
+	wx, wy := u.window.GetPos()
+	mx, my := current.GetPos()
+	x := int(u.dipFromGLFWPixel(float64(wx-mx), current))
+	y := int(u.dipFromGLFWPixel(float64(wy-my), current))
+
+	// Keep the window in the new monitor.
+	w, h := u.origWindowWidthInDIP, u.origWindowHeightInDIP
+	if max := monitor.boundsInDIP.Dx() - w; x >= max {
+		x = max
+	}
+	if max := monitor.boundsInDIP.Dy() - h; y >= max {
+		y = max
+	}
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+
+	// The position must be set before the size is set (#1982).
+	u.setWindowPositionInDIP(x, y, monitor.m)
+	u.setWindowSizeInDIP(w, h, true)
+
+	if fullscreen {
+		u.setFullscreen(true)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build android || ios || js || nintendosdk || ebitengineheadless
+
+package ui
+
+import (
+	"image"
+)
+
+// Monitor represents the only screen on browsers, mobiles and consoles.
+type Monitor struct{}
+
+var theMonitor = &Monitor{}
+
+// Bounds returns the monitor's bounds in device-independent pixels.
+func (m *Monitor) Bounds() image.Rectangle {
+	w, h := theUI.ScreenSizeInFullscreen()
+	return image.Rect(0, 0, w, h)
+}
+
+// Name returns the monitor's name.
+func (m *Monitor) Name() string {
+	return ""
+}
+
+// DeviceScaleFactor returns the monitor's device scale factor.
+func (m *Monitor) DeviceScaleFactor() float64 {
+	return theUI.DeviceScaleFactor()
+}
+
+func (u *userInterfaceImpl) AppendMonitors(mons []*Monitor) []*Monitor {
+	return append(mons, theMonitor)
+}
+
+func (u *userInterfaceImpl) Monitor() *Monitor {
+	return theMonitor
+}
+
+func (u *userInterfaceImpl) SetMonitor(monitor *Monitor) {
+	// Do nothing.
+}
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !android && !ios && !js && !nintendosdk && !ebitenginesinglethread && !ebitensinglethread
+//go:build !android && !ios && !js && !nintendosdk && !ebitenginesinglethread && !ebitensinglethread && !ebitengineheadless
 
 package ui
 
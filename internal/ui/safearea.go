@@ -0,0 +1,77 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"math"
+	"sync"
+)
+
+// safeAreaInsets is the insets of the area not covered by display cutouts or system bars.
+// The values in device-independent pixels are updated by the platform-specific code. On desktops, the values are
+// always zero.
+type safeAreaInsets struct {
+	top    float64
+	right  float64
+	bottom float64
+	left   float64
+
+	// logicalTop, logicalRight, logicalBottom, and logicalLeft are the insets in the logical coordinates of
+	// the game screen. They are updated every frame after the layout is determined.
+	logicalTop    float64
+	logicalRight  float64
+	logicalBottom float64
+	logicalLeft   float64
+
+	m sync.Mutex
+}
+
+var theSafeAreaInsets safeAreaInsets
+
+// SetSafeAreaInsets sets the safe area insets in device-independent pixels.
+func (u *UserInterface) SetSafeAreaInsets(top, right, bottom, left float64) {
+	theSafeAreaInsets.m.Lock()
+	defer theSafeAreaInsets.m.Unlock()
+	theSafeAreaInsets.top = top
+	theSafeAreaInsets.right = right
+	theSafeAreaInsets.bottom = bottom
+	theSafeAreaInsets.left = left
+}
+
+// SafeAreaInsets returns the safe area insets in the logical coordinates of the game screen.
+func (u *UserInterface) SafeAreaInsets() (top, right, bottom, left float64) {
+	theSafeAreaInsets.m.Lock()
+	defer theSafeAreaInsets.m.Unlock()
+	return theSafeAreaInsets.logicalTop, theSafeAreaInsets.logicalRight, theSafeAreaInsets.logicalBottom, theSafeAreaInsets.logicalLeft
+}
+
+// updateLogicalInsets converts the insets into the logical coordinates in the same way as cursor positions.
+// As the game screen is centered in the window, the margins around the screen are subtracted from the insets.
+func (s *safeAreaInsets) updateLogicalInsets(scale, offsetX, offsetY float64, deviceScaleFactor float64) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if scale == 0 {
+		s.logicalTop, s.logicalRight, s.logicalBottom, s.logicalLeft = 0, 0, 0, 0
+		return
+	}
+	toLogical := func(v, offset float64) float64 {
+		return math.Max(0, (v*deviceScaleFactor-offset)/scale)
+	}
+	s.logicalTop = toLogical(s.top, offsetY)
+	s.logicalRight = toLogical(s.right, offsetX)
+	s.logicalBottom = toLogical(s.bottom, offsetY)
+	s.logicalLeft = toLogical(s.left, offsetX)
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+type ScreenOrientation int
+
+const (
+	ScreenOrientationUnspecified ScreenOrientation = iota
+	ScreenOrientationPortrait
+	ScreenOrientationLandscape
+)
+
+func screenOrientationFromSize(width, height float64) ScreenOrientation {
+	if width <= 0 || height <= 0 {
+		return ScreenOrientationUnspecified
+	}
+	if width < height {
+		return ScreenOrientationPortrait
+	}
+	return ScreenOrientationLandscape
+}
@@ -16,6 +16,7 @@ package ui
 
 import (
 	"errors"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
 	"github.com/hajimehoshi/ebiten/v2/internal/mipmap"
@@ -76,6 +77,18 @@ func (u *UserInterface) readPixels(mipmap *mipmap.Mipmap, pixels []byte, x, y, w
 	return mipmap.ReadPixels(u.graphicsDriver, pixels, x, y, width, height)
 }
 
+// GPUFrameTime returns the last measured GPU time of a frame.
+// GPUFrameTime returns false if the graphics driver cannot measure the GPU time.
+func (u *UserInterface) GPUFrameTime() (time.Duration, bool) {
+	g, ok := u.graphicsDriver.(interface {
+		GPUFrameTime() (time.Duration, bool)
+	})
+	if !ok {
+		return 0, false
+	}
+	return g.GPUFrameTime()
+}
+
 func (u *UserInterface) dumpScreenshot(mipmap *mipmap.Mipmap, name string, blackbg bool) (string, error) {
 	return mipmap.DumpScreenshot(u.graphicsDriver, name, blackbg)
 }
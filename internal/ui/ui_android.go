@@ -41,3 +41,7 @@ func (*graphicsDriverCreatorImpl) newDirectX() (graphicsdriver.Graphics, error)
 func (*graphicsDriverCreatorImpl) newMetal() (graphicsdriver.Graphics, error) {
 	return nil, nil
 }
+
+// accelerationScale is the scale to convert the accelerometer's values into m/s^2.
+// Android already reports the values in m/s^2.
+const accelerationScale = 1
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !android && !ios && !js && !nintendosdk
+//go:build !android && !ios && !js && !nintendosdk && !ebitengineheadless
 
 package ui
 
@@ -75,7 +75,7 @@ type userInterfaceImpl struct {
 
 	lastDeviceScaleFactor float64
 
-	// These values are not changed after initialized.
+	// These values are not changed after the main loop starts.
 	// TODO: the fullscreen size should be updated when the initial window position is changed?
 	initMonitor               *glfw.Monitor
 	initDeviceScaleFactor     float64
@@ -181,13 +181,7 @@ func initialize() error {
 		return errors.New("ui: no monitor was found at initialize")
 	}
 
-	theUI.initMonitor = m
-	theUI.initDeviceScaleFactor = theUI.deviceScaleFactor(m)
-	// GetVideoMode must be called from the main thread, then call this here and record
-	// initFullscreen{Width,Height}InDIP.
-	v := m.GetVideoMode()
-	theUI.initFullscreenWidthInDIP = int(theUI.dipFromGLFWMonitorPixel(float64(v.Width), m))
-	theUI.initFullscreenHeightInDIP = int(theUI.dipFromGLFWMonitorPixel(float64(v.Height), m))
+	theUI.setInitMonitor(m)
 
 	// Create system cursors. These cursors are destroyed at glfw.Terminate().
 	glfwSystemCursors[CursorShapeDefault] = nil
@@ -200,57 +194,17 @@ func initialize() error {
 	return nil
 }
 
-type monitor struct {
-	m  *glfw.Monitor
-	vm *glfw.VidMode
-	// Pos of monitor in virtual coords
-	x int
-	y int
-}
-
-// monitors is the monitor list cache for desktop glfw compile targets.
-// populated by 'updateMonitors' which is called on init and every
-// monitor config change event.
-//
-// monitors must be manipulated on the main thread.
-var monitors []*monitor
-
-func updateMonitors() {
-	monitors = nil
-	ms := glfw.GetMonitors()
-	for _, m := range ms {
-		x, y := m.GetPos()
-		monitors = append(monitors, &monitor{
-			m:  m,
-			vm: m.GetVideoMode(),
-			x:  x,
-			y:  y,
-		})
-	}
-	clearVideoModeScaleCache()
-	devicescale.ClearCache()
-}
-
-func ensureMonitors() []*monitor {
-	if len(monitors) == 0 {
-		updateMonitors()
-	}
-	return monitors
-}
-
-// getMonitorFromPosition returns a monitor for the given window x/y,
-// or returns nil if monitor is not found.
+// setInitMonitor sets the initial monitor and the values depending on the monitor.
 //
-// getMonitorFromPosition must be called on the main thread.
-func getMonitorFromPosition(wx, wy int) *monitor {
-	for _, m := range ensureMonitors() {
-		// TODO: Fix incorrectness in the cases of https://github.com/glfw/glfw/issues/1961.
-		// See also internal/devicescale/impl_desktop.go for a maybe better way of doing this.
-		if m.x <= wx && wx < m.x+m.vm.Width && m.y <= wy && wy < m.y+m.vm.Height {
-			return m
-		}
-	}
-	return nil
+// setInitMonitor must be called from the main thread before the main loop starts.
+func (u *userInterfaceImpl) setInitMonitor(m *glfw.Monitor) {
+	u.initMonitor = m
+	u.initDeviceScaleFactor = u.deviceScaleFactor(m)
+	// GetVideoMode must be called from the main thread, then call this here and record
+	// initFullscreen{Width,Height}InDIP.
+	v := m.GetVideoMode()
+	u.initFullscreenWidthInDIP = int(u.dipFromGLFWMonitorPixel(float64(v.Width), m))
+	u.initFullscreenHeightInDIP = int(u.dipFromGLFWMonitorPixel(float64(v.Height), m))
 }
 
 func (u *userInterfaceImpl) isRunning() bool {
@@ -491,6 +445,19 @@ func (u *userInterfaceImpl) ScreenSizeInFullscreen() (int, int) {
 	return w, h
 }
 
+func (u *userInterfaceImpl) ScreenOrientation() ScreenOrientation {
+	w, h := u.ScreenSizeInFullscreen()
+	return screenOrientationFromSize(float64(w), float64(h))
+}
+
+func (u *userInterfaceImpl) SetScreenOrientationLock(orientation ScreenOrientation) {
+	// Do nothing.
+}
+
+func (u *userInterfaceImpl) SetScreenKeepAwake(keepAwake bool) {
+	// Do nothing.
+}
+
 // isFullscreen must be called from the main thread.
 func (u *userInterfaceImpl) isFullscreen() bool {
 	if !u.isRunning() {
@@ -0,0 +1,183 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !android && !ios && !js && !nintendosdk && ebitengineheadless
+
+package ui
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver/null"
+	"github.com/hajimehoshi/ebiten/v2/internal/thread"
+)
+
+type graphicsDriverCreatorImpl struct{}
+
+func (g *graphicsDriverCreatorImpl) newAuto() (graphicsdriver.Graphics, GraphicsLibrary, error) {
+	return null.NewGraphics(), GraphicsLibraryUnknown, nil
+}
+
+func (*graphicsDriverCreatorImpl) newOpenGL() (graphicsdriver.Graphics, error) {
+	return nil, nil
+}
+
+func (*graphicsDriverCreatorImpl) newDirectX() (graphicsdriver.Graphics, error) {
+	return nil, nil
+}
+
+func (*graphicsDriverCreatorImpl) newMetal() (graphicsdriver.Graphics, error) {
+	return nil, nil
+}
+
+const (
+	deviceScaleFactor = 1
+
+	// The screen size in the headless mode is the same as the default window size on desktops.
+	headlessScreenWidth  = 640
+	headlessScreenHeight = 480
+)
+
+type userInterfaceImpl struct {
+	graphicsDriver graphicsdriver.Graphics
+
+	context    *context
+	inputState InputState
+	fpsMode    FPSModeType
+
+	m sync.Mutex
+}
+
+// Run runs the game without any window, GPU or input devices.
+// Draw is called every frame, but nothing is actually rendered.
+func (u *userInterfaceImpl) Run(game Game, options *RunOptions) error {
+	u.context = newContext(game)
+	g, err := newGraphicsDriver(&graphicsDriverCreatorImpl{}, GraphicsLibraryAuto)
+	if err != nil {
+		return err
+	}
+	u.graphicsDriver = g
+
+	graphicscommand.SetRenderThread(thread.NewNoopThread())
+
+	for {
+		t := time.Now()
+		if err := u.context.updateFrame(u.graphicsDriver, headlessScreenWidth, headlessScreenHeight, deviceScaleFactor, u); err != nil {
+			return err
+		}
+
+		// Emulate vsync so that the game doesn't consume CPU too much.
+		if u.fpsModeValue() == FPSModeVsyncOffMaximum {
+			continue
+		}
+		if d := time.Second/60 - time.Since(t); d > 0 {
+			time.Sleep(d)
+		}
+	}
+}
+
+func (*userInterfaceImpl) DeviceScaleFactor() float64 {
+	return deviceScaleFactor
+}
+
+func (*userInterfaceImpl) IsFocused() bool {
+	return true
+}
+
+func (*userInterfaceImpl) ScreenOrientation() ScreenOrientation {
+	return screenOrientationFromSize(headlessScreenWidth, headlessScreenHeight)
+}
+
+func (*userInterfaceImpl) SetScreenOrientationLock(orientation ScreenOrientation) {
+}
+
+func (*userInterfaceImpl) SetScreenKeepAwake(keepAwake bool) {
+}
+
+func (*userInterfaceImpl) ScreenSizeInFullscreen() (int, int) {
+	return headlessScreenWidth, headlessScreenHeight
+}
+
+func (u *userInterfaceImpl) readInputState(inputState *InputState) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.inputState.copyAndReset(inputState)
+}
+
+func (*userInterfaceImpl) CursorMode() CursorMode {
+	return CursorModeHidden
+}
+
+func (*userInterfaceImpl) SetCursorMode(mode CursorMode) {
+}
+
+func (*userInterfaceImpl) CursorShape() CursorShape {
+	return CursorShapeDefault
+}
+
+func (*userInterfaceImpl) SetCursorShape(shape CursorShape) {
+}
+
+func (*userInterfaceImpl) IsFullscreen() bool {
+	return false
+}
+
+func (*userInterfaceImpl) SetFullscreen(fullscreen bool) {
+}
+
+func (*userInterfaceImpl) IsRunnableOnUnfocused() bool {
+	return true
+}
+
+func (*userInterfaceImpl) SetRunnableOnUnfocused(runnableOnUnfocused bool) {
+}
+
+func (u *userInterfaceImpl) fpsModeValue() FPSModeType {
+	u.m.Lock()
+	defer u.m.Unlock()
+	return u.fpsMode
+}
+
+func (u *userInterfaceImpl) SetFPSMode(mode FPSModeType) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.fpsMode = mode
+}
+
+func (*userInterfaceImpl) ScheduleFrame() {
+}
+
+func (*userInterfaceImpl) Window() Window {
+	return &nullWindow{}
+}
+
+func (u *userInterfaceImpl) beginFrame() {
+}
+
+func (u *userInterfaceImpl) endFrame() {
+}
+
+func (u *userInterfaceImpl) updateIconIfNeeded() {
+}
+
+func IsScreenTransparentAvailable() bool {
+	return false
+}
+
+func KeyName(key Key) string {
+	return ""
+}
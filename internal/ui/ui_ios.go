@@ -86,3 +86,7 @@ func (u *userInterfaceImpl) isGL() (bool, error) {
 
 	return u.graphicsDriver.IsGL(), nil
 }
+
+// accelerationScale is the scale to convert the accelerometer's values into m/s^2.
+// iOS reports the values in G with the opposite sign to Android's, e.g., -1 for the Z axis when the device lies face up.
+const accelerationScale = -9.80665
@@ -94,6 +94,9 @@ type userInterfaceImpl struct {
 
 	keyboardLayoutMap js.Value
 
+	screenKeepAwake bool
+	wakeLock        js.Value
+
 	m         sync.Mutex
 	dropFileM sync.Mutex
 }
@@ -108,6 +111,8 @@ var (
 	window                = js.Global().Get("window")
 	document              = js.Global().Get("document")
 	canvas                js.Value
+	container             js.Value
+	safeAreaElement       js.Value
 	requestAnimationFrame = js.Global().Get("requestAnimationFrame")
 	setTimeout            = js.Global().Get("setTimeout")
 )
@@ -126,6 +131,119 @@ func (u *userInterfaceImpl) ScreenSizeInFullscreen() (int, int) {
 	return window.Get("innerWidth").Int(), window.Get("innerHeight").Int()
 }
 
+func (u *userInterfaceImpl) ScreenOrientation() ScreenOrientation {
+	if o := window.Get("screen").Get("orientation"); o.Truthy() {
+		switch o.Get("type").String() {
+		case "portrait-primary", "portrait-secondary":
+			return ScreenOrientationPortrait
+		case "landscape-primary", "landscape-secondary":
+			return ScreenOrientationLandscape
+		}
+	}
+	return screenOrientationFromSize(window.Get("innerWidth").Float(), window.Get("innerHeight").Float())
+}
+
+func (u *userInterfaceImpl) SetScreenOrientationLock(orientation ScreenOrientation) {
+	o := window.Get("screen").Get("orientation")
+	if !o.Truthy() || !o.Get("lock").Truthy() {
+		return
+	}
+
+	var v string
+	switch orientation {
+	case ScreenOrientationPortrait:
+		v = "portrait"
+	case ScreenOrientationLandscape:
+		v = "landscape"
+	default:
+		o.Call("unlock")
+		return
+	}
+
+	// lock returns a promise. An error happens e.g. when the document is not fullscreen.
+	o.Call("lock", v).Call("catch", js.FuncOf(func(this js.Value, args []js.Value) any {
+		js.Global().Get("console").Call("error", "screen.orientation.lock failed: most browsers require the fullscreen mode to lock the screen orientation.", args[0])
+		return nil
+	}))
+}
+
+func (u *userInterfaceImpl) SetScreenKeepAwake(keepAwake bool) {
+	if u.screenKeepAwake == keepAwake {
+		return
+	}
+	u.screenKeepAwake = keepAwake
+	if keepAwake {
+		u.requestWakeLock()
+		return
+	}
+	if u.wakeLock.Truthy() {
+		u.wakeLock.Call("release")
+		u.wakeLock = js.Undefined()
+	}
+}
+
+// requestWakeLock requests a screen wake lock with the Screen Wake Lock API.
+// A wake lock is released automatically when the document is hidden, so this must be called again when the document
+// becomes visible.
+func (u *userInterfaceImpl) requestWakeLock() {
+	wl := js.Global().Get("navigator").Get("wakeLock")
+	if !wl.Truthy() {
+		return
+	}
+	if document.Get("hidden").Bool() {
+		return
+	}
+
+	var then, catch js.Func
+	then = js.FuncOf(func(this js.Value, args []js.Value) any {
+		defer then.Release()
+		defer catch.Release()
+		// SetScreenKeepAwake(false) might be called before the promise is resolved.
+		if !u.screenKeepAwake {
+			args[0].Call("release")
+			return nil
+		}
+		u.wakeLock = args[0]
+		return nil
+	})
+	catch = js.FuncOf(func(this js.Value, args []js.Value) any {
+		defer then.Release()
+		defer catch.Release()
+		js.Global().Get("console").Call("error", "navigator.wakeLock.request failed:", args[0])
+		return nil
+	})
+	wl.Call("request", "screen").Call("then", then).Call("catch", catch)
+}
+
+// LogicalPositionToClientPosition converts the position in the game screen to the position in the browser's client area.
+//
+// LogicalPositionToClientPosition is used by exp/textinput to put the text element.
+func (u *userInterfaceImpl) LogicalPositionToClientPosition(x, y float64) (float64, float64) {
+	if u.context == nil || !canvas.Truthy() {
+		return x, y
+	}
+	x, y = u.context.logicalPositionToClientPosition(x, y, u.DeviceScaleFactor())
+	rect := canvas.Call("getBoundingClientRect")
+	return x + rect.Get("left").Float(), y + rect.Get("top").Float()
+}
+
+// UpdateInputFromEvent updates the input state from the given event.
+//
+// UpdateInputFromEvent is used by exp/textinput, whose text element takes the keyboard events instead of the canvas.
+func (u *userInterfaceImpl) UpdateInputFromEvent(e js.Value) {
+	if err := u.updateInputFromEvent(e); err != nil && u.err == nil {
+		u.err = err
+	}
+}
+
+// FocusCanvas focuses the canvas so that the canvas can take the keyboard events again.
+func (u *userInterfaceImpl) FocusCanvas() {
+	if !canvas.Truthy() {
+		return
+	}
+	canvas.Call("focus")
+}
+
 func (u *userInterfaceImpl) SetFullscreen(fullscreen bool) {
 	if !canvas.Truthy() {
 		return
@@ -245,16 +363,22 @@ func (u *userInterfaceImpl) DeviceScaleFactor() float64 {
 
 func (u *userInterfaceImpl) outsideSize() (float64, float64) {
 	if document.Truthy() {
-		body := document.Get("body")
-		bw := body.Get("clientWidth").Float()
-		bh := body.Get("clientHeight").Float()
-		return bw, bh
+		return containerSize()
 	}
 
 	// Node.js
 	return 640, 480
 }
 
+// containerSize returns the size of the area where the canvas is shown in CSS pixels.
+func containerSize() (float64, float64) {
+	// When the canvas is fullscreen, the canvas fills the screen regardless of its container.
+	if theUI.IsFullscreen() {
+		return window.Get("innerWidth").Float(), window.Get("innerHeight").Float()
+	}
+	return container.Get("clientWidth").Float(), container.Get("clientHeight").Float()
+}
+
 func (u *userInterfaceImpl) suspended() bool {
 	if u.runnableOnUnfocused {
 		return false
@@ -444,24 +568,59 @@ func init() {
 	canvas.Set("width", 16)
 	canvas.Set("height", 16)
 
-	document.Get("body").Call("appendChild", canvas)
+	// If an element with the data-ebitengine-container attribute exists, the canvas is put in the element and
+	// the page's styles are not modified. Otherwise, the canvas is put in the body and fills the page.
+	container = document.Call("querySelector", "[data-ebitengine-container]")
+	if container.Truthy() {
+		container.Call("appendChild", canvas)
+	} else {
+		container = document.Get("body")
+		container.Call("appendChild", canvas)
 
-	htmlStyle := document.Get("documentElement").Get("style")
-	htmlStyle.Set("height", "100%")
-	htmlStyle.Set("margin", "0")
-	htmlStyle.Set("padding", "0")
+		htmlStyle := document.Get("documentElement").Get("style")
+		htmlStyle.Set("height", "100%")
+		htmlStyle.Set("margin", "0")
+		htmlStyle.Set("padding", "0")
 
-	bodyStyle := document.Get("body").Get("style")
-	bodyStyle.Set("backgroundColor", "#000")
-	bodyStyle.Set("height", "100%")
-	bodyStyle.Set("margin", "0")
-	bodyStyle.Set("padding", "0")
+		bodyStyle := document.Get("body").Get("style")
+		bodyStyle.Set("backgroundColor", "#000")
+		bodyStyle.Set("height", "100%")
+		bodyStyle.Set("margin", "0")
+		bodyStyle.Set("padding", "0")
+	}
+
+	// An invisible element to read the safe area insets.
+	// This is put in the container so that the page outside the container is not modified.
+	safeAreaElement = document.Call("createElement", "div")
+	safeAreaStyle := safeAreaElement.Get("style")
+	safeAreaStyle.Set("position", "fixed")
+	safeAreaStyle.Set("visibility", "hidden")
+	safeAreaStyle.Set("pointerEvents", "none")
+	safeAreaStyle.Set("paddingTop", "env(safe-area-inset-top, 0px)")
+	safeAreaStyle.Set("paddingRight", "env(safe-area-inset-right, 0px)")
+	safeAreaStyle.Set("paddingBottom", "env(safe-area-inset-bottom, 0px)")
+	safeAreaStyle.Set("paddingLeft", "env(safe-area-inset-left, 0px)")
+	container.Call("appendChild", safeAreaElement)
+	updateSafeAreaInsets()
 
 	canvasStyle := canvas.Get("style")
 	canvasStyle.Set("width", "100%")
 	canvasStyle.Set("height", "100%")
 	canvasStyle.Set("margin", "0")
 	canvasStyle.Set("padding", "0")
+	// Disable the browser's touch gestures like scrolling and pinch-zooming on the canvas.
+	// The touch events are handled by Ebitengine instead.
+	canvasStyle.Set("touchAction", "none")
+
+	// A container's size can change without resizing the window.
+	if !container.Equal(document.Get("body")) {
+		if ro := js.Global().Get("ResizeObserver"); ro.Truthy() {
+			ro.New(js.FuncOf(func(this js.Value, args []js.Value) any {
+				theUI.updateScreenSize()
+				return nil
+			})).Call("observe", container)
+		}
+	}
 
 	// Make the canvas focusable.
 	canvas.Call("setAttribute", "tabindex", 1)
@@ -487,6 +646,14 @@ func init() {
 		js.Global().Get("console").Call("error", "pointerlockerror event is fired. 'sandbox=\"allow-pointer-lock\"' might be required at an iframe. This function on browsers must be called as a result of a gestural interaction or orientation change.")
 		return nil
 	}))
+	// Fullscreen
+	// The window's resize event is not always fired when the fullscreen state changes, e.g., in an iframe.
+	for _, name := range []string{"fullscreenchange", "webkitfullscreenchange"} {
+		document.Call("addEventListener", name, js.FuncOf(func(this js.Value, args []js.Value) any {
+			theUI.updateScreenSize()
+			return nil
+		}))
+	}
 	document.Call("addEventListener", "fullscreenerror", js.FuncOf(func(this js.Value, args []js.Value) any {
 		js.Global().Get("console").Call("error", "fullscreenerror event is fired. 'allow=\"fullscreen\"' or 'allowfullscreen' might be required at an iframe. This function on browsers must be called as a result of a gestural interaction or orientation change.")
 		return nil
@@ -495,6 +662,14 @@ func init() {
 		js.Global().Get("console").Call("error", "webkitfullscreenerror event is fired. 'allow=\"fullscreen\"' or 'allowfullscreen' might be required at an iframe. This function on browsers must be called as a result of a gestural interaction or orientation change.")
 		return nil
 	}))
+
+	// A wake lock is released when the document is hidden. Request it again when the document becomes visible.
+	document.Call("addEventListener", "visibilitychange", js.FuncOf(func(this js.Value, args []js.Value) any {
+		if theUI.screenKeepAwake && !document.Get("hidden").Bool() {
+			theUI.requestWakeLock()
+		}
+		return nil
+	}))
 }
 
 func setWindowEventHandlers(v js.Value) {
@@ -511,6 +686,13 @@ func setWindowEventHandlers(v js.Value) {
 		}()
 		return nil
 	}))
+
+	// Device motion
+	// On iOS Safari, DeviceMotionEvent.requestPermission must be called by a user gesture to receive the events.
+	v.Call("addEventListener", "devicemotion", js.FuncOf(func(this js.Value, args []js.Value) any {
+		theUI.updateDeviceMotionFromEvent(args[0])
+		return nil
+	}))
 }
 
 func setCanvasEventHandlers(v js.Value) {
@@ -610,6 +792,15 @@ func setCanvasEventHandlers(v js.Value) {
 		return nil
 	}))
 
+	// Pen
+	// Don't call preventDefault for pointer events, or the compatibility mouse events are not fired.
+	for _, name := range []string{"pointerdown", "pointermove", "pointerup", "pointercancel"} {
+		v.Call("addEventListener", name, js.FuncOf(func(this js.Value, args []js.Value) any {
+			theUI.updatePenFromEvent(args[0])
+			return nil
+		}))
+	}
+
 	// Context menu
 	v.Call("addEventListener", "contextmenu", js.FuncOf(func(this js.Value, args []js.Value) any {
 		e := args[0]
@@ -689,20 +880,42 @@ func (u *userInterfaceImpl) Run(game Game, options *RunOptions) error {
 	}
 	u.graphicsDriver = g
 
-	if bodyStyle := document.Get("body").Get("style"); options.ScreenTransparent {
-		bodyStyle.Set("backgroundColor", "transparent")
-	} else {
-		bodyStyle.Set("backgroundColor", "#000")
+	// Don't modify the page's styles when the canvas is in a specified container.
+	if container.Equal(document.Get("body")) {
+		if bodyStyle := document.Get("body").Get("style"); options.ScreenTransparent {
+			bodyStyle.Set("backgroundColor", "transparent")
+		} else {
+			bodyStyle.Set("backgroundColor", "#000")
+		}
 	}
 
 	return <-u.loop(game)
 }
 
+// updateSafeAreaInsets reads the safe area insets via CSS env() variables.
+// The insets are non-zero only when the page specifies viewport-fit=cover.
+func updateSafeAreaInsets() {
+	if !safeAreaElement.Truthy() {
+		return
+	}
+	style := window.Call("getComputedStyle", safeAreaElement)
+	px := func(name string) float64 {
+		// The computed values are like "12px".
+		v := js.Global().Call("parseFloat", style.Get(name))
+		if v.IsNaN() {
+			return 0
+		}
+		return v.Float()
+	}
+	theUI.SetSafeAreaInsets(px("paddingTop"), px("paddingRight"), px("paddingBottom"), px("paddingLeft"))
+}
+
 func (u *userInterfaceImpl) updateScreenSize() {
+	updateSafeAreaInsets()
 	if document.Truthy() {
-		body := document.Get("body")
-		bw := int(body.Get("clientWidth").Float() * u.DeviceScaleFactor())
-		bh := int(body.Get("clientHeight").Float() * u.DeviceScaleFactor())
+		w, h := containerSize()
+		bw := int(w * u.DeviceScaleFactor())
+		bh := int(h * u.DeviceScaleFactor())
 		canvas.Set("width", bw)
 		canvas.Set("height", bh)
 	}
@@ -714,7 +927,20 @@ func (u *userInterfaceImpl) readInputState(inputState *InputState) {
 }
 
 func (u *userInterfaceImpl) Window() Window {
-	return &nullWindow{}
+	return &browserWindow{}
+}
+
+// browserWindow is a Window for browsers. The window is treated as minimized when the document is hidden,
+// e.g., the tab is in the background.
+type browserWindow struct {
+	nullWindow
+}
+
+func (*browserWindow) IsMinimized() bool {
+	if !document.Truthy() {
+		return false
+	}
+	return documentHidden.Invoke().Bool()
 }
 
 func (u *userInterfaceImpl) beginFrame() {
@@ -117,6 +117,12 @@ type userInterfaceImpl struct {
 	fpsMode         FPSModeType
 	renderRequester RenderRequester
 
+	screenOrientationLock   ScreenOrientation
+	screenOrientationLocker ScreenOrientationLocker
+
+	screenKeepAwake       bool
+	screenKeepAwakeSetter ScreenKeepAwakeSetter
+
 	renderThread *thread.OSThread
 
 	m sync.RWMutex
@@ -273,6 +279,7 @@ func (u *userInterfaceImpl) run(game Game, mainloop bool, options *RunOptions) (
 	}()
 
 	u.context = newContext(game)
+	u.startMotionSensors()
 
 	var mgl gl.Context
 	if mainloop {
@@ -337,6 +344,62 @@ func (u *userInterfaceImpl) update() error {
 	return nil
 }
 
+func (u *userInterfaceImpl) ScreenOrientation() ScreenOrientation {
+	return screenOrientationFromSize(u.outsideSize())
+}
+
+type ScreenOrientationLocker interface {
+	LockScreenOrientation(orientation int)
+}
+
+func (u *userInterfaceImpl) SetScreenOrientationLocker(locker ScreenOrientationLocker) {
+	u.m.Lock()
+	u.screenOrientationLocker = locker
+	orientation := u.screenOrientationLock
+	u.m.Unlock()
+
+	if locker != nil && orientation != ScreenOrientationUnspecified {
+		locker.LockScreenOrientation(int(orientation))
+	}
+}
+
+func (u *userInterfaceImpl) SetScreenOrientationLock(orientation ScreenOrientation) {
+	u.m.Lock()
+	u.screenOrientationLock = orientation
+	locker := u.screenOrientationLocker
+	u.m.Unlock()
+
+	if locker != nil {
+		locker.LockScreenOrientation(int(orientation))
+	}
+}
+
+type ScreenKeepAwakeSetter interface {
+	SetScreenKeepAwake(keepAwake bool)
+}
+
+func (u *userInterfaceImpl) SetScreenKeepAwakeSetter(setter ScreenKeepAwakeSetter) {
+	u.m.Lock()
+	u.screenKeepAwakeSetter = setter
+	keepAwake := u.screenKeepAwake
+	u.m.Unlock()
+
+	if setter != nil && keepAwake {
+		setter.SetScreenKeepAwake(true)
+	}
+}
+
+func (u *userInterfaceImpl) SetScreenKeepAwake(keepAwake bool) {
+	u.m.Lock()
+	u.screenKeepAwake = keepAwake
+	setter := u.screenKeepAwakeSetter
+	u.m.Unlock()
+
+	if setter != nil {
+		setter.SetScreenKeepAwake(keepAwake)
+	}
+}
+
 func (u *userInterfaceImpl) ScreenSizeInFullscreen() (int, int) {
 	// TODO: This function should return gbuildWidthPx, gbuildHeightPx,
 	// but these values are not initialized until the main loop starts.
@@ -147,6 +147,16 @@ func (*userInterfaceImpl) IsFocused() bool {
 	return true
 }
 
+func (*userInterfaceImpl) ScreenOrientation() ScreenOrientation {
+	return ScreenOrientationLandscape
+}
+
+func (*userInterfaceImpl) SetScreenOrientationLock(orientation ScreenOrientation) {
+}
+
+func (*userInterfaceImpl) SetScreenKeepAwake(keepAwake bool) {
+}
+
 func (*userInterfaceImpl) ScreenSizeInFullscreen() (int, int) {
 	return 0, 0
 }
@@ -0,0 +1,36 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// SetScreenKeepAwake sets whether the screen is prevented from dimming and sleeping.
+// This is useful for games that are played without touching the screen, e.g., with gamepads or motion sensors.
+//
+// On browsers, SetScreenKeepAwake uses the Screen Wake Lock API. The lock is requested again when the document
+// becomes visible.
+//
+// On Android, SetScreenKeepAwake keeps the screen on while the view is visible when the game runs with ebitenmobile.
+//
+// On iOS, SetScreenKeepAwake disables the application's idle timer when the game runs with ebitenmobile.
+//
+// SetScreenKeepAwake does nothing on desktops so far.
+//
+// SetScreenKeepAwake is concurrent-safe.
+func SetScreenKeepAwake(keepAwake bool) {
+	ui.Get().SetScreenKeepAwake(keepAwake)
+}
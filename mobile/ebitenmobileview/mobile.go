@@ -61,6 +61,12 @@ func Layout(viewWidth, viewHeight float64) {
 	ui.Get().SetOutsideSize(viewWidth, viewHeight)
 }
 
+// SetSafeAreaInsets sets the insets of the area not covered by display cutouts or system bars
+// in device-independent pixels.
+func SetSafeAreaInsets(top, right, bottom, left float64) {
+	ui.Get().SetSafeAreaInsets(top, right, bottom, left)
+}
+
 func Update() error {
 	// Lock the OS thread since graphics functions (GL) must be called on this thread.
 	runtime.LockOSThread()
@@ -99,3 +105,19 @@ type RenderRequester interface {
 func SetRenderRequester(renderRequester RenderRequester) {
 	ui.Get().SetRenderRequester(renderRequester)
 }
+
+type ScreenOrientationLocker interface {
+	LockScreenOrientation(orientation int)
+}
+
+func SetScreenOrientationLocker(screenOrientationLocker ScreenOrientationLocker) {
+	ui.Get().SetScreenOrientationLocker(screenOrientationLocker)
+}
+
+type ScreenKeepAwakeSetter interface {
+	SetScreenKeepAwake(keepAwake bool)
+}
+
+func SetScreenKeepAwakeSetter(screenKeepAwakeSetter ScreenKeepAwakeSetter) {
+	ui.Get().SetScreenKeepAwakeSetter(screenKeepAwakeSetter)
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// MonitorType represents a monitor available to the system.
+//
+// On browsers and mobiles, there is always only one monitor representing the screen.
+type MonitorType ui.Monitor
+
+// Bounds returns the position and size of the monitor in device-independent pixels.
+//
+// The position is in the desktop's global coordinate, and the origin is the top-left corner of the primary monitor.
+//
+// Bounds is concurrent-safe.
+func (m *MonitorType) Bounds() image.Rectangle {
+	return (*ui.Monitor)(m).Bounds()
+}
+
+// Name returns the monitor's name.
+//
+// On browsers and mobiles, Name returns an empty string.
+//
+// Name is concurrent-safe.
+func (m *MonitorType) Name() string {
+	return (*ui.Monitor)(m).Name()
+}
+
+// DeviceScaleFactor returns the monitor's device scale factor.
+//
+// On desktops, the value is taken when the monitor configuration changes and is not updated otherwise.
+//
+// DeviceScaleFactor is concurrent-safe.
+func (m *MonitorType) DeviceScaleFactor() float64 {
+	return (*ui.Monitor)(m).DeviceScaleFactor()
+}
+
+// Monitor returns the monitor which the window belongs to.
+// Before RunGame, Monitor returns the monitor where the window will be created.
+//
+// Monitor might return nil in theory, e.g., when the monitor is just disconnected.
+//
+// Monitor must be called on the main thread before ebiten.RunGame, and is concurrent-safe after ebiten.RunGame.
+func Monitor() *MonitorType {
+	return (*MonitorType)(ui.Get().Monitor())
+}
+
+// SetMonitor moves the window to the given monitor.
+// Before RunGame, SetMonitor specifies the monitor where the window will be created.
+//
+// If the window is fullscreen, the window becomes fullscreen on the given monitor.
+// The window's position relative to the monitor is kept as much as possible.
+//
+// SetMonitor panics if monitor is nil.
+//
+// SetMonitor does nothing on browsers and mobiles.
+//
+// SetMonitor must be called on the main thread before ebiten.RunGame, and is concurrent-safe after ebiten.RunGame.
+func SetMonitor(monitor *MonitorType) {
+	ui.Get().SetMonitor((*ui.Monitor)(monitor))
+}
+
+// AppendMonitors appends the current monitors to monitors and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+//
+// The monitors are updated when a monitor is connected or disconnected, and the previous *MonitorType values
+// might no longer be valid then.
+//
+// AppendMonitors must be called on the main thread before ebiten.RunGame, and is concurrent-safe after ebiten.RunGame.
+func AppendMonitors(monitors []*MonitorType) []*MonitorType {
+	ms := ui.Get().AppendMonitors(nil)
+	for _, m := range ms {
+		monitors = append(monitors, (*MonitorType)(m))
+	}
+	return monitors
+}
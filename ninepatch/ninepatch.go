@@ -0,0 +1,135 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ninepatch provides a function to draw nine-patch (nine-slice) images.
+//
+// A nine-patch image is split into nine parts by insets. When the image is drawn with an arbitrary size,
+// the four corners are not scaled, the four edges are scaled in one direction, and the center is scaled in both directions.
+// This is useful to draw UI elements like buttons and windows.
+package ninepatch
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Insets represents the widths of the fixed borders of a nine-patch image in pixels.
+type Insets struct {
+	Left   int
+	Top    int
+	Right  int
+	Bottom int
+}
+
+// DrawOptions represents options for Draw.
+type DrawOptions struct {
+	// GeoM is a geometry matrix to draw.
+	// The default (zero) value is identity, which draws the image at (0, 0).
+	GeoM ebiten.GeoM
+
+	// ColorScale is a scale of color.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ebiten.ColorScale
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend ebiten.Blend
+
+	// Filter is a type of texture filter.
+	// The default (zero) value is ebiten.FilterNearest.
+	Filter ebiten.Filter
+}
+
+// Draw draws src on dst as a nine-patch image with the size (width, height).
+//
+// If width or height is smaller than the sum of the insets, the corners are shrunk proportionally.
+//
+// Draw issues only one DrawTriangles call, so the draw calls can be batched with other draw calls.
+//
+// Draw panics if the insets are negative or don't fit in src's bounds.
+func Draw(dst, src *ebiten.Image, width, height float64, insets Insets, options *DrawOptions) {
+	if options == nil {
+		options = &DrawOptions{}
+	}
+
+	b := src.Bounds()
+	if insets.Left < 0 || insets.Top < 0 || insets.Right < 0 || insets.Bottom < 0 {
+		panic("ninepatch: insets must not be negative")
+	}
+	if insets.Left+insets.Right > b.Dx() || insets.Top+insets.Bottom > b.Dy() {
+		panic("ninepatch: insets must fit in the source image")
+	}
+
+	sxs := [4]float32{
+		float32(b.Min.X),
+		float32(b.Min.X + insets.Left),
+		float32(b.Max.X - insets.Right),
+		float32(b.Max.X),
+	}
+	sys := [4]float32{
+		float32(b.Min.Y),
+		float32(b.Min.Y + insets.Top),
+		float32(b.Max.Y - insets.Bottom),
+		float32(b.Max.Y),
+	}
+	dxs := splitLength(width, insets.Left, insets.Right)
+	dys := splitLength(height, insets.Top, insets.Bottom)
+
+	cr := options.ColorScale.R()
+	cg := options.ColorScale.G()
+	cb := options.ColorScale.B()
+	ca := options.ColorScale.A()
+
+	vs := make([]ebiten.Vertex, 0, 16)
+	for j := 0; j < 4; j++ {
+		for i := 0; i < 4; i++ {
+			x, y := options.GeoM.Apply(dxs[i], dys[j])
+			vs = append(vs, ebiten.Vertex{
+				DstX:   float32(x),
+				DstY:   float32(y),
+				SrcX:   sxs[i],
+				SrcY:   sys[j],
+				ColorR: cr,
+				ColorG: cg,
+				ColorB: cb,
+				ColorA: ca,
+			})
+		}
+	}
+
+	is := make([]uint16, 0, 54)
+	for j := 0; j < 3; j++ {
+		for i := 0; i < 3; i++ {
+			idx := uint16(4*j + i)
+			is = append(is, idx, idx+1, idx+4, idx+1, idx+5, idx+4)
+		}
+	}
+
+	op := &ebiten.DrawTrianglesOptions{}
+	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	op.Blend = options.Blend
+	op.Filter = options.Filter
+	dst.DrawTriangles(vs, is, src, op)
+}
+
+// splitLength returns the positions of the borders when a length is split into the start inset, the stretched part,
+// and the end inset.
+func splitLength(length float64, start, end int) [4]float64 {
+	s := float64(start)
+	e := float64(end)
+	if s+e > length && s+e > 0 {
+		s = length * s / (s + e)
+		e = length - s
+	}
+	return [4]float64{0, s, length - e, length}
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ninepatch_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	t "github.com/hajimehoshi/ebiten/v2/internal/testing"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+	"github.com/hajimehoshi/ebiten/v2/ninepatch"
+)
+
+func TestMain(m *testing.M) {
+	ui.SetPanicOnErrorOnReadingPixelsForTesting(true)
+	t.MainWithRunLoop(m)
+}
+
+// The source image is 6x6 pixels with 2-pixel insets. Each of the nine parts is filled with a different color.
+const (
+	srcSize  = 6
+	srcInset = 2
+)
+
+var partColors = [3][3]color.RGBA{
+	{{0xff, 0, 0, 0xff}, {0, 0xff, 0, 0xff}, {0, 0, 0xff, 0xff}},
+	{{0xff, 0xff, 0, 0xff}, {0xff, 0, 0xff, 0xff}, {0, 0xff, 0xff, 0xff}},
+	{{0xff, 0xff, 0xff, 0xff}, {0x80, 0, 0, 0xff}, {0, 0x80, 0, 0xff}},
+}
+
+func newSourceImage() *ebiten.Image {
+	img := ebiten.NewImage(srcSize, srcSize)
+	bounds := [4]int{0, srcInset, srcSize - srcInset, srcSize}
+	for j := 0; j < 3; j++ {
+		for i := 0; i < 3; i++ {
+			r := image.Rect(bounds[i], bounds[j], bounds[i+1], bounds[j+1])
+			img.SubImage(r).(*ebiten.Image).Fill(partColors[j][i])
+		}
+	}
+	return img
+}
+
+// partIndex returns the index of the part at the position v in the destination with the given borders.
+func partIndex(v int, borders [4]int) int {
+	switch {
+	case v < borders[1]:
+		return 0
+	case v < borders[2]:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func testDraw(t *testing.T, width, height int, xBorders, yBorders [4]int) {
+	t.Helper()
+
+	src := newSourceImage()
+	insets := ninepatch.Insets{
+		Left:   srcInset,
+		Top:    srcInset,
+		Right:  srcInset,
+		Bottom: srcInset,
+	}
+
+	// Draw the nine-patch image at an offset so that the GeoM is also tested.
+	const offset = 3
+	dst := ebiten.NewImage(width+2*offset, height+2*offset)
+	op := &ninepatch.DrawOptions{}
+	op.GeoM.Translate(offset, offset)
+	ninepatch.Draw(dst, src, float64(width), float64(height), insets, op)
+
+	for j := 0; j < height+2*offset; j++ {
+		for i := 0; i < width+2*offset; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			var want color.RGBA
+			x, y := i-offset, j-offset
+			if x >= 0 && x < width && y >= 0 && y < height {
+				want = partColors[partIndex(y, yBorders)][partIndex(x, xBorders)]
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDrawStretch(t *testing.T) {
+	// The corners keep their sizes, the edges are stretched in one direction, and the center is stretched in both
+	// directions.
+	testDraw(t, 20, 14, [4]int{0, 2, 18, 20}, [4]int{0, 2, 12, 14})
+}
+
+func TestDrawSameSize(t *testing.T) {
+	testDraw(t, srcSize, srcSize, [4]int{0, 2, 4, 6}, [4]int{0, 2, 4, 6})
+}
+
+func TestDrawSmallerThanCorners(t *testing.T) {
+	// The width and the height are smaller than the sum of the insets.
+	// The corners are shrunk proportionally, and the center is not drawn.
+	testDraw(t, 2, 2, [4]int{0, 1, 1, 2}, [4]int{0, 1, 1, 2})
+}
+
+func TestDrawInvalidInsets(t *testing.T) {
+	src := newSourceImage()
+	dst := ebiten.NewImage(16, 16)
+
+	for _, insets := range []ninepatch.Insets{
+		{Left: -1},
+		{Left: 4, Right: 3},
+		{Top: 7},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Draw with %+v must panic but not", insets)
+				}
+			}()
+			ninepatch.Draw(dst, src, 16, 16, insets, nil)
+		}()
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package particles provides a simple particle system.
+//
+// All the live particles of an emitter are drawn with one DrawTriangles call as long as the number of the particles
+// is less than MaxParticlesPerDrawCall.
+package particles
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// MaxParticlesPerDrawCall is the maximum number of particles drawn with one DrawTriangles call.
+const MaxParticlesPerDrawCall = ebiten.MaxIndicesCount / 6
+
+// EmitterOptions represents options for an emitter.
+type EmitterOptions struct {
+	// Rate is the number of particles emitted per tick.
+	// Rate can be a fractional number. For example, 0.5 emits one particle every two ticks.
+	Rate float64
+
+	// Lifetime is the lifetime of a particle in ticks.
+	// If Lifetime is 0 or less, 60 is used.
+	Lifetime int
+
+	// Direction is the center of the directions of new particles in radians.
+	Direction float64
+
+	// Spread is the range of the directions of new particles in radians.
+	// A new particle's direction is chosen randomly from [Direction-Spread/2, Direction+Spread/2).
+	Spread float64
+
+	// SpeedMin and SpeedMax are the range of the speeds of new particles in pixels per tick.
+	SpeedMin float64
+	SpeedMax float64
+
+	// AccelerationX and AccelerationY are the acceleration applied to every particle in pixels per tick squared.
+	AccelerationX float64
+	AccelerationY float64
+
+	// Size returns the scale of a particle image at the given age t in [0, 1].
+	// If Size is nil, the scale is always 1.
+	Size func(t float64) float64
+
+	// Color returns the color of a particle at the given age t in [0, 1].
+	// The returned color is multiplied with the particle image.
+	// If Color is nil, the color is always white.
+	Color func(t float64) color.Color
+
+	// MaxParticles is the maximum number of live particles.
+	// If MaxParticles is 0 or less, the number is not limited.
+	MaxParticles int
+}
+
+type particle struct {
+	x   float64
+	y   float64
+	vx  float64
+	vy  float64
+	age int
+}
+
+// Emitter emits and updates particles.
+type Emitter struct {
+	image   *ebiten.Image
+	options EmitterOptions
+
+	x float64
+	y float64
+
+	particles []particle
+	remainder float64
+
+	vertices []ebiten.Vertex
+	indices  []uint16
+}
+
+// NewEmitter creates a new emitter that draws particles with the given image.
+//
+// NewEmitter panics if img is nil.
+func NewEmitter(img *ebiten.Image, options *EmitterOptions) *Emitter {
+	if img == nil {
+		panic("particles: img must not be nil")
+	}
+	e := &Emitter{
+		image: img,
+	}
+	if options != nil {
+		e.options = *options
+	}
+	if e.options.Lifetime <= 0 {
+		e.options.Lifetime = 60
+	}
+	return e
+}
+
+// SetPosition sets the position where new particles are emitted.
+func (e *Emitter) SetPosition(x, y float64) {
+	e.x = x
+	e.y = y
+}
+
+// Position returns the position where new particles are emitted.
+func (e *Emitter) Position() (x, y float64) {
+	return e.x, e.y
+}
+
+// Len returns the number of live particles.
+func (e *Emitter) Len() int {
+	return len(e.particles)
+}
+
+// Clear removes all the live particles.
+func (e *Emitter) Clear() {
+	e.particles = e.particles[:0]
+	e.remainder = 0
+}
+
+// Emit emits n particles immediately regardless of the rate.
+func (e *Emitter) Emit(n int) {
+	for i := 0; i < n; i++ {
+		if e.options.MaxParticles > 0 && len(e.particles) >= e.options.MaxParticles {
+			return
+		}
+		dir := e.options.Direction + (rand.Float64()-0.5)*e.options.Spread
+		speed := e.options.SpeedMin + rand.Float64()*(e.options.SpeedMax-e.options.SpeedMin)
+		e.particles = append(e.particles, particle{
+			x:  e.x,
+			y:  e.y,
+			vx: speed * math.Cos(dir),
+			vy: speed * math.Sin(dir),
+		})
+	}
+}
+
+// Update proceeds the particles by one tick and emits new particles based on the rate.
+//
+// Update is expected to be called from the game's Update.
+func (e *Emitter) Update() {
+	for i := 0; i < len(e.particles); {
+		p := &e.particles[i]
+		p.age++
+		if p.age >= e.options.Lifetime {
+			e.particles[i] = e.particles[len(e.particles)-1]
+			e.particles = e.particles[:len(e.particles)-1]
+			continue
+		}
+		p.vx += e.options.AccelerationX
+		p.vy += e.options.AccelerationY
+		p.x += p.vx
+		p.y += p.vy
+		i++
+	}
+
+	e.remainder += e.options.Rate
+	n := int(e.remainder)
+	e.remainder -= float64(n)
+	e.Emit(n)
+}
+
+// DrawOptions represents options for Draw.
+type DrawOptions struct {
+	// GeoM is a geometry matrix applied to the particles' positions.
+	// The default (zero) value is identity.
+	GeoM ebiten.GeoM
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend ebiten.Blend
+
+	// Filter is a type of texture filter.
+	// The default (zero) value is ebiten.FilterNearest.
+	Filter ebiten.Filter
+}
+
+// Draw draws the live particles on dst.
+//
+// Each particle image is centered at the particle's position.
+func (e *Emitter) Draw(dst *ebiten.Image, options *DrawOptions) {
+	if options == nil {
+		options = &DrawOptions{}
+	}
+
+	op := &ebiten.DrawTrianglesOptions{}
+	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	op.Blend = options.Blend
+	op.Filter = options.Filter
+
+	for i := 0; i < len(e.particles); i += MaxParticlesPerDrawCall {
+		end := i + MaxParticlesPerDrawCall
+		if end > len(e.particles) {
+			end = len(e.particles)
+		}
+		e.appendVerticesAndIndices(e.particles[i:end], &options.GeoM)
+		dst.DrawTriangles(e.vertices, e.indices, e.image, op)
+	}
+}
+
+func (e *Emitter) appendVerticesAndIndices(particles []particle, geoM *ebiten.GeoM) {
+	e.vertices = e.vertices[:0]
+	e.indices = e.indices[:0]
+
+	b := e.image.Bounds()
+	sx0, sy0 := float32(b.Min.X), float32(b.Min.Y)
+	sx1, sy1 := float32(b.Max.X), float32(b.Max.Y)
+	hw, hh := float64(b.Dx())/2, float64(b.Dy())/2
+
+	for i, p := range particles {
+		t := float64(p.age) / float64(e.options.Lifetime)
+
+		s := 1.0
+		if e.options.Size != nil {
+			s = e.options.Size(t)
+		}
+
+		cr, cg, cb, ca := float32(1), float32(1), float32(1), float32(1)
+		if e.options.Color != nil {
+			r, g, b, a := e.options.Color(t).RGBA()
+			cr = float32(r) / 0xffff
+			cg = float32(g) / 0xffff
+			cb = float32(b) / 0xffff
+			ca = float32(a) / 0xffff
+		}
+
+		x0, y0 := geoM.Apply(p.x-hw*s, p.y-hh*s)
+		x1, y1 := geoM.Apply(p.x+hw*s, p.y-hh*s)
+		x2, y2 := geoM.Apply(p.x-hw*s, p.y+hh*s)
+		x3, y3 := geoM.Apply(p.x+hw*s, p.y+hh*s)
+		e.vertices = append(e.vertices,
+			ebiten.Vertex{DstX: float32(x0), DstY: float32(y0), SrcX: sx0, SrcY: sy0, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+			ebiten.Vertex{DstX: float32(x1), DstY: float32(y1), SrcX: sx1, SrcY: sy0, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+			ebiten.Vertex{DstX: float32(x2), DstY: float32(y2), SrcX: sx0, SrcY: sy1, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+			ebiten.Vertex{DstX: float32(x3), DstY: float32(y3), SrcX: sx1, SrcY: sy1, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+		)
+
+		idx := uint16(4 * i)
+		e.indices = append(e.indices, idx, idx+1, idx+2, idx+1, idx+3, idx+2)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package particles_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/particles"
+)
+
+func TestEmitterRate(t *testing.T) {
+	e := particles.NewEmitter(ebiten.NewImage(1, 1), &particles.EmitterOptions{
+		Rate:     0.5,
+		Lifetime: 10,
+	})
+	for i := 0; i < 4; i++ {
+		e.Update()
+	}
+	if got, want := e.Len(), 2; got != want {
+		t.Errorf("e.Len(): got: %d, want: %d", got, want)
+	}
+
+	// After enough ticks, the number of live particles is stable.
+	for i := 0; i < 100; i++ {
+		e.Update()
+	}
+	if got, want := e.Len(), 5; got != want {
+		t.Errorf("e.Len(): got: %d, want: %d", got, want)
+	}
+}
+
+func TestEmitterMaxParticles(t *testing.T) {
+	e := particles.NewEmitter(ebiten.NewImage(1, 1), &particles.EmitterOptions{
+		MaxParticles: 3,
+	})
+	e.Emit(10)
+	if got, want := e.Len(), 3; got != want {
+		t.Errorf("e.Len(): got: %d, want: %d", got, want)
+	}
+	e.Clear()
+	if got, want := e.Len(), 0; got != want {
+		t.Errorf("e.Len(): got: %d, want: %d", got, want)
+	}
+}
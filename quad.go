@@ -0,0 +1,183 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// quadShaderSource is a shader to render an image with perspective correction.
+//
+// The source position of each vertex is multiplied by the vertex's q value relatively to the source region's origin,
+// and q is passed as the red color value. As the varying values are interpolated linearly on the screen,
+// dividing the interpolated source position by the interpolated q results in the perspective-correct position.
+var quadShaderSource = []byte(`package main
+
+var ColorScale vec4
+var Linear float
+
+func Fragment(position vec4, texCoord vec2, color vec4) vec4 {
+	origin, _ := imageSrcRegionOnTexture()
+	pos := origin + (texCoord-origin)/color.r
+
+	if Linear == 0 {
+		return imageSrc0At(pos) * ColorScale
+	}
+
+	sourceSize := imageSrcTextureSize()
+	texelSize := 1 / sourceSize
+
+	// Shift 1/512 [texel] to avoid the tie-breaking issue (#1212).
+	p0 := pos - texelSize/2 + texelSize/512
+	p1 := pos + texelSize/2 + texelSize/512
+
+	c0 := imageSrc0At(p0)
+	c1 := imageSrc0At(vec2(p1.x, p0.y))
+	c2 := imageSrc0At(vec2(p0.x, p1.y))
+	c3 := imageSrc0At(p1)
+
+	rate := fract(p0 * sourceSize)
+	return mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y) * ColorScale
+}
+`)
+
+var (
+	quadShader     *Shader
+	quadShaderOnce sync.Once
+)
+
+func getQuadShader() *Shader {
+	quadShaderOnce.Do(func() {
+		s, err := NewShader(quadShaderSource)
+		if err != nil {
+			panic(fmt.Sprintf("ebiten: NewShader for the quad shader failed: %v", err))
+		}
+		quadShader = s
+	})
+	return quadShader
+}
+
+// DrawImageToQuadOptions represents options for DrawImageToQuad.
+type DrawImageToQuadOptions struct {
+	// ColorScale is a scale of color.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ColorScale
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend Blend
+
+	// Filter is a type of texture filter.
+	// The default (zero) value is FilterNearest.
+	Filter Filter
+}
+
+// DrawImageToQuad draws the given image onto the quadrilateral on the image i with perspective correction.
+//
+// (x0, y0), (x1, y1), (x2, y2), and (x3, y3) are the positions where the upper-left, the upper-right,
+// the lower-right, and the lower-left corners of img are drawn respectively.
+// Unlike DrawImage with an affine GeoM, the image is drawn as if it is a rectangle seen in a perspective view,
+// which is useful for e.g. Mode 7 style floors, flipping cards and pseudo 3D billboards.
+//
+// The quadrilateral must be convex. Otherwise, the result is undefined.
+//
+// DrawImageToQuad doesn't work correctly when img is larger than the maximum texture size.
+//
+// When the image i is disposed, DrawImageToQuad does nothing.
+// When the given image img is disposed, DrawImageToQuad panics.
+func (i *Image) DrawImageToQuad(img *Image, x0, y0, x1, y1, x2, y2, x3, y3 float64, options *DrawImageToQuadOptions) {
+	i.copyCheck()
+
+	if img.isDisposed() {
+		panic("ebiten: the given image to DrawImageToQuad must not be disposed")
+	}
+	if i.isDisposed() {
+		return
+	}
+
+	if options == nil {
+		options = &DrawImageToQuadOptions{}
+	}
+
+	qs := quadWeights(x0, y0, x1, y1, x2, y2, x3, y3)
+
+	b := img.Bounds()
+	ox, oy := float32(b.Min.X), float32(b.Min.Y)
+	w, h := float32(b.Dx()), float32(b.Dy())
+	vs := []Vertex{
+		{DstX: float32(x0), DstY: float32(y0), SrcX: 0, SrcY: 0},
+		{DstX: float32(x1), DstY: float32(y1), SrcX: w, SrcY: 0},
+		{DstX: float32(x2), DstY: float32(y2), SrcX: w, SrcY: h},
+		{DstX: float32(x3), DstY: float32(y3), SrcX: 0, SrcY: h},
+	}
+	for j := range vs {
+		q := float32(qs[j])
+		vs[j].SrcX = ox + vs[j].SrcX*q
+		vs[j].SrcY = oy + vs[j].SrcY*q
+		vs[j].ColorR = q
+		vs[j].ColorG = 1
+		vs[j].ColorB = 1
+		vs[j].ColorA = 1
+	}
+	is := []uint16{0, 1, 2, 0, 2, 3}
+
+	var linear float32
+	if options.Filter == FilterLinear {
+		linear = 1
+	}
+
+	op := &DrawTrianglesShaderOptions{}
+	op.Blend = options.Blend
+	op.Images[0] = img
+	op.Uniforms = map[string]any{
+		"ColorScale": []float32{options.ColorScale.R(), options.ColorScale.G(), options.ColorScale.B(), options.ColorScale.A()},
+		"Linear":     linear,
+	}
+	i.DrawTrianglesShader(vs, is, getQuadShader(), op)
+}
+
+// quadWeights returns the q values of the corners of the quadrilateral for perspective-correct texture mapping.
+//
+// The q value of a corner is calculated from the distances between the corners and the intersection of the diagonals.
+// See https://www.reedbeta.com/blog/quadrilateral-interpolation-part-1/.
+func quadWeights(x0, y0, x1, y1, x2, y2, x3, y3 float64) [4]float64 {
+	// Calculate the intersection of the diagonals (x0, y0)-(x2, y2) and (x1, y1)-(x3, y3).
+	dx02, dy02 := x2-x0, y2-y0
+	dx13, dy13 := x3-x1, y3-y1
+	d := dx02*dy13 - dy02*dx13
+	if d == 0 {
+		return [4]float64{1, 1, 1, 1}
+	}
+	t := ((x1-x0)*dy13 - (y1-y0)*dx13) / d
+	u := ((x1-x0)*dy02 - (y1-y0)*dx02) / d
+	if t <= 0 || t >= 1 || u <= 0 || u >= 1 {
+		// The quadrilateral is not convex.
+		return [4]float64{1, 1, 1, 1}
+	}
+	cx, cy := x0+t*dx02, y0+t*dy02
+
+	d0 := math.Hypot(x0-cx, y0-cy)
+	d1 := math.Hypot(x1-cx, y1-cy)
+	d2 := math.Hypot(x2-cx, y2-cy)
+	d3 := math.Hypot(x3-cx, y3-cy)
+	return [4]float64{
+		(d0 + d2) / d2,
+		(d1 + d3) / d3,
+		(d2 + d0) / d0,
+		(d3 + d1) / d1,
+	}
+}
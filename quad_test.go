@@ -0,0 +1,92 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestQuadWeights(t *testing.T) {
+	// For a rectangle, all the q values are the same and no perspective correction happens.
+	for i, q := range ebiten.QuadWeightsForTesting(0, 0, 16, 0, 16, 8, 0, 8) {
+		if got, want := q, 2.0; math.Abs(got-want) > 1e-9 {
+			t.Errorf("q[%d]: got: %f, want: %f", i, got, want)
+		}
+	}
+
+	// For a trapezoid, the center of the source must be mapped to the intersection of the diagonals.
+	x0, y0, x1, y1, x2, y2, x3, y3 := 4.0, 0.0, 12.0, 0.0, 16.0, 8.0, 0.0, 8.0
+	qs := ebiten.QuadWeightsForTesting(x0, y0, x1, y1, x2, y2, x3, y3)
+
+	// The intersection of the diagonals is on the line (x0, y0)-(x2, y2) at the rate d0 / (d0 + d2).
+	d0 := math.Hypot(x0-8, y0-8.0/3)
+	d2 := math.Hypot(x2-8, y2-8.0/3)
+	r := d0 / (d0 + d2)
+	u := r * qs[2] / ((1-r)*qs[0] + r*qs[2])
+	if got, want := u, 0.5; math.Abs(got-want) > 1e-9 {
+		t.Errorf("u: got: %f, want: %f", got, want)
+	}
+}
+
+func TestImageDrawImageToQuad(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (j*w + i)
+			pix[idx] = byte(i * 16)
+			pix[idx+1] = byte(j * 16)
+			pix[idx+2] = 0
+			pix[idx+3] = 0xff
+		}
+	}
+	src.WritePixels(pix)
+
+	for _, sub := range []bool{false, true} {
+		s := src
+		if sub {
+			s = src.SubImage(image.Rect(4, 4, 12, 12)).(*ebiten.Image)
+		}
+		sw, sh := s.Bounds().Dx(), s.Bounds().Dy()
+
+		// Drawing to an axis-aligned rectangle is the same as DrawImage.
+		dst := ebiten.NewImage(w, h)
+		dst.DrawImageToQuad(s, 0, 0, float64(sw), 0, float64(sw), float64(sh), 0, float64(sh), nil)
+		for j := 0; j < sh; j++ {
+			for i := 0; i < sw; i++ {
+				got := dst.At(i, j)
+				want := s.At(s.Bounds().Min.X+i, s.Bounds().Min.Y+j)
+				if got != want {
+					t.Errorf("sub: %v, dst.At(%d, %d): got: %v, want: %v", sub, i, j, got, want)
+				}
+			}
+		}
+	}
+
+	// The center of the source is drawn at the intersection of the diagonals.
+	dst := ebiten.NewImage(w, h)
+	dst.DrawImageToQuad(src, 4, 0, 12, 0, 16, 16, 0, 16, nil)
+	// The intersection is (8, 16/3).
+	got := dst.At(8, 5).(color.RGBA)
+	if got.R < 0x70 || got.R > 0x90 || got.G < 0x70 || got.G > 0x90 || got.A != 0xff {
+		t.Errorf("dst.At(8, 5): got: %v, want: around (0x80, 0x80, 0, 0xff)", got)
+	}
+}
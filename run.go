@@ -61,6 +61,8 @@ type Game interface {
 	//
 	// The frequency of Draw calls depends on the user's environment, especially the monitors refresh rate.
 	// For portability, you should not put your game logic in Draw in general.
+	//
+	// If the game implements the interface InterpolatedDrawer, Draw is never called and DrawInterpolated is called instead.
 	Draw(screen *Image)
 
 	// Layout accepts a native outside size in device-independent pixels and returns the game's logical screen
@@ -93,6 +95,22 @@ type LayoutFer interface {
 	LayoutF(outsideWidth, outsideHeight float64) (screenWidth, screenHeight float64)
 }
 
+// InterpolatedDrawer is an interface for Game.Draw with an interpolation factor between ticks.
+//
+// Update is called at a fixed rate specified by SetTPS, and a frame can be drawn between two ticks.
+// InterpolatedDrawer is useful to draw smooth motions, especially when the display's refresh rate is higher than TPS.
+type InterpolatedDrawer interface {
+	// DrawInterpolated is the interpolated version of Game.Draw.
+	//
+	// alpha is the ratio of the elapsed time since the last Update to the duration of a tick, in [0, 1].
+	// A typical usage is to keep the previous and the current game states at Update,
+	// and draw the state previous*(1-alpha) + current*alpha.
+	// If TPS is SyncWithFPS, alpha is always 1.
+	//
+	// If the game implements this interface, Draw is never called and DrawInterpolated is called instead.
+	DrawInterpolated(screen *Image, alpha float64)
+}
+
 // FinalScreen represents the final screen image.
 // FinalScreen implements a part of Image functions.
 type FinalScreen interface {
@@ -336,6 +354,27 @@ func ScreenSizeInFullscreen() (int, int) {
 	return ui.Get().ScreenSizeInFullscreen()
 }
 
+// SafeAreaInsets returns the insets of the area that is not covered by display cutouts like notches, rounded corners,
+// or system bars.
+// HUD elements and touch controls should be placed inside the screen excluding these insets.
+//
+// The insets are in the logical coordinates of the screen given to Draw, i.e., the coordinates of the size returned by
+// Layout, in the same way as cursor positions. The margins around the screen are excluded, so an inset is zero when
+// the cutout is only in the margins. The insets are updated every frame, and are zeros before the first frame.
+//
+// On browsers, SafeAreaInsets is based on the values of the CSS env(safe-area-inset-*) variables.
+// These values are non-zero only when the page's viewport meta tag specifies viewport-fit=cover.
+//
+// On Android and iOS, SafeAreaInsets is based on the display cutout insets and the safe area insets respectively.
+// On Android, the insets are available on Android 9 (API level 28) or later.
+//
+// On desktops, SafeAreaInsets always returns zeros.
+//
+// SafeAreaInsets is concurrent-safe.
+func SafeAreaInsets() (top, right, bottom, left float64) {
+	return ui.Get().SafeAreaInsets()
+}
+
 // CursorMode returns the current cursor mode.
 //
 // CursorMode returns CursorModeHidden on mobiles.
@@ -430,6 +469,9 @@ func IsRunnableOnUnfocused() bool {
 // If the given value is true, the game runs even in background e.g. when losing focus.
 // The initial state is true.
 //
+// If the given value is false, the audio is also suspended while the game is not running in background,
+// and is resumed when the game gets focused again. Use this to pause the music together with the game.
+//
 // Known issue: On browsers, even if the state is on, the game doesn't run in background tabs.
 // This is because browsers throttles background tabs not to often update.
 //
@@ -0,0 +1,177 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scene provides a stack-based scene manager with cross-fade transitions.
+package scene
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Scene represents a scene like a title menu, gameplay or a pause menu.
+type Scene interface {
+	// Update proceeds the scene's state.
+	Update() error
+
+	// Draw draws the scene.
+	Draw(screen *ebiten.Image)
+}
+
+// TransitionOptions represents options for a scene transition.
+type TransitionOptions struct {
+	// Duration is the duration of the cross-fade in ticks.
+	// If Duration is 0 or less, the scene is switched immediately.
+	Duration int
+}
+
+// Manager manages scenes as a stack. Only the top scene is updated and drawn.
+//
+// The zero value for Manager is an empty stack.
+type Manager struct {
+	scenes []Scene
+
+	from     Scene
+	count    int
+	duration int
+
+	fromImage *ebiten.Image
+	toImage   *ebiten.Image
+}
+
+// Current returns the top scene. If there is no scene, Current returns nil.
+func (m *Manager) Current() Scene {
+	if len(m.scenes) == 0 {
+		return nil
+	}
+	return m.scenes[len(m.scenes)-1]
+}
+
+// Len returns the number of the scenes in the stack.
+func (m *Manager) Len() int {
+	return len(m.scenes)
+}
+
+// IsTransitioning reports whether a cross-fade is in progress.
+func (m *Manager) IsTransitioning() bool {
+	return m.count > 0
+}
+
+// Push pushes scene onto the stack.
+//
+// If options is nil, the scene is switched immediately.
+func (m *Manager) Push(scene Scene, options *TransitionOptions) {
+	from := m.Current()
+	m.scenes = append(m.scenes, scene)
+	m.startTransition(from, options)
+}
+
+// Pop removes the top scene from the stack and returns it.
+// If there is no scene, Pop does nothing and returns nil.
+//
+// If options is nil, the scene is switched immediately.
+func (m *Manager) Pop(options *TransitionOptions) Scene {
+	from := m.Current()
+	if from == nil {
+		return nil
+	}
+	m.scenes[len(m.scenes)-1] = nil
+	m.scenes = m.scenes[:len(m.scenes)-1]
+	m.startTransition(from, options)
+	return from
+}
+
+// Replace replaces the top scene with scene.
+// If there is no scene, Replace works as Push.
+//
+// If options is nil, the scene is switched immediately.
+func (m *Manager) Replace(scene Scene, options *TransitionOptions) {
+	from := m.Current()
+	if from == nil {
+		m.Push(scene, options)
+		return
+	}
+	m.scenes[len(m.scenes)-1] = scene
+	m.startTransition(from, options)
+}
+
+func (m *Manager) startTransition(from Scene, options *TransitionOptions) {
+	if from == nil || options == nil || options.Duration <= 0 {
+		m.from = nil
+		m.count = 0
+		m.duration = 0
+		return
+	}
+	m.from = from
+	m.count = options.Duration
+	m.duration = options.Duration
+}
+
+// Update updates the top scene and proceeds the transition.
+//
+// While a cross-fade is in progress, only the new scene is updated.
+func (m *Manager) Update() error {
+	if m.count > 0 {
+		m.count--
+		if m.count == 0 {
+			m.from = nil
+		}
+	}
+	s := m.Current()
+	if s == nil {
+		return nil
+	}
+	return s.Update()
+}
+
+// Draw draws the top scene.
+//
+// While a cross-fade is in progress, the previous scene and the new scene are drawn to offscreen images and then
+// blended onto screen.
+func (m *Manager) Draw(screen *ebiten.Image) {
+	if m.count == 0 || m.from == nil {
+		if s := m.Current(); s != nil {
+			s.Draw(screen)
+		}
+		return
+	}
+
+	size := screen.Bounds().Size()
+	if m.fromImage == nil || m.fromImage.Bounds().Size() != size {
+		if m.fromImage != nil {
+			m.fromImage.Dispose()
+			m.toImage.Dispose()
+		}
+		m.fromImage = ebiten.NewImage(size.X, size.Y)
+		m.toImage = ebiten.NewImage(size.X, size.Y)
+	}
+
+	alpha := 1 - float32(m.count)/float32(m.duration)
+
+	m.fromImage.Clear()
+	m.from.Draw(m.fromImage)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(screen.Bounds().Min.X), float64(screen.Bounds().Min.Y))
+	if m.Current() == nil {
+		// Fade out when there is no scene to switch to.
+		op.ColorScale.ScaleAlpha(1 - alpha)
+	}
+	screen.DrawImage(m.fromImage, op)
+
+	if s := m.Current(); s != nil {
+		m.toImage.Clear()
+		s.Draw(m.toImage)
+		op.ColorScale.ScaleAlpha(alpha)
+		screen.DrawImage(m.toImage, op)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scene_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/scene"
+)
+
+type testScene struct {
+	updated int
+}
+
+func (t *testScene) Update() error {
+	t.updated++
+	return nil
+}
+
+func (t *testScene) Draw(screen *ebiten.Image) {
+}
+
+func TestManagerStack(t *testing.T) {
+	var m scene.Manager
+	a := &testScene{}
+	b := &testScene{}
+	c := &testScene{}
+
+	m.Push(a, nil)
+	m.Push(b, nil)
+	if got, want := m.Current(), scene.Scene(b); got != want {
+		t.Errorf("m.Current(): got: %v, want: %v", got, want)
+	}
+	m.Replace(c, nil)
+	if got, want := m.Len(), 2; got != want {
+		t.Errorf("m.Len(): got: %d, want: %d", got, want)
+	}
+	if got, want := m.Pop(nil), scene.Scene(c); got != want {
+		t.Errorf("m.Pop(): got: %v, want: %v", got, want)
+	}
+	if err := m.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if a.updated != 1 || b.updated != 0 || c.updated != 0 {
+		t.Errorf("updated counts: got: (%d, %d, %d), want: (1, 0, 0)", a.updated, b.updated, c.updated)
+	}
+	m.Pop(nil)
+	if got := m.Pop(nil); got != nil {
+		t.Errorf("m.Pop() on an empty stack: got: %v, want: nil", got)
+	}
+}
+
+func TestManagerTransition(t *testing.T) {
+	var m scene.Manager
+	m.Push(&testScene{}, &scene.TransitionOptions{Duration: 10})
+	if m.IsTransitioning() {
+		t.Errorf("pushing the first scene must not start a transition")
+	}
+
+	m.Push(&testScene{}, &scene.TransitionOptions{Duration: 3})
+	for i := 0; i < 3; i++ {
+		if !m.IsTransitioning() {
+			t.Errorf("m.IsTransitioning() at tick %d: got: false, want: true", i)
+		}
+		if err := m.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if m.IsTransitioning() {
+		t.Errorf("m.IsTransitioning() after the duration: got: true, want: false")
+	}
+}
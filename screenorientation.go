@@ -0,0 +1,59 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// ScreenOrientationType represents an orientation of a screen.
+type ScreenOrientationType = ui.ScreenOrientation
+
+// ScreenOrientationTypes
+const (
+	// ScreenOrientationUnspecified represents an unknown orientation, or no lock of the orientation.
+	ScreenOrientationUnspecified ScreenOrientationType = ui.ScreenOrientationUnspecified
+	ScreenOrientationPortrait    ScreenOrientationType = ui.ScreenOrientationPortrait
+	ScreenOrientationLandscape   ScreenOrientationType = ui.ScreenOrientationLandscape
+)
+
+// ScreenOrientation returns the current orientation of the screen.
+//
+// On desktops, ScreenOrientation returns the orientation of the monitor the window belongs to.
+// On mobiles, ScreenOrientation returns the orientation of the view.
+//
+// ScreenOrientation might return ScreenOrientationUnspecified when the orientation is not determined yet.
+//
+// ScreenOrientation is concurrent-safe.
+func ScreenOrientation() ScreenOrientationType {
+	return ui.Get().ScreenOrientation()
+}
+
+// SetScreenOrientationLock locks the screen orientation.
+// ScreenOrientationUnspecified unlocks the screen orientation.
+//
+// On browsers, SetScreenOrientationLock uses the Screen Orientation API.
+// Most browsers can lock the orientation only in the fullscreen mode, and otherwise SetScreenOrientationLock
+// does nothing but leave an error message in console.
+//
+// On Android, SetScreenOrientationLock changes the requested orientation of the activity when the game runs with
+// ebitenmobile.
+//
+// SetScreenOrientationLock does nothing on desktops and iOS so far.
+//
+// SetScreenOrientationLock is concurrent-safe.
+func SetScreenOrientationLock(orientation ScreenOrientationType) {
+	ui.Get().SetScreenOrientationLock(orientation)
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image"
+	"sync"
+)
+
+type screenshotRequests struct {
+	callbacks []func(screenshot *image.RGBA)
+	m         sync.Mutex
+}
+
+var theScreenshotRequests screenshotRequests
+
+func (s *screenshotRequests) add(f func(screenshot *image.RGBA)) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.callbacks = append(s.callbacks, f)
+}
+
+// take reads the screen pixels and calls the requested callbacks.
+// The pixels are read only once and shared by the callbacks requested at the same frame.
+func (s *screenshotRequests) take(screen *Image) {
+	s.m.Lock()
+	callbacks := s.callbacks
+	s.callbacks = nil
+	s.m.Unlock()
+
+	var pix []byte
+	for _, f := range callbacks {
+		img := image.NewRGBA(screen.Bounds())
+		if pix == nil {
+			screen.ReadPixels(img.Pix)
+			pix = img.Pix
+		} else {
+			copy(img.Pix, pix)
+		}
+		f(img)
+	}
+}
+
+// TakeScreenshot requests to take a screenshot of the screen.
+//
+// f is called with the screenshot after the next Draw finishes, and before the next Update is called.
+// If the game stops running before that, f is never called.
+//
+// Reading the screen pixels waits for the GPU to finish all the drawing commands, so this might cause a short stall.
+// Avoid taking screenshots every frame.
+//
+// f is called on the same goroutine as Update and Draw.
+// The screenshot's size is the same as the screen image given to Draw, i.e., the size returned by Layout.
+// The screenshot's pixels are premultiplied by alpha, as *image.RGBA's are.
+//
+// TakeScreenshot is concurrent-safe.
+func TakeScreenshot(f func(screenshot *image.RGBA)) {
+	theScreenshotRequests.add(f)
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestScreenshot(t *testing.T) {
+	const w, h = 16, 8
+	screen := ebiten.NewImage(w, h)
+	defer screen.Dispose()
+
+	var s ebiten.ScreenshotRequestsForTesting
+	var shots []*image.RGBA
+	for i := 0; i < 2; i++ {
+		s.Add(func(screenshot *image.RGBA) {
+			shots = append(shots, screenshot)
+		})
+	}
+
+	screen.Fill(color.RGBA{R: 0xff, A: 0xff})
+	s.Take(screen)
+	if got, want := len(shots), 2; got != want {
+		t.Fatalf("len(shots): got: %d, want: %d", got, want)
+	}
+
+	// Drawing after taking the screenshots doesn't affect them.
+	screen.Fill(color.RGBA{G: 0xff, A: 0xff})
+
+	if shots[0] == shots[1] {
+		t.Errorf("each callback must get a different image")
+	}
+	for _, shot := range shots {
+		if got, want := shot.Bounds(), image.Rect(0, 0, w, h); got != want {
+			t.Errorf("shot.Bounds(): got: %v, want: %v", got, want)
+		}
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				got := shot.RGBAAt(i, j)
+				want := color.RGBA{R: 0xff, A: 0xff}
+				if got != want {
+					t.Errorf("shot.At(%d, %d): got: %v, want: %v", i, j, got, want)
+				}
+			}
+		}
+	}
+
+	// No more callbacks are called.
+	s.Take(screen)
+	if got, want := len(shots), 2; got != want {
+		t.Errorf("len(shots): got: %d, want: %d", got, want)
+	}
+}
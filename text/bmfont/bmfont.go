@@ -0,0 +1,220 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bmfont provides font faces of AngelCode BMFont bitmap fonts.
+//
+// A BMFont consists of a font descriptor file (.fnt) and page images.
+// The text, the XML and the binary (version 3) formats of the descriptor are supported.
+//
+// The font face is a golang.org/x/image/font.Face, so it can be used with the text package.
+package bmfont
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"io/fs"
+	"path"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// invalidCharID is the ID of the glyph for missing characters.
+// The glyph is used as a substitution for characters not in the font.
+const invalidCharID = -1
+
+// Face is a font face of a BMFont.
+//
+// Glyphs are rendered as masks, so the colors of the page images are ignored.
+// If a page image has transparent pixels, its alpha channel is used as the mask.
+// Otherwise, its luminance is used as the mask.
+//
+// Face's positions are always aligned to integers.
+type Face struct {
+	lineHeight int
+	base       int
+	pages      []*image.Alpha
+	chars      map[rune]*char
+	kernings   map[kerningKey]int
+}
+
+// NewFace parses a font descriptor read from r and returns a font face.
+//
+// loadPage is called with each page's file name written in the descriptor, and must return the page image.
+func NewFace(r io.Reader, loadPage func(filename string) (image.Image, error)) (*Face, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	d, err := parseDescriptor(data)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Face{
+		lineHeight: d.lineHeight,
+		base:       d.base,
+		chars:      map[rune]*char{},
+		kernings:   d.kernings,
+	}
+	for _, name := range d.pages {
+		if name == "" {
+			f.pages = append(f.pages, nil)
+			continue
+		}
+		img, err := loadPage(name)
+		if err != nil {
+			return nil, err
+		}
+		f.pages = append(f.pages, toMask(img))
+	}
+	for i := range d.chars {
+		c := &d.chars[i]
+		if c.page < 0 || c.page >= len(f.pages) || f.pages[c.page] == nil {
+			return nil, fmt.Errorf("bmfont: page %d for the character %d not found", c.page, c.id)
+		}
+		f.chars[c.id] = c
+	}
+	return f, nil
+}
+
+// NewFaceFromFS loads a font descriptor file and its page images from fsys, and returns a font face.
+//
+// The page images' file names are resolved relatively to the directory of the descriptor file.
+// The page images are decoded by image.Decode, so the decoders for the page images' formats must be registered,
+// e.g., by importing image/png.
+func NewFaceFromFS(fsys fs.FS, name string) (*Face, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	dir := path.Dir(name)
+	return NewFace(file, func(filename string) (image.Image, error) {
+		f, err := fsys.Open(path.Join(dir, filename))
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		img, _, err := image.Decode(f)
+		if err != nil {
+			return nil, fmt.Errorf("bmfont: decoding %s failed: %w", filename, err)
+		}
+		return img, nil
+	})
+}
+
+// toMask converts a page image to an alpha mask.
+func toMask(img image.Image) *image.Alpha {
+	b := img.Bounds()
+	opaque := true
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		opaque = o.Opaque()
+	} else {
+	loop:
+		for j := b.Min.Y; j < b.Max.Y; j++ {
+			for i := b.Min.X; i < b.Max.X; i++ {
+				if _, _, _, a := img.At(i, j).RGBA(); a != 0xffff {
+					opaque = false
+					break loop
+				}
+			}
+		}
+	}
+
+	m := image.NewAlpha(b)
+	for j := b.Min.Y; j < b.Max.Y; j++ {
+		for i := b.Min.X; i < b.Max.X; i++ {
+			c := img.At(i, j)
+			if opaque {
+				m.SetAlpha(i, j, color.Alpha{A: color.GrayModel.Convert(c).(color.Gray).Y})
+				continue
+			}
+			_, _, _, a := c.RGBA()
+			m.SetAlpha(i, j, color.Alpha{A: uint8(a >> 8)})
+		}
+	}
+	return m
+}
+
+// char returns the character for r.
+// If r is not found, char returns the glyph for missing characters if exists, or nil.
+func (f *Face) char(r rune) *char {
+	if c, ok := f.chars[r]; ok {
+		return c
+	}
+	return f.chars[invalidCharID]
+}
+
+// Close implements font.Face's Close.
+func (f *Face) Close() error {
+	return nil
+}
+
+// Glyph implements font.Face's Glyph.
+func (f *Face) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	c := f.char(r)
+	if c == nil {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	x := dot.X.Round() + c.xoffset
+	y := dot.Y.Round() - f.base + c.yoffset
+	dr = image.Rect(x, y, x+c.width, y+c.height)
+	maskp = image.Pt(f.pages[c.page].Rect.Min.X+c.x, f.pages[c.page].Rect.Min.Y+c.y)
+	return dr, f.pages[c.page], maskp, fixed.I(c.xadvance), true
+}
+
+// GlyphBounds implements font.Face's GlyphBounds.
+func (f *Face) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	c := f.char(r)
+	if c == nil {
+		return fixed.Rectangle26_6{}, 0, false
+	}
+	x := c.xoffset
+	y := c.yoffset - f.base
+	bounds = fixed.R(x, y, x+c.width, y+c.height)
+	return bounds, fixed.I(c.xadvance), true
+}
+
+// GlyphAdvance implements font.Face's GlyphAdvance.
+func (f *Face) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	c := f.char(r)
+	if c == nil {
+		return 0, false
+	}
+	return fixed.I(c.xadvance), true
+}
+
+// Kern implements font.Face's Kern.
+func (f *Face) Kern(r0, r1 rune) fixed.Int26_6 {
+	return fixed.I(f.kernings[kerningKey{first: r0, second: r1}])
+}
+
+// Metrics implements font.Face's Metrics.
+func (f *Face) Metrics() font.Metrics {
+	return font.Metrics{
+		Height:  fixed.I(f.lineHeight),
+		Ascent:  fixed.I(f.base),
+		Descent: fixed.I(f.lineHeight - f.base),
+	}
+}
+
+var _ font.Face = (*Face)(nil)
@@ -0,0 +1,236 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bmfont_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/hajimehoshi/ebiten/v2/text/bmfont"
+)
+
+const textDescriptor = `info face="Test Font" size=8 bold=0 italic=0 charset="" unicode=1 stretchH=100 smooth=0 aa=1 padding=0,0,0,0 spacing=1,1
+common lineHeight=10 base=8 scaleW=16 scaleH=8 pages=1 packed=0
+page id=0 file="page 0.png"
+chars count=2
+char id=65 x=0 y=0 width=4 height=6 xoffset=1 yoffset=2 xadvance=6 page=0 chnl=15
+char id=66 x=4 y=0 width=3 height=8 xoffset=0 yoffset=0 xadvance=5 page=0 chnl=15
+kernings count=1
+kerning first=65 second=66 amount=-2
+`
+
+const xmlDescriptor = `<?xml version="1.0"?>
+<font>
+  <info face="Test Font" size="8"/>
+  <common lineHeight="10" base="8" scaleW="16" scaleH="8" pages="1" packed="0"/>
+  <pages>
+    <page id="0" file="page 0.png"/>
+  </pages>
+  <chars count="2">
+    <char id="65" x="0" y="0" width="4" height="6" xoffset="1" yoffset="2" xadvance="6" page="0" chnl="15"/>
+    <char id="66" x="4" y="0" width="3" height="8" xoffset="0" yoffset="0" xadvance="5" page="0" chnl="15"/>
+  </chars>
+  <kernings count="1">
+    <kerning first="65" second="66" amount="-2"/>
+  </kernings>
+</font>
+`
+
+func binaryDescriptor() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("BMF\x03")
+	block := func(typ byte, data []byte) {
+		buf.WriteByte(typ)
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(len(data)))
+		buf.Write(data)
+	}
+	le := func(vs ...any) []byte {
+		var b bytes.Buffer
+		for _, v := range vs {
+			_ = binary.Write(&b, binary.LittleEndian, v)
+		}
+		return b.Bytes()
+	}
+	block(2, le(uint16(10), uint16(8), uint16(16), uint16(8), uint16(1), uint8(0), uint8(0), uint8(0), uint8(0), uint8(0)))
+	block(3, []byte("page 0.png\x00"))
+	block(4, le(
+		uint32(65), uint16(0), uint16(0), uint16(4), uint16(6), int16(1), int16(2), int16(6), uint8(0), uint8(15),
+		uint32(66), uint16(4), uint16(0), uint16(3), uint16(8), int16(0), int16(0), int16(5), uint8(0), uint8(15),
+	))
+	block(5, le(uint32(65), uint32(66), int16(-2)))
+	return buf.Bytes()
+}
+
+func pageImage() image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 8))
+	// 'A' is a filled rectangle.
+	for j := 0; j < 6; j++ {
+		for i := 0; i < 4; i++ {
+			img.Set(i, j, color.White)
+		}
+	}
+	// 'B' is a vertical line.
+	for j := 0; j < 8; j++ {
+		img.Set(4, j, color.White)
+	}
+	return img
+}
+
+func loadPage(t *testing.T) func(string) (image.Image, error) {
+	return func(name string) (image.Image, error) {
+		if name != "page 0.png" {
+			t.Errorf("page name: got: %q, want: %q", name, "page 0.png")
+		}
+		return pageImage(), nil
+	}
+}
+
+func checkFace(t *testing.T, face font.Face) {
+	t.Helper()
+
+	m := face.Metrics()
+	if got, want := m.Height, fixed.I(10); got != want {
+		t.Errorf("Height: got: %v, want: %v", got, want)
+	}
+	if got, want := m.Ascent, fixed.I(8); got != want {
+		t.Errorf("Ascent: got: %v, want: %v", got, want)
+	}
+	if got, want := m.Descent, fixed.I(2); got != want {
+		t.Errorf("Descent: got: %v, want: %v", got, want)
+	}
+
+	b, a, ok := face.GlyphBounds('A')
+	if !ok {
+		t.Fatalf("GlyphBounds('A') must succeed")
+	}
+	if got, want := b, fixed.R(1, -6, 5, 0); got != want {
+		t.Errorf("GlyphBounds('A'): got: %v, want: %v", got, want)
+	}
+	if got, want := a, fixed.I(6); got != want {
+		t.Errorf("GlyphBounds('A') advance: got: %v, want: %v", got, want)
+	}
+	if a, _ := face.GlyphAdvance('B'); a != fixed.I(5) {
+		t.Errorf("GlyphAdvance('B'): got: %v, want: %v", a, fixed.I(5))
+	}
+	if _, ok := face.GlyphAdvance('C'); ok {
+		t.Errorf("GlyphAdvance('C') must fail")
+	}
+	if got, want := face.Kern('A', 'B'), fixed.I(-2); got != want {
+		t.Errorf("Kern('A', 'B'): got: %v, want: %v", got, want)
+	}
+	if got, want := face.Kern('B', 'A'), fixed.I(0); got != want {
+		t.Errorf("Kern('B', 'A'): got: %v, want: %v", got, want)
+	}
+	if got, want := font.MeasureString(face, "AB"), fixed.I(6-2+5); got != want {
+		t.Errorf("MeasureString: got: %v, want: %v", got, want)
+	}
+
+	// Draw "AB" with the dot at (0, 8).
+	dst := image.NewAlpha(image.Rect(0, 0, 16, 10))
+	d := font.Drawer{
+		Dst:  dst,
+		Src:  image.White,
+		Face: face,
+		Dot:  fixed.P(0, 8),
+	}
+	d.DrawString("AB")
+	for j := 0; j < 10; j++ {
+		for i := 0; i < 16; i++ {
+			want := uint8(0)
+			// 'A' is at (1, 2)-(5, 8), and 'B' is at (4, 0)-(5, 8) after the kerning.
+			if (i >= 1 && i < 5 && j >= 2 && j < 8) || (i == 4 && j < 8) {
+				want = 0xff
+			}
+			if got := dst.AlphaAt(i, j).A; got != want {
+				t.Errorf("AlphaAt(%d, %d): got: %d, want: %d", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestTextFormat(t *testing.T) {
+	face, err := bmfont.NewFace(bytes.NewReader([]byte(textDescriptor)), loadPage(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFace(t, face)
+}
+
+func TestXMLFormat(t *testing.T) {
+	face, err := bmfont.NewFace(bytes.NewReader([]byte(xmlDescriptor)), loadPage(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFace(t, face)
+}
+
+func TestBinaryFormat(t *testing.T) {
+	face, err := bmfont.NewFace(bytes.NewReader(binaryDescriptor()), loadPage(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFace(t, face)
+}
+
+func TestNewFaceFromFS(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, pageImage()); err != nil {
+		t.Fatal(err)
+	}
+	fsys := fstest.MapFS{
+		"fonts/test.fnt":   {Data: []byte(textDescriptor)},
+		"fonts/page 0.png": {Data: buf.Bytes()},
+	}
+	face, err := bmfont.NewFaceFromFS(fsys, "fonts/test.fnt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFace(t, face)
+}
+
+func TestInvalidChar(t *testing.T) {
+	const descriptor = `common lineHeight=10 base=8
+page id=0 file="page 0.png"
+char id=-1 x=4 y=0 width=3 height=8 xoffset=0 yoffset=0 xadvance=7 page=0 chnl=15
+`
+	face, err := bmfont.NewFace(bytes.NewReader([]byte(descriptor)), loadPage(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, ok := face.GlyphAdvance('Z')
+	if !ok {
+		t.Errorf("GlyphAdvance('Z') must succeed with the invalid char glyph")
+	}
+	if a != fixed.I(7) {
+		t.Errorf("GlyphAdvance('Z'): got: %v, want: %v", a, fixed.I(7))
+	}
+}
+
+func TestMissingPage(t *testing.T) {
+	const descriptor = `common lineHeight=10 base=8
+char id=65 x=0 y=0 width=4 height=6 xoffset=1 yoffset=2 xadvance=6 page=0 chnl=15
+`
+	if _, err := bmfont.NewFace(bytes.NewReader([]byte(descriptor)), loadPage(t)); err == nil {
+		t.Errorf("NewFace must fail when the page is missing")
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bmfont
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type char struct {
+	id       rune
+	x        int
+	y        int
+	width    int
+	height   int
+	xoffset  int
+	yoffset  int
+	xadvance int
+	page     int
+}
+
+type kerningKey struct {
+	first  rune
+	second rune
+}
+
+// descriptor is a parsed font descriptor file.
+type descriptor struct {
+	lineHeight int
+	base       int
+	pages      []string
+	chars      []char
+	kernings   map[kerningKey]int
+}
+
+// parseDescriptor parses a font descriptor in the text, the XML or the binary format.
+func parseDescriptor(data []byte) (*descriptor, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte("BMF")):
+		return parseBinary(data)
+	case bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")):
+		return parseXML(data)
+	default:
+		return parseText(data)
+	}
+}
+
+func (d *descriptor) setPage(id int, file string) error {
+	if id < 0 {
+		return fmt.Errorf("bmfont: invalid page ID: %d", id)
+	}
+	for len(d.pages) <= id {
+		d.pages = append(d.pages, "")
+	}
+	d.pages[id] = file
+	return nil
+}
+
+func (d *descriptor) addKerning(first, second rune, amount int) {
+	if d.kernings == nil {
+		d.kernings = map[kerningKey]int{}
+	}
+	d.kernings[kerningKey{first: first, second: second}] = amount
+}
+
+// parseText parses a descriptor in the text format like:
+//
+//	common lineHeight=16 base=13 pages=1
+//	page id=0 file="font_0.png"
+//	char id=65 x=0 y=0 width=8 height=10 xoffset=0 yoffset=3 xadvance=9 page=0 chnl=15
+//	kerning first=65 second=86 amount=-1
+func parseText(data []byte) (*descriptor, error) {
+	d := &descriptor{}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; s.Scan(); lineNum++ {
+		tag, attrs, err := parseTextLine(s.Text())
+		if err != nil {
+			return nil, fmt.Errorf("bmfont: line %d: %w", lineNum, err)
+		}
+
+		var ints map[string]int
+		switch tag {
+		case "common", "page", "char", "kerning":
+			ints = map[string]int{}
+			for k, v := range attrs {
+				if k == "file" {
+					continue
+				}
+				n, err := strconv.Atoi(v)
+				if err != nil {
+					return nil, fmt.Errorf("bmfont: line %d: invalid value for %s: %q", lineNum, k, v)
+				}
+				ints[k] = n
+			}
+		}
+
+		switch tag {
+		case "common":
+			d.lineHeight = ints["lineHeight"]
+			d.base = ints["base"]
+		case "page":
+			if err := d.setPage(ints["id"], attrs["file"]); err != nil {
+				return nil, err
+			}
+		case "char":
+			d.chars = append(d.chars, char{
+				id:       rune(ints["id"]),
+				x:        ints["x"],
+				y:        ints["y"],
+				width:    ints["width"],
+				height:   ints["height"],
+				xoffset:  ints["xoffset"],
+				yoffset:  ints["yoffset"],
+				xadvance: ints["xadvance"],
+				page:     ints["page"],
+			})
+		case "kerning":
+			d.addKerning(rune(ints["first"]), rune(ints["second"]), ints["amount"])
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// parseTextLine parses a line like `tag key1=value1 key2="value 2"`.
+func parseTextLine(line string) (string, map[string]string, error) {
+	line = strings.TrimSpace(line)
+	tag, rest, _ := strings.Cut(line, " ")
+	attrs := map[string]string{}
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			break
+		}
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			return "", nil, fmt.Errorf("attribute without a value: %q", rest)
+		}
+		if strings.HasPrefix(value, `"`) {
+			end := strings.IndexByte(value[1:], '"')
+			if end < 0 {
+				return "", nil, fmt.Errorf("unterminated quote: %q", value)
+			}
+			attrs[key] = value[1 : end+1]
+			rest = value[end+2:]
+			continue
+		}
+		value, rest, _ = strings.Cut(value, " ")
+		attrs[key] = value
+	}
+	return tag, attrs, nil
+}
+
+// parseXML parses a descriptor in the XML format.
+func parseXML(data []byte) (*descriptor, error) {
+	var f struct {
+		Common struct {
+			LineHeight int `xml:"lineHeight,attr"`
+			Base       int `xml:"base,attr"`
+		} `xml:"common"`
+		Pages []struct {
+			ID   int    `xml:"id,attr"`
+			File string `xml:"file,attr"`
+		} `xml:"pages>page"`
+		Chars []struct {
+			ID       int `xml:"id,attr"`
+			X        int `xml:"x,attr"`
+			Y        int `xml:"y,attr"`
+			Width    int `xml:"width,attr"`
+			Height   int `xml:"height,attr"`
+			XOffset  int `xml:"xoffset,attr"`
+			YOffset  int `xml:"yoffset,attr"`
+			XAdvance int `xml:"xadvance,attr"`
+			Page     int `xml:"page,attr"`
+		} `xml:"chars>char"`
+		Kernings []struct {
+			First  int `xml:"first,attr"`
+			Second int `xml:"second,attr"`
+			Amount int `xml:"amount,attr"`
+		} `xml:"kernings>kerning"`
+	}
+	if err := xml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("bmfont: %w", err)
+	}
+
+	d := &descriptor{
+		lineHeight: f.Common.LineHeight,
+		base:       f.Common.Base,
+	}
+	for _, p := range f.Pages {
+		if err := d.setPage(p.ID, p.File); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range f.Chars {
+		d.chars = append(d.chars, char{
+			id:       rune(c.ID),
+			x:        c.X,
+			y:        c.Y,
+			width:    c.Width,
+			height:   c.Height,
+			xoffset:  c.XOffset,
+			yoffset:  c.YOffset,
+			xadvance: c.XAdvance,
+			page:     c.Page,
+		})
+	}
+	for _, k := range f.Kernings {
+		d.addKerning(rune(k.First), rune(k.Second), k.Amount)
+	}
+	return d, nil
+}
+
+// parseBinary parses a descriptor in the binary format version 3.
+func parseBinary(data []byte) (*descriptor, error) {
+	if len(data) < 4 || data[3] != 3 {
+		return nil, fmt.Errorf("bmfont: unsupported binary format")
+	}
+	data = data[4:]
+
+	le := binary.LittleEndian
+	d := &descriptor{}
+	for len(data) > 0 {
+		if len(data) < 5 {
+			return nil, fmt.Errorf("bmfont: unexpected end of the binary data")
+		}
+		typ := data[0]
+		size := int(le.Uint32(data[1:5]))
+		data = data[5:]
+		if len(data) < size {
+			return nil, fmt.Errorf("bmfont: unexpected end of the binary data")
+		}
+		block := data[:size]
+		data = data[size:]
+
+		switch typ {
+		case 1:
+			// The info block is not used.
+		case 2:
+			if len(block) < 4 {
+				return nil, fmt.Errorf("bmfont: invalid common block")
+			}
+			d.lineHeight = int(le.Uint16(block[0:2]))
+			d.base = int(le.Uint16(block[2:4]))
+		case 3:
+			for id := 0; len(block) > 0; id++ {
+				end := bytes.IndexByte(block, 0)
+				if end < 0 {
+					return nil, fmt.Errorf("bmfont: invalid pages block")
+				}
+				if err := d.setPage(id, string(block[:end])); err != nil {
+					return nil, err
+				}
+				block = block[end+1:]
+			}
+		case 4:
+			const charSize = 20
+			if len(block)%charSize != 0 {
+				return nil, fmt.Errorf("bmfont: invalid chars block")
+			}
+			for ; len(block) > 0; block = block[charSize:] {
+				d.chars = append(d.chars, char{
+					id:       rune(int32(le.Uint32(block[0:4]))),
+					x:        int(le.Uint16(block[4:6])),
+					y:        int(le.Uint16(block[6:8])),
+					width:    int(le.Uint16(block[8:10])),
+					height:   int(le.Uint16(block[10:12])),
+					xoffset:  int(int16(le.Uint16(block[12:14]))),
+					yoffset:  int(int16(le.Uint16(block[14:16]))),
+					xadvance: int(int16(le.Uint16(block[16:18]))),
+					page:     int(block[18]),
+				})
+			}
+		case 5:
+			const kerningSize = 10
+			if len(block)%kerningSize != 0 {
+				return nil, fmt.Errorf("bmfont: invalid kerning pairs block")
+			}
+			for ; len(block) > 0; block = block[kerningSize:] {
+				d.addKerning(rune(le.Uint32(block[0:4])), rune(le.Uint32(block[4:8])), int(int16(le.Uint16(block[8:10]))))
+			}
+		default:
+			return nil, fmt.Errorf("bmfont: unknown block type: %d", typ)
+		}
+	}
+	return d, nil
+}
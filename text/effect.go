@@ -0,0 +1,303 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Effect represents decorations of glyphs for DrawWithEffect.
+//
+// The decorations are rendered in this order: the shadow, the glow, the outline, and the glyphs themselves.
+// Each layer is rendered for the whole text before the next layer, so an outline never covers adjacent glyphs.
+type Effect struct {
+	// OutlineWidth is the width of the outline in pixels.
+	// The outline is rendered when OutlineWidth is positive and OutlineColor is not nil.
+	OutlineWidth int
+
+	// OutlineColor is the color of the outline.
+	OutlineColor color.Color
+
+	// ShadowOffsetX and ShadowOffsetY are the offset of the drop shadow in pixels.
+	ShadowOffsetX float64
+	ShadowOffsetY float64
+
+	// ShadowBlur is the blur radius of the drop shadow in pixels.
+	// The default (zero) value means that the shadow is not blurred.
+	ShadowBlur int
+
+	// ShadowColor is the color of the drop shadow.
+	// The shadow is rendered when ShadowColor is not nil.
+	// The shadow has the shape of the outlined glyphs.
+	ShadowColor color.Color
+
+	// GlowRadius is the radius of the glow around the outlined glyphs in pixels.
+	// The glow is rendered when GlowRadius is positive and GlowColor is not nil.
+	GlowRadius int
+
+	// GlowColor is the color of the glow.
+	GlowColor color.Color
+}
+
+// DrawWithEffect draws a given text on a given destination image dst with decorations like
+// an outline, a drop shadow, and a glow.
+//
+// face, text and options work in the same way as DrawWithOptions.
+// The sizes in effect are in the text's coordinate, so they are transformed by options' GeoM.
+// The colors of the decorations are multiplied by the alpha value of options' ColorScale.
+// If effect is nil, DrawWithEffect works in the same way as DrawWithOptions.
+//
+// The decorated glyph images are created on CPU when they are not cached, which is slower than
+// creating regular glyph images. The cache is shared among DrawWithEffect calls with the same face and effect sizes.
+//
+// Be careful that the passed font face is held by this package and is never released.
+// This is a known issue (#498).
+//
+// DrawWithEffect is concurrent-safe.
+func DrawWithEffect(dst *ebiten.Image, text string, face font.Face, options *ebiten.DrawImageOptions, effect *Effect) {
+	if effect == nil {
+		DrawWithOptions(dst, text, face, options)
+		return
+	}
+
+	textM.Lock()
+	defer textM.Unlock()
+
+	outline := effect.OutlineWidth
+	if outline < 0 || effect.OutlineColor == nil {
+		outline = 0
+	}
+
+	if effect.ShadowColor != nil {
+		blur := effect.ShadowBlur
+		if blur < 0 {
+			blur = 0
+		}
+		ox := fixed.Int26_6(math.Round(effect.ShadowOffsetX * (1 << 6)))
+		oy := fixed.Int26_6(math.Round(effect.ShadowOffsetY * (1 << 6)))
+		drawEffectLayer(dst, text, face, options, outline, blur, fixed.Point26_6{X: ox, Y: oy}, effect.ShadowColor)
+	}
+	if effect.GlowRadius > 0 && effect.GlowColor != nil {
+		drawEffectLayer(dst, text, face, options, outline, effect.GlowRadius, fixed.Point26_6{}, effect.GlowColor)
+	}
+	if outline > 0 {
+		drawEffectLayer(dst, text, face, options, outline, 0, fixed.Point26_6{}, effect.OutlineColor)
+	}
+	forEachGlyph(text, face, func(r rune, topleft, offset fixed.Point26_6) {
+		drawGlyph(dst, getGlyphImage(face, r, offset), topleft, options)
+	})
+	cleanCache(face)
+}
+
+func drawEffectLayer(dst *ebiten.Image, text string, face font.Face, options *ebiten.DrawImageOptions, dilation, blur int, shift fixed.Point26_6, clr color.Color) {
+	op := &ebiten.DrawImageOptions{}
+	if options != nil {
+		*op = *options
+	}
+	op.ColorScale.Reset()
+	op.ColorScale.ScaleWithColor(clr)
+	if options != nil {
+		op.ColorScale.ScaleAlpha(options.ColorScale.A())
+	}
+
+	forEachGlyph(text, face, func(r rune, topleft, offset fixed.Point26_6) {
+		img, padding := getEffectImage(face, r, offset, dilation, blur)
+		p := fixed.Int26_6(padding << 6)
+		drawGlyph(dst, img, fixed.Point26_6{
+			X: topleft.X - p + shift.X,
+			Y: topleft.Y - p + shift.Y,
+		}, op)
+	})
+}
+
+type effectImageCacheKey struct {
+	rune     rune
+	xoffset  fixed.Int26_6
+	dilation int
+	blur     int
+}
+
+type effectImageCacheEntry struct {
+	image   *ebiten.Image
+	padding int
+	atime   int64
+}
+
+var (
+	effectImageCache = map[font.Face]map[effectImageCacheKey]*effectImageCacheEntry{}
+)
+
+// getEffectImage returns the glyph image dilated by dilation pixels and then blurred by blur pixels.
+// getEffectImage also returns the padding size in pixels around the original glyph image.
+func getEffectImage(face font.Face, r rune, offset fixed.Point26_6, dilation, blur int) (*ebiten.Image, int) {
+	if _, ok := effectImageCache[face]; !ok {
+		effectImageCache[face] = map[effectImageCacheKey]*effectImageCacheEntry{}
+	}
+
+	key := effectImageCacheKey{
+		rune:     r,
+		xoffset:  offset.X,
+		dilation: dilation,
+		blur:     blur,
+	}
+	if e, ok := effectImageCache[face][key]; ok {
+		e.atime = now()
+		return e.image, e.padding
+	}
+
+	var img *ebiten.Image
+	var padding int
+	if rgba := rasterizeGlyph(face, r, offset); rgba != nil {
+		var a *image.Alpha
+		a, padding = effectMask(rgba, dilation, blur)
+		dst := image.NewRGBA(a.Bounds())
+		for i, v := range a.Pix {
+			dst.Pix[4*i] = v
+			dst.Pix[4*i+1] = v
+			dst.Pix[4*i+2] = v
+			dst.Pix[4*i+3] = v
+		}
+		img = ebiten.NewImageFromImage(dst)
+	}
+	effectImageCache[face][key] = &effectImageCacheEntry{
+		image:   img,
+		padding: padding,
+		atime:   now(),
+	}
+	return img, padding
+}
+
+// effectMask returns the alpha mask of src dilated by dilation pixels and then blurred by blur pixels,
+// and the padding size in pixels added to each side of the mask.
+func effectMask(src *image.RGBA, dilation, blur int) (*image.Alpha, int) {
+	// The dilation reaches one more pixel for anti-aliasing.
+	var dilationPadding int
+	if dilation > 0 {
+		dilationPadding = dilation + 1
+	}
+
+	// The blur is approximated by three box blurs, which is close to a Gaussian blur.
+	var boxRadius int
+	if blur > 0 {
+		boxRadius = (blur + 2) / 3
+	}
+	padding := dilationPadding + 3*boxRadius
+
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	w, h := sw+2*padding, sh+2*padding
+	buf := make([]float32, w*h)
+	for j := 0; j < sh; j++ {
+		for i := 0; i < sw; i++ {
+			buf[(j+padding)*w+i+padding] = float32(src.Pix[j*src.Stride+4*i+3]) / 0xff
+		}
+	}
+
+	if dilation > 0 {
+		buf = dilateMask(buf, w, h, dilation)
+	}
+	if boxRadius > 0 {
+		tmp := make([]float32, w*h)
+		for k := 0; k < 3; k++ {
+			boxBlurMask(tmp, buf, w, h, boxRadius, 1, w)
+			boxBlurMask(buf, tmp, h, w, boxRadius, w, 1)
+		}
+	}
+
+	dst := image.NewAlpha(image.Rect(0, 0, w, h))
+	for i, v := range buf {
+		dst.Pix[i] = uint8(math.Round(float64(min32f(max32f(v, 0), 1)) * 0xff))
+	}
+	return dst, padding
+}
+
+// dilateMask returns the mask dilated with a circle of the given radius.
+// The edge of the circle is anti-aliased.
+func dilateMask(src []float32, w, h int, radius int) []float32 {
+	type weight struct {
+		dx, dy int
+		w      float32
+	}
+	var weights []weight
+	r := radius + 1
+	for dy := -r; dy <= r; dy++ {
+		for dx := -r; dx <= r; dx++ {
+			v := float32(radius) + 1 - float32(math.Hypot(float64(dx), float64(dy)))
+			if v <= 0 {
+				continue
+			}
+			weights = append(weights, weight{dx: dx, dy: dy, w: min32f(v, 1)})
+		}
+	}
+
+	dst := make([]float32, w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			var v float32
+			for _, wt := range weights {
+				x, y := i+wt.dx, j+wt.dy
+				if x < 0 || y < 0 || x >= w || y >= h {
+					continue
+				}
+				v = max32f(v, src[y*w+x]*wt.w)
+			}
+			dst[j*w+i] = v
+		}
+	}
+	return dst
+}
+
+// boxBlurMask blurs the lines of src with a box of the given radius and writes the result to dst.
+// lineLen and lineCount are the length and the number of the lines.
+// step is the distance between adjacent values in a line, and stride is the distance between adjacent lines.
+func boxBlurMask(dst, src []float32, lineLen, lineCount int, radius int, step, stride int) {
+	scale := 1 / float32(2*radius+1)
+	for l := 0; l < lineCount; l++ {
+		base := l * stride
+		var sum float32
+		for k := 0; k <= radius && k < lineLen; k++ {
+			sum += src[base+k*step]
+		}
+		for k := 0; k < lineLen; k++ {
+			dst[base+k*step] = sum * scale
+			if n := k + radius + 1; n < lineLen {
+				sum += src[base+n*step]
+			}
+			if o := k - radius; o >= 0 {
+				sum -= src[base+o*step]
+			}
+		}
+	}
+}
+
+func min32f(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max32f(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package richtext
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+type style struct {
+	color color.Color
+	bold  bool
+	scale float64
+}
+
+type segmentKind int
+
+const (
+	segmentText segmentKind = iota
+	segmentImage
+	segmentRuby
+)
+
+// segment is a parsed piece of markup.
+//
+// For segmentText, text is the text. For segmentImage, text is the image name.
+// For segmentRuby, text is the base text and ruby is the annotation.
+type segment struct {
+	kind  segmentKind
+	text  string
+	ruby  string
+	style style
+}
+
+type tag struct {
+	name  string
+	style style
+}
+
+// parse parses the markup and returns the segments.
+func parse(markup string) ([]segment, error) {
+	var segs []segment
+	var stack []tag
+	current := style{scale: 1}
+
+	var buf strings.Builder
+	flush := func() {
+		if buf.Len() == 0 {
+			return
+		}
+		segs = append(segs, segment{
+			kind:  segmentText,
+			text:  buf.String(),
+			style: current,
+		})
+		buf.Reset()
+	}
+
+	for len(markup) > 0 {
+		idx := strings.IndexByte(markup, '[')
+		if idx < 0 {
+			buf.WriteString(markup)
+			break
+		}
+		buf.WriteString(markup[:idx])
+		markup = markup[idx:]
+
+		if strings.HasPrefix(markup, "[[") {
+			buf.WriteByte('[')
+			markup = markup[2:]
+			continue
+		}
+
+		end := strings.IndexByte(markup, ']')
+		if end < 0 {
+			return nil, fmt.Errorf("richtext: unterminated tag: %q", markup)
+		}
+		content := markup[1:end]
+		markup = markup[end+1:]
+
+		if strings.HasPrefix(content, "/") {
+			name := content[1:]
+			if len(stack) == 0 || stack[len(stack)-1].name != name {
+				return nil, fmt.Errorf("richtext: unexpected closing tag: [%s]", content)
+			}
+			flush()
+			current = stack[len(stack)-1].style
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(content, "=")
+		switch name {
+		case "b":
+			if hasValue {
+				return nil, fmt.Errorf("richtext: [b] must not have a value")
+			}
+			flush()
+			stack = append(stack, tag{name: name, style: current})
+			current.bold = true
+		case "color":
+			clr, err := parseColor(value)
+			if err != nil {
+				return nil, err
+			}
+			flush()
+			stack = append(stack, tag{name: name, style: current})
+			current.color = clr
+		case "size":
+			s, err := strconv.ParseFloat(value, 64)
+			if err != nil || s <= 0 {
+				return nil, fmt.Errorf("richtext: invalid size: %q", value)
+			}
+			flush()
+			stack = append(stack, tag{name: name, style: current})
+			current.scale *= s
+		case "img":
+			if value == "" {
+				return nil, fmt.Errorf("richtext: [img] must have an image name")
+			}
+			flush()
+			segs = append(segs, segment{
+				kind:  segmentImage,
+				text:  value,
+				style: current,
+			})
+		case "ruby":
+			if value == "" {
+				return nil, fmt.Errorf("richtext: [ruby] must have an annotation")
+			}
+			end := strings.Index(markup, "[/ruby]")
+			if end < 0 {
+				return nil, fmt.Errorf("richtext: [ruby] is not closed")
+			}
+			base := markup[:end]
+			if strings.ContainsAny(base, "[\n") {
+				return nil, fmt.Errorf("richtext: the base text of [ruby] must not include tags or newlines: %q", base)
+			}
+			markup = markup[end+len("[/ruby]"):]
+			flush()
+			segs = append(segs, segment{
+				kind:  segmentRuby,
+				text:  base,
+				ruby:  value,
+				style: current,
+			})
+		default:
+			return nil, fmt.Errorf("richtext: unknown tag: [%s]", content)
+		}
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("richtext: [%s] is not closed", stack[len(stack)-1].name)
+	}
+	flush()
+	return segs, nil
+}
+
+// parseColor parses a color in the form of #rgb, #rrggbb or #rrggbbaa.
+func parseColor(str string) (color.Color, error) {
+	s := strings.TrimPrefix(str, "#")
+	if len(s) == len(str) {
+		return nil, fmt.Errorf("richtext: invalid color: %q", str)
+	}
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) == 6 {
+		s += "ff"
+	}
+	if len(s) != 8 {
+		return nil, fmt.Errorf("richtext: invalid color: %q", str)
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("richtext: invalid color: %q", str)
+	}
+	// The color is not premultiplied.
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
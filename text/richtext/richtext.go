@@ -0,0 +1,463 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package richtext provides a text layout with simple inline markup for e.g. dialogue boxes and tutorials.
+//
+// The markup consists of texts and tags in square brackets:
+//
+//	[color=#ff0000]...[/color]  draws the text in the color. #rgb, #rrggbb and #rrggbbaa forms are available.
+//	[b]...[/b]                  draws the text in bold.
+//	[size=1.5]...[/size]        scales the text.
+//	[img=name]                  draws the image registered in Options.Images as an inline icon.
+//	[ruby=annotation]...[/ruby] draws the annotation above the base text. The base text must not include tags.
+//	[[                          is a literal '['.
+//
+// Tags can be nested, and must be closed in the reverse order.
+// The '\n' newline character puts the following text on the next line.
+package richtext
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"unicode"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+// Options represents options for New.
+type Options struct {
+	// Face is the font face for the text.
+	// Face is required.
+	Face font.Face
+
+	// BoldFace is the font face for the text in [b] tags.
+	// If BoldFace is nil, the bold text is rendered by drawing Face twice with 1 pixel shift.
+	BoldFace font.Face
+
+	// RubyFace is the font face for ruby annotations.
+	// If RubyFace is nil, Face scaled by 0.5 is used.
+	RubyFace font.Face
+
+	// Images is the images referred by [img] tags.
+	// An inline image is scaled to the ascent of the current font face, and its bottom is aligned to the baseline.
+	Images map[string]*ebiten.Image
+
+	// Color is the default color of the text.
+	// If Color is nil, the text is white.
+	Color color.Color
+
+	// MaxWidth is the maximum width of a line.
+	// A line longer than MaxWidth is wrapped at spaces, or between CJK characters.
+	// The default (zero) value means that lines are not wrapped.
+	MaxWidth float64
+
+	// LineSpacing is the additional space between lines.
+	LineSpacing float64
+}
+
+type itemKind int
+
+const (
+	itemGlyph itemKind = iota
+	itemSpace
+	itemNewline
+	itemImage
+	itemRuby
+)
+
+type item struct {
+	kind  itemKind
+	r     rune
+	image *ebiten.Image
+	base  string
+	ruby  string
+	style style
+	width float64
+
+	// x is the position in the line.
+	x float64
+}
+
+type line struct {
+	items []*item
+
+	width      float64
+	ascent     float64
+	descent    float64
+	rubyHeight float64
+
+	// baseline is the baseline position from the top of the text.
+	baseline float64
+}
+
+// Text is a laid-out text with inline markup.
+type Text struct {
+	options Options
+	lines   []*line
+	width   float64
+	height  float64
+}
+
+// New parses the markup and lays out the text.
+//
+// New returns an error when the markup is invalid, or an image referred by the markup is not found.
+func New(markup string, options *Options) (*Text, error) {
+	if options == nil || options.Face == nil {
+		return nil, fmt.Errorf("richtext: Options.Face is required")
+	}
+
+	segs, err := parse(markup)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Text{
+		options: *options,
+	}
+	items, err := t.items(segs)
+	if err != nil {
+		return nil, err
+	}
+	t.layout(items)
+	return t, nil
+}
+
+// Size returns the size of the laid-out text.
+func (t *Text) Size() (width, height float64) {
+	return t.width, t.height
+}
+
+// LineCount returns the number of the lines after wrapping.
+func (t *Text) LineCount() int {
+	return len(t.lines)
+}
+
+func (t *Text) face(s style) font.Face {
+	if s.bold && t.options.BoldFace != nil {
+		return t.options.BoldFace
+	}
+	return t.options.Face
+}
+
+func (t *Text) rubyFace(s style) (font.Face, float64) {
+	if t.options.RubyFace != nil {
+		return t.options.RubyFace, s.scale
+	}
+	return t.options.Face, s.scale / 2
+}
+
+func fixedToFloat64(x fixed.Int26_6) float64 {
+	return float64(x) / (1 << 6)
+}
+
+func measure(face font.Face, str string) float64 {
+	return fixedToFloat64(font.MeasureString(face, str))
+}
+
+func (t *Text) items(segs []segment) ([]*item, error) {
+	var items []*item
+	for _, seg := range segs {
+		switch seg.kind {
+		case segmentText:
+			face := t.face(seg.style)
+			prevR := rune(-1)
+			for _, r := range seg.text {
+				it := &item{
+					r:     r,
+					style: seg.style,
+				}
+				switch {
+				case r == '\n':
+					it.kind = itemNewline
+					prevR = -1
+					items = append(items, it)
+					continue
+				case unicode.IsSpace(r):
+					it.kind = itemSpace
+				default:
+					it.kind = itemGlyph
+				}
+				a, _ := face.GlyphAdvance(r)
+				if prevR >= 0 {
+					a += face.Kern(prevR, r)
+				}
+				it.width = fixedToFloat64(a) * seg.style.scale
+				prevR = r
+				items = append(items, it)
+			}
+		case segmentImage:
+			img, ok := t.options.Images[seg.text]
+			if !ok {
+				return nil, fmt.Errorf("richtext: image not found: %q", seg.text)
+			}
+			h := t.ascent(seg.style)
+			w := h
+			if s := img.Bounds().Size(); s.Y > 0 {
+				w = h * float64(s.X) / float64(s.Y)
+			}
+			items = append(items, &item{
+				kind:  itemImage,
+				image: img,
+				style: seg.style,
+				width: w,
+			})
+		case segmentRuby:
+			rf, rs := t.rubyFace(seg.style)
+			w := measure(t.face(seg.style), seg.text) * seg.style.scale
+			if rw := measure(rf, seg.ruby) * rs; rw > w {
+				w = rw
+			}
+			items = append(items, &item{
+				kind:  itemRuby,
+				base:  seg.text,
+				ruby:  seg.ruby,
+				style: seg.style,
+				width: w,
+			})
+		}
+	}
+	return items, nil
+}
+
+func (t *Text) ascent(s style) float64 {
+	return fixedToFloat64(t.face(s).Metrics().Ascent) * s.scale
+}
+
+func (t *Text) descent(s style) float64 {
+	return fixedToFloat64(t.face(s).Metrics().Descent) * s.scale
+}
+
+// isBreakable reports whether a line can be broken around the item regardless of spaces.
+func isBreakable(it *item) bool {
+	switch it.kind {
+	case itemImage, itemRuby:
+		return true
+	case itemGlyph:
+		return unicode.In(it.r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+			(it.r >= 0x3000 && it.r <= 0x303f) || (it.r >= 0xff00 && it.r <= 0xffef)
+	}
+	return false
+}
+
+// isNoBreakBefore reports whether r must not be at the beginning of a line.
+func isNoBreakBefore(r rune) bool {
+	switch r {
+	case '、', '。', '，', '．', '）', '」', '』', '】', '〉', '》', '！', '？', 'ー', '…', 'っ', 'ゃ', 'ゅ', 'ょ', 'ッ', 'ャ', 'ュ', 'ョ':
+		return true
+	}
+	return false
+}
+
+func canBreakBefore(items []*item, i int) bool {
+	if i == 0 {
+		return false
+	}
+	cur, prev := items[i], items[i-1]
+	if cur.kind == itemNewline || prev.kind == itemNewline {
+		return true
+	}
+	if cur.kind == itemSpace || prev.kind == itemSpace {
+		return true
+	}
+	if cur.kind == itemGlyph && isNoBreakBefore(cur.r) {
+		return false
+	}
+	return isBreakable(cur) || isBreakable(prev)
+}
+
+func (t *Text) layout(items []*item) {
+	maxWidth := t.options.MaxWidth
+
+	cur := &line{}
+	var x float64
+	wrapped := false
+	newLine := func(wrap bool) {
+		t.lines = append(t.lines, cur)
+		cur = &line{}
+		x = 0
+		wrapped = wrap
+	}
+	place := func(it *item) {
+		it.x = x
+		x += it.width
+		cur.items = append(cur.items, it)
+	}
+
+	for i := 0; i < len(items); {
+		it := items[i]
+		if it.kind == itemNewline {
+			cur.items = append(cur.items, it)
+			newLine(false)
+			i++
+			continue
+		}
+		// Spaces at the beginning of a wrapped line are skipped.
+		if it.kind == itemSpace && wrapped && x == 0 {
+			i++
+			continue
+		}
+
+		// Find the end of the word.
+		j := i + 1
+		for j < len(items) && !canBreakBefore(items, j) {
+			j++
+		}
+		var w float64
+		for _, it := range items[i:j] {
+			w += it.width
+		}
+
+		if maxWidth > 0 && x > 0 && x+w > maxWidth {
+			newLine(true)
+			continue
+		}
+
+		if maxWidth > 0 && w > maxWidth {
+			// The word is longer than a line. Break the word at any position.
+			for _, it := range items[i:j] {
+				if x > 0 && x+it.width > maxWidth {
+					newLine(true)
+				}
+				place(it)
+			}
+		} else {
+			for _, it := range items[i:j] {
+				place(it)
+			}
+		}
+		i = j
+	}
+	// An empty line after wrapping is not needed, while a line after a newline is always needed.
+	if len(cur.items) > 0 || !wrapped {
+		t.lines = append(t.lines, cur)
+	}
+
+	var y float64
+	for i, l := range t.lines {
+		st := style{scale: 1}
+		if len(l.items) == 0 && i > 0 {
+			// An empty line after a newline has the style of the newline.
+			prev := t.lines[i-1].items
+			st = prev[len(prev)-1].style
+		}
+		l.ascent = t.ascent(st)
+		l.descent = t.descent(st)
+		if len(l.items) > 0 {
+			l.ascent, l.descent = 0, 0
+		}
+		for _, it := range l.items {
+			l.ascent = math.Max(l.ascent, t.ascent(it.style))
+			l.descent = math.Max(l.descent, t.descent(it.style))
+			if it.kind == itemRuby {
+				rf, rs := t.rubyFace(it.style)
+				m := rf.Metrics()
+				l.rubyHeight = math.Max(l.rubyHeight, fixedToFloat64(m.Ascent+m.Descent)*rs)
+			}
+			if it.kind != itemSpace && it.kind != itemNewline {
+				l.width = it.x + it.width
+			}
+		}
+
+		if i > 0 {
+			y += t.options.LineSpacing
+		}
+		l.baseline = y + l.rubyHeight + l.ascent
+		y = l.baseline + l.descent
+		t.width = math.Max(t.width, l.width)
+	}
+	t.height = y
+}
+
+// Draw draws the text onto dst.
+//
+// The upper-left corner of the text is at the origin, and options' GeoM transforms the text.
+// options' ColorScale is multiplied with the colors of the text.
+func (t *Text) Draw(dst *ebiten.Image, options *ebiten.DrawImageOptions) {
+	if options == nil {
+		options = &ebiten.DrawImageOptions{}
+	}
+
+	for _, l := range t.lines {
+		for i := 0; i < len(l.items); {
+			it := l.items[i]
+			switch it.kind {
+			case itemGlyph, itemSpace:
+				// Merge the adjacent glyphs in the same style to draw them at once.
+				j := i + 1
+				for j < len(l.items) && (l.items[j].kind == itemGlyph || l.items[j].kind == itemSpace) && l.items[j].style == it.style {
+					j++
+				}
+				rs := make([]rune, 0, j-i)
+				for _, it := range l.items[i:j] {
+					rs = append(rs, it.r)
+				}
+				t.drawString(dst, string(rs), t.face(it.style), it.style, it.style.scale, it.x, l.baseline, options)
+				i = j
+				continue
+			case itemImage:
+				op := &ebiten.DrawImageOptions{}
+				*op = *options
+				op.GeoM.Reset()
+				s := it.image.Bounds().Size()
+				if s.X > 0 && s.Y > 0 {
+					op.GeoM.Scale(it.width/float64(s.X), t.ascent(it.style)/float64(s.Y))
+				}
+				op.GeoM.Translate(it.x, l.baseline-t.ascent(it.style))
+				op.GeoM.Concat(options.GeoM)
+				dst.DrawImage(it.image, op)
+			case itemRuby:
+				face := t.face(it.style)
+				bw := measure(face, it.base) * it.style.scale
+				t.drawString(dst, it.base, face, it.style, it.style.scale, it.x+(it.width-bw)/2, l.baseline, options)
+
+				rf, rs := t.rubyFace(it.style)
+				rw := measure(rf, it.ruby) * rs
+				rb := l.baseline - l.ascent - fixedToFloat64(rf.Metrics().Descent)*rs
+				t.drawString(dst, it.ruby, rf, style{color: it.style.color, scale: rs}, rs, it.x+(it.width-rw)/2, rb, options)
+			}
+			i++
+		}
+	}
+}
+
+func (t *Text) drawString(dst *ebiten.Image, str string, face font.Face, s style, scale float64, x, y float64, options *ebiten.DrawImageOptions) {
+	op := &ebiten.DrawImageOptions{}
+	*op = *options
+	op.GeoM.Reset()
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(x, y)
+	op.GeoM.Concat(options.GeoM)
+
+	clr := s.color
+	if clr == nil {
+		clr = t.options.Color
+	}
+	if clr != nil {
+		op.ColorScale.ScaleWithColor(clr)
+	}
+	text.DrawWithOptions(dst, str, face, op)
+
+	if s.bold && t.options.BoldFace == nil {
+		op.GeoM.Reset()
+		op.GeoM.Scale(scale, scale)
+		op.GeoM.Translate(x+scale, y)
+		op.GeoM.Concat(options.GeoM)
+		text.DrawWithOptions(dst, str, face, op)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package richtext_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/bitmapfont/v2"
+	"golang.org/x/image/font"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/richtext"
+)
+
+func advance(r rune) float64 {
+	a, _ := bitmapfont.Face.GlyphAdvance(r)
+	return float64(a) / (1 << 6)
+}
+
+func TestParseError(t *testing.T) {
+	cases := []string{
+		"[b]foo",
+		"foo[/b]",
+		"[b]foo[/color]",
+		"[color=red]foo[/color]",
+		"[color=#12345]foo[/color]",
+		"[size=0]foo[/size]",
+		"[size=x]foo[/size]",
+		"[unknown]foo[/unknown]",
+		"[ruby=bar]foo",
+		"[ruby=bar][b]foo[/b][/ruby]",
+		"[img=]",
+		"[img=missing]",
+		"[b",
+	}
+	for _, c := range cases {
+		if _, err := richtext.New(c, &richtext.Options{Face: bitmapfont.Face}); err == nil {
+			t.Errorf("richtext.New(%q) must return an error", c)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := []string{
+		"",
+		"foo",
+		"[[foo]",
+		"[b]foo[/b]",
+		"[color=#f00]f[color=#00ff0080]o[/color]o[/color]",
+		"[size=2][b]foo[/b][/size]",
+		"[ruby=かんじ]漢字[/ruby]",
+		"[img=icon] x",
+	}
+	img := ebiten.NewImage(16, 8)
+	for _, c := range cases {
+		if _, err := richtext.New(c, &richtext.Options{
+			Face: bitmapfont.Face,
+			Images: map[string]*ebiten.Image{
+				"icon": img,
+			},
+		}); err != nil {
+			t.Errorf("richtext.New(%q) failed: %v", c, err)
+		}
+	}
+}
+
+func TestSize(t *testing.T) {
+	m := bitmapfont.Face.Metrics()
+	lineHeight := float64(m.Ascent+m.Descent) / (1 << 6)
+
+	txt, err := richtext.New("foo\n[size=2]bar[/size]", &richtext.Options{Face: bitmapfont.Face, LineSpacing: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, h := txt.Size()
+	if want := 2 * (advance('b') + advance('a') + advance('r')); w != want {
+		t.Errorf("width: got: %f, want: %f", w, want)
+	}
+	if want := lineHeight + 3 + 2*lineHeight; h != want {
+		t.Errorf("height: got: %f, want: %f", h, want)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	a := advance('a')
+	cases := []struct {
+		Markup   string
+		MaxWidth float64
+		Lines    int
+	}{
+		{
+			Markup: "aaa aaa aaa",
+			Lines:  1,
+		},
+		{
+			Markup:   "aaa aaa aaa",
+			MaxWidth: 7 * a,
+			Lines:    2,
+		},
+		{
+			Markup:   "aaa aaa aaa",
+			MaxWidth: 3 * a,
+			Lines:    3,
+		},
+		{
+			Markup:   "aa[b]a[/b]a aaa",
+			MaxWidth: 5 * a,
+			Lines:    2,
+		},
+		{
+			// A word longer than a line is broken at any position.
+			Markup:   "aaaaaaaa",
+			MaxWidth: 3 * a,
+			Lines:    3,
+		},
+		{
+			Markup:   "aaa\n\naaa",
+			MaxWidth: 3 * a,
+			Lines:    3,
+		},
+		{
+			// CJK characters can be broken at any position.
+			Markup:   "あいうえお",
+			MaxWidth: 2 * advance('あ'),
+			Lines:    3,
+		},
+		{
+			// A line must not start with '。'.
+			Markup:   "あい。",
+			MaxWidth: 2 * advance('あ'),
+			Lines:    2,
+		},
+	}
+	for _, c := range cases {
+		txt, err := richtext.New(c.Markup, &richtext.Options{Face: bitmapfont.Face, MaxWidth: c.MaxWidth})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := txt.LineCount(), c.Lines; got != want {
+			t.Errorf("richtext.New(%q) with MaxWidth %f: LineCount(): got: %d, want: %d", c.Markup, c.MaxWidth, got, want)
+		}
+		if c.MaxWidth > 0 {
+			if w, _ := txt.Size(); w > c.MaxWidth {
+				t.Errorf("richtext.New(%q) with MaxWidth %f: width: got: %f", c.Markup, c.MaxWidth, w)
+			}
+		}
+	}
+}
+
+func TestRuby(t *testing.T) {
+	m := bitmapfont.Face.Metrics()
+	lineHeight := float64(m.Ascent+m.Descent) / (1 << 6)
+
+	txt, err := richtext.New("[ruby=abcdefgh]字[/ruby]", &richtext.Options{Face: bitmapfont.Face})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, h := txt.Size()
+	if want := float64(font.MeasureString(bitmapfont.Face, "abcdefgh")) / (1 << 6) / 2; w != want {
+		t.Errorf("width: got: %f, want: %f", w, want)
+	}
+	if want := lineHeight * 1.5; h != want {
+		t.Errorf("height: got: %f, want: %f", h, want)
+	}
+}
@@ -115,9 +115,8 @@ func getGlyphImage(face font.Face, r rune, offset fixed.Point26_6) *ebiten.Image
 		return e.image
 	}
 
-	b := getGlyphBounds(face, r)
-	w, h := (b.Max.X - b.Min.X).Ceil(), (b.Max.Y - b.Min.Y).Ceil()
-	if w == 0 || h == 0 {
+	rgba := rasterizeGlyph(face, r, offset)
+	if rgba == nil {
 		glyphImageCache[face][key] = &glyphImageCacheEntry{
 			image: nil,
 			atime: now(),
@@ -125,6 +124,24 @@ func getGlyphImage(face font.Face, r rune, offset fixed.Point26_6) *ebiten.Image
 		return nil
 	}
 
+	img := ebiten.NewImageFromImage(rgba)
+	glyphImageCache[face][key] = &glyphImageCacheEntry{
+		image: img,
+		atime: now(),
+	}
+
+	return img
+}
+
+// rasterizeGlyph renders the glyph for r with the given sub-pixel offset onto a new RGBA image.
+// rasterizeGlyph returns nil if the glyph has no pixels.
+func rasterizeGlyph(face font.Face, r rune, offset fixed.Point26_6) *image.RGBA {
+	b := getGlyphBounds(face, r)
+	w, h := (b.Max.X - b.Min.X).Ceil(), (b.Max.Y - b.Min.Y).Ceil()
+	if w == 0 || h == 0 {
+		return nil
+	}
+
 	if b.Min.X&((1<<6)-1) != 0 {
 		w++
 	}
@@ -145,13 +162,7 @@ func getGlyphImage(face font.Face, r rune, offset fixed.Point26_6) *ebiten.Image
 	d.Dot = fixed.Point26_6{X: x, Y: y}
 	d.DrawString(string(r))
 
-	img := ebiten.NewImageFromImage(rgba)
-	glyphImageCache[face][key] = &glyphImageCacheEntry{
-		image: img,
-		atime: now(),
-	}
-
-	return img
+	return rgba
 }
 
 var textM sync.Mutex
@@ -235,6 +246,15 @@ func DrawWithOptions(dst *ebiten.Image, text string, face font.Face, options *eb
 	textM.Lock()
 	defer textM.Unlock()
 
+	forEachGlyph(text, face, func(r rune, topleft, offset fixed.Point26_6) {
+		drawGlyph(dst, getGlyphImage(face, r, offset), topleft, options)
+	})
+	cleanCache(face)
+}
+
+// forEachGlyph calls f for each glyph of text with the glyph's top-left position relative to the origin and
+// the sub-pixel offset to render the glyph.
+func forEachGlyph(text string, face font.Face, f func(r rune, topleft, offset fixed.Point26_6)) {
 	var dx, dy fixed.Int26_6
 	prevR := rune(-1)
 
@@ -258,16 +278,18 @@ func DrawWithOptions(dst *ebiten.Image, text string, face font.Face, options *eb
 			X: (adjustOffsetGranularity(dx) + b.Min.X) & ((1 << 6) - 1),
 			Y: b.Min.Y & ((1 << 6) - 1),
 		}
-		img := getGlyphImage(face, r, offset)
-		drawGlyph(dst, img, fixed.Point26_6{
+		f(r, fixed.Point26_6{
 			X: dx + b.Min.X - offset.X,
 			Y: dy + b.Min.Y - offset.Y,
-		}, options)
+		}, offset)
 		dx += glyphAdvance(face, r)
 
 		prevR = r
 	}
+}
 
+// cleanCache removes old glyph images of the face from the caches.
+func cleanCache(face font.Face) {
 	// cacheSoftLimit indicates the soft limit of the number of glyphs in the cache.
 	// If the number of glyphs exceeds this soft limits, old glyphs are removed.
 	// Even after cleaning up the cache, the number of glyphs might still exceed the soft limit, but
@@ -283,6 +305,13 @@ func DrawWithOptions(dst *ebiten.Image, text string, face font.Face, options *eb
 			}
 		}
 	}
+	if len(effectImageCache[face]) > cacheSoftLimit {
+		for k, e := range effectImageCache[face] {
+			if e.atime < now()-60 {
+				delete(effectImageCache[face], k)
+			}
+		}
+	}
 }
 
 // BoundString returns the measured size of a given string using a given font.
@@ -146,3 +146,45 @@ func TestTextOverlap(t *testing.T) {
 		}
 	}
 }
+
+func TestEffectMask(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 3, 3))
+	src.Set(1, 1, color.White)
+
+	// Dilation.
+	got, padding := text.EffectMaskForTesting(src, 2, 0)
+	if padding != 3 {
+		t.Errorf("padding: got %d, want: %d", padding, 3)
+	}
+	if w, h := got.Bounds().Dx(), got.Bounds().Dy(); w != 9 || h != 9 {
+		t.Errorf("size: got (%d, %d), want: (%d, %d)", w, h, 9, 9)
+	}
+	c := 1 + padding
+	for _, p := range []image.Point{{c, c}, {c + 2, c}, {c, c - 2}, {c + 1, c + 1}} {
+		if a := got.AlphaAt(p.X, p.Y).A; a != 0xff {
+			t.Errorf("dilation: AlphaAt(%d, %d): got %d, want: %d", p.X, p.Y, a, 0xff)
+		}
+	}
+	for _, p := range []image.Point{{c + 3, c}, {c + 3, c + 3}, {0, 0}} {
+		if a := got.AlphaAt(p.X, p.Y).A; a != 0 {
+			t.Errorf("dilation: AlphaAt(%d, %d): got %d, want: %d", p.X, p.Y, a, 0)
+		}
+	}
+
+	// Blur.
+	got, padding = text.EffectMaskForTesting(src, 0, 3)
+	if padding != 3 {
+		t.Errorf("padding: got %d, want: %d", padding, 3)
+	}
+	c = 1 + padding
+	var sum int
+	for _, v := range got.Pix {
+		sum += int(v)
+	}
+	if sum < 0xff-16 || sum > 0xff+16 {
+		t.Errorf("blur: sum of alpha: got %d, want: about %d", sum, 0xff)
+	}
+	if a0, a1 := got.AlphaAt(c, c).A, got.AlphaAt(c+2, c).A; a0 <= a1 || a1 == 0 {
+		t.Errorf("blur: AlphaAt(%d, %d) = %d and AlphaAt(%d, %d) = %d must be positive and decreasing", c, c, a0, c+2, c, a1)
+	}
+}
@@ -32,6 +32,10 @@ func init() {
 }
 
 func drawVerticesForUtil(dst *ebiten.Image, vs []ebiten.Vertex, is []uint16, clr color.Color) {
+	drawVerticesForUtilWithFillRule(dst, vs, is, clr, ebiten.FillAll)
+}
+
+func drawVerticesForUtilWithFillRule(dst *ebiten.Image, vs []ebiten.Vertex, is []uint16, clr color.Color, fillRule ebiten.FillRule) {
 	r, g, b, a := clr.RGBA()
 	for i := range vs {
 		vs[i].SrcX = 1
@@ -45,6 +49,7 @@ func drawVerticesForUtil(dst *ebiten.Image, vs []ebiten.Vertex, is []uint16, clr
 	op := &ebiten.DrawTrianglesOptions{}
 	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
 	op.AntiAlias = true
+	op.FillRule = fillRule
 	dst.DrawTriangles(vs, is, whiteSubImage, op)
 }
 
@@ -60,6 +65,17 @@ func StrokeLine(dst *ebiten.Image, x0, y0, x1, y1 float32, strokeWidth float32,
 	drawVerticesForUtil(dst, vs, is, clr)
 }
 
+// StrokePath strokes the specified path with the specified color and stroke options.
+// The stroke's width, line caps, line joins, and miter limit are specified by options.
+// The edges are rendered with anti-aliasing.
+//
+// clr has be to be a solid (non-transparent) color.
+func StrokePath(dst *ebiten.Image, path *Path, clr color.Color, options *StrokeOptions) {
+	vs, is := path.AppendVerticesAndIndicesForStroke(nil, nil, options)
+
+	drawVerticesForUtil(dst, vs, is, clr)
+}
+
 // DrawFilledRect fills a rectangle with the specified width and color.
 func DrawFilledRect(dst *ebiten.Image, x, y, width, height float32, clr color.Color) {
 	var path Path
@@ -114,3 +130,58 @@ func StrokeCircle(dst *ebiten.Image, cx, cy, r float32, strokeWidth float32, clr
 
 	drawVerticesForUtil(dst, vs, is, clr)
 }
+
+// DrawFilledRoundedRect fills a rectangle with rounded corners with the specified radius (r) and color.
+// r is limited to the half of the shorter side.
+func DrawFilledRoundedRect(dst *ebiten.Image, x, y, width, height, r float32, clr color.Color) {
+	var path Path
+	appendRoundedRect(&path, x, y, width, height, r)
+	vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
+
+	drawVerticesForUtil(dst, vs, is, clr)
+}
+
+// StrokeRoundedRect strokes a rectangle with rounded corners with the specified radius (r), width and color.
+// r is limited to the half of the shorter side.
+//
+// clr has be to be a solid (non-transparent) color.
+func StrokeRoundedRect(dst *ebiten.Image, x, y, width, height, r float32, strokeWidth float32, clr color.Color) {
+	var path Path
+	appendRoundedRect(&path, x, y, width, height, r)
+
+	strokeOp := &StrokeOptions{}
+	strokeOp.Width = strokeWidth
+	vs, is := path.AppendVerticesAndIndicesForStroke(nil, nil, strokeOp)
+
+	drawVerticesForUtil(dst, vs, is, clr)
+}
+
+func appendRoundedRect(path *Path, x, y, width, height, r float32) {
+	r = float32(math.Min(float64(r), math.Min(float64(width), float64(height))/2))
+	if r <= 0 {
+		path.MoveTo(x, y)
+		path.LineTo(x, y+height)
+		path.LineTo(x+width, y+height)
+		path.LineTo(x+width, y)
+		path.Close()
+		return
+	}
+
+	path.MoveTo(x, y+r)
+	path.Arc(x+r, y+r, r, math.Pi, 3*math.Pi/2, Clockwise)
+	path.Arc(x+width-r, y+r, r, 3*math.Pi/2, 2*math.Pi, Clockwise)
+	path.Arc(x+width-r, y+height-r, r, 0, math.Pi/2, Clockwise)
+	path.Arc(x+r, y+height-r, r, math.Pi/2, math.Pi, Clockwise)
+	path.Close()
+}
+
+// DrawFilledPath fills the specified path with the specified color.
+// The path can be a complex polygon like a concave polygon, a polygon with holes, or a self-intersecting polygon,
+// and is filled with the even-odd rule. For example, a pie or a ring can be drawn with Arc.
+//
+// clr has be to be a solid (non-transparent) color.
+func DrawFilledPath(dst *ebiten.Image, path *Path, clr color.Color) {
+	vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
+
+	drawVerticesForUtilWithFillRule(dst, vs, is, clr, ebiten.EvenOdd)
+}
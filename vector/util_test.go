@@ -0,0 +1,273 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vector_test
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	t "github.com/hajimehoshi/ebiten/v2/internal/testing"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+func TestMain(m *testing.M) {
+	ui.SetPanicOnErrorOnReadingPixelsForTesting(true)
+	t.MainWithRunLoop(m)
+}
+
+type bounds struct {
+	minX, minY, maxX, maxY float32
+}
+
+func verticesBounds(vs []ebiten.Vertex) bounds {
+	b := bounds{
+		minX: math.MaxFloat32,
+		minY: math.MaxFloat32,
+		maxX: -math.MaxFloat32,
+		maxY: -math.MaxFloat32,
+	}
+	for _, v := range vs {
+		b.minX = float32(math.Min(float64(b.minX), float64(v.DstX)))
+		b.minY = float32(math.Min(float64(b.minY), float64(v.DstY)))
+		b.maxX = float32(math.Max(float64(b.maxX), float64(v.DstX)))
+		b.maxY = float32(math.Max(float64(b.maxY), float64(v.DstY)))
+	}
+	return b
+}
+
+func (b bounds) near(other bounds) bool {
+	const eps = 1e-3
+	return math.Abs(float64(b.minX-other.minX)) < eps &&
+		math.Abs(float64(b.minY-other.minY)) < eps &&
+		math.Abs(float64(b.maxX-other.maxX)) < eps &&
+		math.Abs(float64(b.maxY-other.maxY)) < eps
+}
+
+func checkIndices(t *testing.T, vs []ebiten.Vertex, is []uint16) {
+	t.Helper()
+	if len(is)%3 != 0 {
+		t.Errorf("len(indices) must be a multiple of 3 but %d", len(is))
+	}
+	for _, idx := range is {
+		if int(idx) >= len(vs) {
+			t.Errorf("index %d is out of range [0, %d)", idx, len(vs))
+		}
+	}
+}
+
+// polyline returns an open path (0, 0)-(10, 0)-(10, 10) with a right-angle corner.
+func polyline() *vector.Path {
+	var path vector.Path
+	path.MoveTo(0, 0)
+	path.LineTo(10, 0)
+	path.LineTo(10, 10)
+	return &path
+}
+
+func TestStrokePath(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+
+	for _, tc := range []struct {
+		op vector.StrokeOptions
+		// capped reports whether the pixels beyond the path's ends are rendered.
+		capped bool
+	}{
+		{
+			op:     vector.StrokeOptions{Width: 4, LineJoin: vector.LineJoinMiter, MiterLimit: 10},
+			capped: false,
+		},
+		{
+			op:     vector.StrokeOptions{Width: 4, LineJoin: vector.LineJoinMiter, MiterLimit: 10, LineCap: vector.LineCapSquare},
+			capped: true,
+		},
+	} {
+		tc := tc
+		t.Run(fmt.Sprintf("%+v", tc.op), func(t *testing.T) {
+			dst := ebiten.NewImage(16, 20)
+
+			// A path (2, 4)-(12, 4)-(12, 16) makes a horizontal band y in [2, 6] and a vertical band x in [10, 14].
+			var path vector.Path
+			path.MoveTo(2, 4)
+			path.LineTo(12, 4)
+			path.LineTo(12, 16)
+			vector.StrokePath(dst, &path, red, &tc.op)
+
+			for _, p := range []struct {
+				x, y int
+				want color.RGBA
+			}{
+				// The horizontal segment.
+				{x: 2, y: 2, want: red},
+				{x: 6, y: 5, want: red},
+				// The miter join at the outer corner.
+				{x: 13, y: 2, want: red},
+				// The vertical segment.
+				{x: 10, y: 10, want: red},
+				{x: 13, y: 15, want: red},
+				// Outside the stroke.
+				{x: 6, y: 1, want: color.RGBA{}},
+				{x: 6, y: 6, want: color.RGBA{}},
+				{x: 9, y: 10, want: color.RGBA{}},
+				{x: 14, y: 10, want: color.RGBA{}},
+			} {
+				if got := dst.At(p.x, p.y).(color.RGBA); got != p.want {
+					t.Errorf("dst.At(%d, %d): got: %v, want: %v", p.x, p.y, got, p.want)
+				}
+			}
+
+			// Square caps extend the ends by the half of the width, and butt caps don't.
+			var want color.RGBA
+			if tc.capped {
+				want = red
+			}
+			for _, p := range []image.Point{{X: 0, Y: 4}, {X: 1, Y: 3}, {X: 11, Y: 17}, {X: 12, Y: 16}} {
+				if got := dst.At(p.X, p.Y).(color.RGBA); got != want {
+					t.Errorf("dst.At(%d, %d): got: %v, want: %v", p.X, p.Y, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRoundedRectWithoutRadius(t *testing.T) {
+	for _, r := range []float32{0, -1} {
+		var path vector.Path
+		vector.AppendRoundedRectForTesting(&path, 1, 2, 20, 10, r)
+
+		// A rectangle has 4 points and the closing point.
+		vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
+		if got, want := len(vs), 5; got != want {
+			t.Errorf("r: %v, len(vertices) for filling: got: %d, want: %d", r, got, want)
+		}
+		if got, want := verticesBounds(vs), (bounds{minX: 1, minY: 2, maxX: 21, maxY: 12}); !got.near(want) {
+			t.Errorf("r: %v, bounds for filling: got: %+v, want: %+v", r, got, want)
+		}
+		checkIndices(t, vs, is)
+
+		// The zero miter limit is always exceeded, so 4 segments are joined with 4 bevel joins.
+		vs, is = path.AppendVerticesAndIndicesForStroke(nil, nil, &vector.StrokeOptions{Width: 2})
+		if got, want := len(vs), 4*4+4*3; got != want {
+			t.Errorf("r: %v, len(vertices) for stroke: got: %d, want: %d", r, got, want)
+		}
+		if got, want := len(is), 4*6+4*3; got != want {
+			t.Errorf("r: %v, len(indices) for stroke: got: %d, want: %d", r, got, want)
+		}
+		if got, want := verticesBounds(vs), (bounds{minX: 0, minY: 1, maxX: 22, maxY: 13}); !got.near(want) {
+			t.Errorf("r: %v, bounds for stroke: got: %+v, want: %+v", r, got, want)
+		}
+		checkIndices(t, vs, is)
+	}
+}
+
+func TestRoundedRectBounds(t *testing.T) {
+	for _, r := range []float32{2, 5} {
+		var path vector.Path
+		vector.AppendRoundedRectForTesting(&path, 1, 2, 20, 10, r)
+
+		// The arcs' ends are on the edges of the rectangle.
+		vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
+		if len(vs) <= 5 {
+			t.Errorf("r: %v, len(vertices) for filling must be more than 5 but %d", r, len(vs))
+		}
+		if got, want := verticesBounds(vs), (bounds{minX: 1, minY: 2, maxX: 21, maxY: 12}); !got.near(want) {
+			t.Errorf("r: %v, bounds for filling: got: %+v, want: %+v", r, got, want)
+		}
+		checkIndices(t, vs, is)
+
+		// The left and right sides have no straight edges when r is 5, so only the top and bottom bounds are exact.
+		vs, is = path.AppendVerticesAndIndicesForStroke(nil, nil, &vector.StrokeOptions{Width: 2})
+		const eps = 1e-3
+		b := verticesBounds(vs)
+		if b.minX < -eps || b.minY < 1-eps || b.maxX > 22+eps || b.maxY > 13+eps {
+			t.Errorf("r: %v, the bounds for stroke %+v must be within the half of the width from the path", r, b)
+		}
+		if math.Abs(float64(b.minY-1)) > eps || math.Abs(float64(b.maxY-13)) > eps {
+			t.Errorf("r: %v, the bounds for stroke %+v must reach the top and the bottom edges", r, b)
+		}
+		checkIndices(t, vs, is)
+	}
+}
+
+func TestRoundedRectRadiusLimit(t *testing.T) {
+	// The radius is limited to the half of the shorter side.
+	var path0 vector.Path
+	vector.AppendRoundedRectForTesting(&path0, 1, 2, 20, 10, 100)
+	vs0, is0 := path0.AppendVerticesAndIndicesForFilling(nil, nil)
+
+	var path1 vector.Path
+	vector.AppendRoundedRectForTesting(&path1, 1, 2, 20, 10, 5)
+	vs1, is1 := path1.AppendVerticesAndIndicesForFilling(nil, nil)
+
+	if len(vs0) != len(vs1) || len(is0) != len(is1) {
+		t.Fatalf("len(vertices), len(indices): got: %d, %d, want: %d, %d", len(vs0), len(is0), len(vs1), len(is1))
+	}
+	for i := range vs0 {
+		if vs0[i] != vs1[i] {
+			t.Errorf("vertices[%d]: got: %+v, want: %+v", i, vs0[i], vs1[i])
+		}
+	}
+}
+
+func TestFilledPathRing(t *testing.T) {
+	appendCircle := func(path *vector.Path, r float32) {
+		path.MoveTo(10+r, 10)
+		path.Arc(10, 10, r, 0, 2*math.Pi, vector.Clockwise)
+		path.Close()
+	}
+
+	var outer vector.Path
+	appendCircle(&outer, 8)
+	outerVs, _ := outer.AppendVerticesAndIndicesForFilling(nil, nil)
+
+	// A ring is two circles. With the even-odd rule, the inner circle becomes a hole.
+	var ring vector.Path
+	appendCircle(&ring, 8)
+	appendCircle(&ring, 4)
+	vs, is := ring.AppendVerticesAndIndicesForFilling(nil, nil)
+
+	// Both circles are split into the same number of curves.
+	if got, want := len(vs), 2*len(outerVs); got != want {
+		t.Errorf("len(vertices): got: %d, want: %d", got, want)
+	}
+	if got, want := verticesBounds(vs), (bounds{minX: 2, minY: 2, maxX: 18, maxY: 18}); !got.near(want) {
+		t.Errorf("bounds: got: %+v, want: %+v", got, want)
+	}
+	checkIndices(t, vs, is)
+
+	// Each triangle must belong to either circle.
+	n := uint16(len(outerVs))
+	for i := 0; i+2 < len(is); i += 3 {
+		if (is[i] < n) != (is[i+1] < n) || (is[i] < n) != (is[i+2] < n) {
+			t.Errorf("triangle %d has vertices from both circles: %v", i/3, is[i:i+3])
+		}
+	}
+}
+
+func TestDrawRoundedRectAndFilledPath(t *testing.T) {
+	dst := ebiten.NewImage(16, 16)
+
+	// These must not panic even with degenerated shapes.
+	vector.DrawFilledRoundedRect(dst, 1, 2, 10, 8, 3, color.White)
+	vector.DrawFilledRoundedRect(dst, 1, 2, 0, 0, 3, color.White)
+	vector.StrokeRoundedRect(dst, 1, 2, 10, 8, 3, 2, color.White)
+	vector.StrokeRoundedRect(dst, 1, 2, 10, 8, -1, 2, color.White)
+	vector.DrawFilledPath(dst, polyline(), color.White)
+	vector.DrawFilledPath(dst, &vector.Path{}, color.White)
+}
@@ -267,7 +267,8 @@ func MinimizeWindow() {
 
 // IsWindowMinimized reports whether the window is minimized or not.
 //
-// IsWindowMinimized always returns false on browsers and mobiles.
+// On browsers, IsWindowMinimized reports whether the page is hidden, e.g., the tab is in the background.
+// IsWindowMinimized always returns false on mobiles.
 //
 // IsWindowMinimized is concurrent-safe.
 func IsWindowMinimized() bool {